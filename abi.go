@@ -0,0 +1,202 @@
+package symbolizer
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ABIType describes the type of a parameter in a Solidity/EVM ABI signature.
+// Tuple types have the name "tuple" and describe their members with Components.
+type ABIType struct {
+	// Name is the elementary type name (such as uint256 or address) or "tuple"
+	Name string
+	// Label is the parameter name that followed the type, if any
+	Label string
+	// Components are the member types of a tuple type
+	Components []ABIType
+	// Dimensions are the array dimensions of the type in the order they appear.
+	// Dynamically sized dimensions (such as in uint256[]) are represented as -1.
+	Dimensions []int
+	// Position is the byte offset of the type within the input
+	Position int
+}
+
+// String returns the canonical representation of the ABIType, as used for computing selectors.
+// Tuples are rendered as their parenthesized component list, parameter labels are omitted and
+// the aliases of elementary types are expanded (such as uint into uint256, see abiAliases).
+func (typ ABIType) String() string {
+	var builder strings.Builder
+
+	switch alias, ok := abiAliases[typ.Name]; {
+	case typ.Name == "tuple":
+		builder.WriteString("(" + joinABITypes(typ.Components) + ")")
+	case ok:
+		builder.WriteString(alias)
+	default:
+		builder.WriteString(typ.Name)
+	}
+
+	for _, dimension := range typ.Dimensions {
+		if dimension < 0 {
+			builder.WriteString("[]")
+		} else {
+			builder.WriteString("[" + strconv.Itoa(dimension) + "]")
+		}
+	}
+
+	return builder.String()
+}
+
+// ABISignature describes a parsed Solidity/EVM ABI method signature
+type ABISignature struct {
+	Name   string
+	Inputs []ABIType
+}
+
+// String returns the canonical signature such as `transfer(address,uint256)`. The 4-byte method
+// selector is the first 4 bytes of the Keccak-256 hash of this canonical signature.
+func (signature ABISignature) String() string {
+	return signature.Name + "(" + joinABITypes(signature.Inputs) + ")"
+}
+
+// ParseABISignature parses a Solidity/EVM ABI method signature such as `transfer(address,uint256)`
+// into its method name and parameter types. Tuple parameters (including nested tuples, written either as
+// `(uint256,address)` or `tuple(uint256,address)`) are resolved into their components and array dimensions
+// (fixed or dynamic) are supported for all types.
+// Parameter labels (such as in `transfer(address to, uint256 amount)`) and data locations (such as in
+// `store(bytes calldata data)`) are permitted, but are not part of the canonical signature. Array
+// dimensions must be unsigned decimal numerics, such that `uint256[+3]` is rejected.
+// Options such as MaxDepth can be provided to limit the nesting depth of tuples.
+func ParseABISignature(input string, opts ...ParserOption) (ABISignature, error) {
+	parser := NewParser(input, append([]ParserOption{IgnoreWhitespaces()}, opts...)...)
+
+	// Collect the method name
+	if !parser.IsCursor(TokenIdent) {
+		return ABISignature{}, parser.errorf(parser.curr.Position, "expected method name, found '%v'", parser.curr.Literal)
+	}
+
+	signature := ABISignature{Name: parser.curr.Literal}
+	parser.Advance()
+
+	// Collect the parameter types from within the parenthesis
+	inputs, err := parser.enclosedParser(EnclosureParens())
+	if err != nil {
+		return ABISignature{}, err
+	}
+
+	if signature.Inputs, err = inputs.parseABITypes(); err != nil {
+		return ABISignature{}, err
+	}
+
+	if !parser.Exhausted() {
+		return ABISignature{}, parser.errorf(parser.curr.Position, "unexpected token after signature: '%v'", parser.curr.Literal)
+	}
+
+	return signature, nil
+}
+
+// abiAliases are the canonical names of the elementary types that have an alias
+var abiAliases = map[string]string{"uint": "uint256", "int": "int256", "fixed": "fixed128x18", "ufixed": "ufixed128x18"}
+
+// abiDataLocations are the data locations that may follow the type of a parameter
+var abiDataLocations = map[string]bool{"memory": true, "calldata": true, "storage": true}
+
+// parseABITypes parses the remaining contents of the parser as a comma separated list of ABITypes
+func (parser *Parser) parseABITypes() (types []ABIType, err error) {
+	for !parser.Exhausted() {
+		typ, err := parser.parseABIType()
+		if err != nil {
+			return nil, err
+		}
+
+		// Skip the data location, if any
+		if parser.IsCursor(TokenIdent) && abiDataLocations[parser.curr.Literal] {
+			parser.Advance()
+		}
+
+		// Collect the parameter label, if any
+		if parser.IsCursor(TokenIdent) {
+			typ.Label = parser.curr.Literal
+			parser.Advance()
+		}
+
+		types = append(types, typ)
+
+		switch {
+		case parser.Exhausted():
+		case parser.IsCursor(',') && !parser.IsPeek(TokenEoF):
+			parser.Advance()
+		default:
+			return nil, parser.errorf(parser.curr.Position, "expected parameter delimiter, found '%v'", parser.curr.Literal)
+		}
+	}
+
+	return types, nil
+}
+
+// parseABIType parses an ABIType and its array dimensions beginning at the cursor
+func (parser *Parser) parseABIType() (ABIType, error) {
+	typ := ABIType{Position: parser.curr.Position}
+
+	switch {
+	// Explicit Tuple Type (such as tuple(uint256,address))
+	case parser.IsCursor(TokenIdent) && parser.curr.Literal == "tuple" && parser.IsPeek('('):
+		parser.Advance()
+		fallthrough
+
+	// Tuple Type
+	case parser.IsCursor('('):
+		components, err := parser.enclosedParser(EnclosureParens())
+		if err != nil {
+			return ABIType{}, err
+		}
+
+		if typ.Components, err = components.parseABITypes(); err != nil {
+			return ABIType{}, err
+		}
+
+		typ.Name = "tuple"
+
+	// Elementary Type
+	case parser.IsCursor(TokenIdent):
+		typ.Name = parser.curr.Literal
+		parser.Advance()
+
+	default:
+		return ABIType{}, parser.errorf(parser.curr.Position, "expected parameter type, found '%v'", parser.curr.Literal)
+	}
+
+	// Collect the array dimensions
+	for parser.IsCursor('[') {
+		dimension := -1
+
+		if parser.ExpectPeek(TokenNumber) {
+			// Dimensions are unsigned, ParseUint rejects any sign
+			size, err := strconv.ParseUint(parser.curr.Literal, 10, strconv.IntSize-1)
+			if err != nil {
+				return ABIType{}, parser.errorf(parser.curr.Position, "invalid array dimension: '%v'", parser.curr.Literal)
+			}
+
+			dimension = int(size)
+		}
+
+		if !parser.ExpectPeek(']') {
+			return ABIType{}, parser.errorf(parser.next.Position, "missing end of array dimension: ']'")
+		}
+
+		parser.Advance()
+		typ.Dimensions = append(typ.Dimensions, dimension)
+	}
+
+	return typ, nil
+}
+
+// joinABITypes joins the canonical representations of the given ABITypes with commas
+func joinABITypes(types []ABIType) string {
+	canonical := make([]string, 0, len(types))
+	for _, typ := range types {
+		canonical = append(canonical, typ.String())
+	}
+
+	return strings.Join(canonical, ",")
+}
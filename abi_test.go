@@ -0,0 +1,96 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseABISignature(t *testing.T) {
+	tests := []struct {
+		input     string
+		output    ABISignature
+		canonical string
+		error     string
+	}{
+		{
+			"transfer(address,uint256)",
+			ABISignature{"transfer", []ABIType{{Name: "address", Position: 9}, {Name: "uint256", Position: 17}}},
+			"transfer(address,uint256)", "",
+		},
+		{
+			"pause()",
+			ABISignature{"pause", nil},
+			"pause()", "",
+		},
+		{
+			"transfer(address to, uint256 amount)",
+			ABISignature{"transfer", []ABIType{{Name: "address", Label: "to", Position: 9}, {Name: "uint256", Label: "amount", Position: 21}}},
+			"transfer(address,uint256)", "",
+		},
+		{
+			"submit((uint8,(bytes32,address[])[2])[],bytes[3][])",
+			ABISignature{"submit", []ABIType{
+				{Name: "tuple", Position: 7, Dimensions: []int{-1}, Components: []ABIType{
+					{Name: "uint8", Position: 8},
+					{Name: "tuple", Position: 14, Dimensions: []int{2}, Components: []ABIType{
+						{Name: "bytes32", Position: 15},
+						{Name: "address", Position: 23, Dimensions: []int{-1}},
+					}},
+				}},
+				{Name: "bytes", Position: 40, Dimensions: []int{3, -1}},
+			}},
+			"submit((uint8,(bytes32,address[])[2])[],bytes[3][])", "",
+		},
+		{
+			"store(bytes calldata data, string memory, uint256[] storage values)",
+			ABISignature{"store", []ABIType{
+				{Name: "bytes", Label: "data", Position: 6},
+				{Name: "string", Position: 27},
+				{Name: "uint256", Label: "values", Position: 42, Dimensions: []int{-1}},
+			}},
+			"store(bytes,string,uint256[])", "",
+		},
+		{
+			"transfer(address,uint)",
+			ABISignature{"transfer", []ABIType{{Name: "address", Position: 9}, {Name: "uint", Position: 17}}},
+			"transfer(address,uint256)", "",
+		},
+		{
+			"swap(int,fixed,ufixed[2])",
+			ABISignature{"swap", []ABIType{{Name: "int", Position: 5}, {Name: "fixed", Position: 9}, {Name: "ufixed", Position: 15, Dimensions: []int{2}}}},
+			"swap(int256,fixed128x18,ufixed128x18[2])", "",
+		},
+		{
+			"submit(tuple(uint,address)[],bytes)",
+			ABISignature{"submit", []ABIType{
+				{Name: "tuple", Position: 7, Dimensions: []int{-1}, Components: []ABIType{{Name: "uint", Position: 13}, {Name: "address", Position: 18}}},
+				{Name: "bytes", Position: 29},
+			}},
+			"submit((uint256,address)[],bytes)", "",
+		},
+		{"(address)", ABISignature{}, "", "expected method name, found '('"},
+		{"transfer", ABISignature{}, "", "missing start of enclosure: '('"},
+		{"transfer(address,uint256", ABISignature{}, "", "missing end of enclosure: ')'"},
+		{"transfer(address,)", ABISignature{}, "", "expected parameter delimiter, found ','"},
+		{"transfer(address;uint256)", ABISignature{}, "", "expected parameter delimiter, found ';'"},
+		{"transfer(uint256[x])", ABISignature{}, "", "missing end of array dimension: ']'"},
+		{"transfer(uint256[-2])", ABISignature{}, "", "invalid array dimension: '-2'"},
+		{"transfer(uint256[+3])", ABISignature{}, "", "invalid array dimension: '+3'"},
+		{"transfer(,)", ABISignature{}, "", "expected parameter type, found ','"},
+		{"transfer(address) view", ABISignature{}, "", "unexpected token after signature: 'view'"},
+	}
+
+	for _, test := range tests {
+		signature, err := ParseABISignature(test.input)
+
+		if test.error != "" {
+			assert.EqualError(t, err, test.error, test.input)
+			continue
+		}
+
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.output, signature, test.input)
+		assert.Equal(t, test.canonical, signature.String())
+	}
+}
@@ -0,0 +1,190 @@
+package symbolizer
+
+import "math"
+
+// EvalArithmetic evaluates an arithmetic expression such as `2 * (width + margin) ^ 2 / 3` with the given values of
+// its identifiers. The binary operators '+', '-', '*', '/', '%' (the floating point remainder) and '^' (the power,
+// which is right-associative) are supported with the usual precedence, along with unary '-' and '+', parenthesis,
+// numerics (including fractional and scientific numerics such as 1.5e3) and identifiers. Unary operators bind
+// looser than '^', such that -2^2 is -4. Whitespace between the components of the expression is ignored.
+// Returns an error if the expression is malformed, refers to an identifier that is not defined in vars,
+// or divides by zero.
+func EvalArithmetic(input string, vars map[string]float64) (float64, error) {
+	parser := NewParser(input, IgnoreWhitespaces(), ScientificNumbers(), Use(splitSigns))
+
+	value, err := parser.evalArithmetic(0, vars)
+	if err != nil {
+		return 0, err
+	}
+
+	if !parser.Exhausted() {
+		return 0, parser.errorf(parser.curr.Position, "unexpected token after expression: %v", describeToken(parser.curr))
+	}
+
+	return value, nil
+}
+
+// Binding powers of the arithmetic operators, in increasing order of precedence
+const (
+	powerSum     = 10
+	powerProduct = 20
+	powerUnary   = 30
+	powerPower   = 40
+)
+
+// arithmeticPower returns the binding power of the arithmetic binary operator of the TokenKind,
+// which is 0 if the TokenKind is not a binary operator
+func arithmeticPower(kind TokenKind) int {
+	switch kind {
+	case '+', '-':
+		return powerSum
+	case '*', '/', '%':
+		return powerProduct
+	case '^':
+		return powerPower
+	default:
+		return 0
+	}
+}
+
+// evalArithmetic evaluates the arithmetic expression at the cursor until an operator
+// that binds as loose as the given binding power, with top-down operator precedence
+func (parser *Parser) evalArithmetic(power int, vars map[string]float64) (float64, error) {
+	left, err := parser.evalOperand(vars)
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		op := parser.curr
+
+		binding := arithmeticPower(op.Kind)
+		if binding <= power {
+			return left, nil
+		}
+
+		// The power operator is right-associative
+		if op.Kind == '^' {
+			binding--
+		}
+
+		parser.Advance()
+
+		right, err := parser.evalArithmetic(binding, vars)
+		if err != nil {
+			return 0, err
+		}
+
+		switch op.Kind {
+		case '+':
+			left += right
+		case '-':
+			left -= right
+		case '*':
+			left *= right
+		case '^':
+			left = math.Pow(left, right)
+
+		case '/', '%':
+			if right == 0 {
+				return 0, parser.errorf(op.Position, "division by zero")
+			}
+
+			if op.Kind == '/' {
+				left /= right
+			} else {
+				left = math.Mod(left, right)
+			}
+		}
+	}
+}
+
+// evalOperand evaluates the numeric, identifier, parenthesized expression or unary expression at the cursor
+func (parser *Parser) evalOperand(vars map[string]float64) (float64, error) {
+	if err := parser.descend(); err != nil {
+		return 0, err
+	}
+
+	defer parser.ascend()
+
+	token := parser.curr
+
+	switch token.Kind {
+	// Unary Expression
+	case '-', '+':
+		parser.Advance()
+
+		operand, err := parser.evalArithmetic(powerUnary, vars)
+		if err != nil || token.Kind == '+' {
+			return operand, err
+		}
+
+		return -operand, nil
+
+	// Parenthesized Expression
+	case '(':
+		parser.Advance()
+
+		value, err := parser.evalArithmetic(0, vars)
+		if err != nil {
+			return 0, err
+		}
+
+		if !parser.IsCursor(')') {
+			return 0, parser.sentinelf(token.Position, ErrUnterminatedEnclosure, "missing end of expression group: ')'")
+		}
+
+		parser.Advance()
+		return value, nil
+
+	// Numeric
+	case TokenNumber, TokenFloat:
+		value, err := token.Float64()
+		if err != nil {
+			return 0, parser.valueError(token.Position, err)
+		}
+
+		parser.Advance()
+		return value, nil
+
+	// Identifier
+	case TokenIdent:
+		value, ok := vars[token.Literal]
+		if !ok {
+			return 0, parser.errorf(token.Position, "undefined variable: '%v'", token.Literal)
+		}
+
+		parser.Advance()
+		return value, nil
+
+	default:
+		return 0, parser.errorf(token.Position, "expected operand, found %v", describeToken(token))
+	}
+}
+
+// splitSigns is a Middleware that splits the sign of signed numeric Tokens into a separate unicode Token,
+// such that '3-2' is scanned as a subtraction instead of two juxtaposed numerics
+func splitSigns(next TokenSource) TokenSource {
+	var pending []Token
+
+	return TokenSourceFunc(func() Token {
+		if len(pending) != 0 {
+			token := pending[0]
+			pending = pending[1:]
+
+			return token
+		}
+
+		token := next.Next()
+		if token.Kind != TokenNumber && token.Kind != TokenFloat {
+			return token
+		}
+
+		if sign := token.Literal[0]; sign == '-' || sign == '+' {
+			pending = append(pending, Token{token.Kind, token.Literal[1:], token.Position + 1, token.End, nil})
+			return UnicodeToken(rune(sign), token.Position)
+		}
+
+		return token
+	})
+}
@@ -0,0 +1,66 @@
+package symbolizer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvalArithmetic(t *testing.T) {
+	vars := map[string]float64{"width": 10, "margin": 2, "ratio": 0.5}
+
+	tests := []struct {
+		input  string
+		output float64
+	}{
+		{"42", 42},
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"3-2", 1},
+		{"3 -2", 1},
+		{"3 - -2", 5},
+		{"10 - 4 - 3", 3},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2^2", -4},
+		{"2^-1", 0.5},
+		{"+3 * -(1 + 1)", -6},
+		{"7 % 4", 3},
+		{"7.5 % 2", 1.5},
+		{"1.5e3 / 3", 500},
+		{"2 * (width + margin) ^ 2 / 3", 96},
+		{"width*ratio-margin", 3},
+	}
+
+	for _, test := range tests {
+		value, err := EvalArithmetic(test.input, vars)
+		assert.NoError(t, err, test.input)
+		assert.InDelta(t, test.output, value, 1e-9, test.input)
+	}
+
+	value, err := EvalArithmetic("(-8) ^ 0.5", nil)
+	assert.NoError(t, err)
+	assert.True(t, math.IsNaN(value))
+}
+
+func TestEvalArithmetic_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"", "expected operand, found <eof>"},
+		{"1 +", "expected operand, found <eof>"},
+		{"1 2", "unexpected token after expression: <num> '2'"},
+		{"(1 + 2", "missing end of expression group: ')'"},
+		{"1 / (2 - 2)", "division by zero"},
+		{"1 % 0", "division by zero"},
+		{"x * 2", "undefined variable: 'x'"},
+		{"* 2", "expected operand, found '*'"},
+		{`"a" + 1`, `expected operand, found <str> '"a"'`},
+	}
+
+	for _, test := range tests {
+		_, err := EvalArithmetic(test.input, nil)
+		assert.EqualError(t, err, test.err, test.input)
+	}
+}
@@ -0,0 +1,298 @@
+package symbolizer
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// scanPlaceholder scans for a Placeholder lexeme under the cursor, either a positional '?' or a ':' that
+// is immediately followed by an identifier and does not follow an operand (see afterOperand), such that the
+// ':' of an unspaced pair like `key:value` is not a placeholder. Returns false for any other ':'.
+func (lexer *lexer) scanPlaceholder() (Lexeme, bool) {
+	start := lexer.cursor
+
+	if lexer.char() == ':' {
+		if next := lexer.peek(); lexer.afterOperand() || (!unicode.IsLetter(next) && next != '_' && !lexer.config.identClass(next)) {
+			return Lexeme{}, false
+		}
+
+		lexer.advanceCursor()
+		for lexer.identChar(lexer.char()) {
+			lexer.advanceCursor()
+		}
+
+		return Lexeme{TokenPlaceholder, start, lexer.cursor}, true
+	}
+
+	lexer.advanceCursor()
+	return Lexeme{TokenPlaceholder, start, lexer.cursor}, true
+}
+
+// Prepared is a symbol with placeholders (such as `transfer(to: ?, amount: :amount)`) that values
+// can be bound to, like a prepared statement. It is generated with Prepare and rendered with Bind.
+type Prepared struct {
+	input string
+	// placeholders are the TokenPlaceholder Tokens of the input
+	placeholders []Token
+	// config is the configuration with which the input was tokenized
+	config *parseConfig
+}
+
+// Prepare tokenizes a symbol with placeholders with the given options (Placeholders is always enabled)
+// and returns a Prepared symbol that values can be bound to with Bind. Returns an error if the
+// symbol contains a malformed Token or if the lexer is terminated (such as by a limit).
+func Prepare(input string, opts ...ParserOption) (*Prepared, error) {
+	scanner := newLexer([]byte(input), newParseConfig(append(opts, Placeholders())...))
+	prepared := &Prepared{input: input, config: scanner.config}
+
+	for token := scanner.nextToken(); token.Kind != TokenEoF; token = scanner.nextToken() {
+		switch token.Kind {
+		case TokenMalformed:
+			return nil, &Error{Position: token.Position, Message: fmt.Sprintf("malformed token: '%v'", token.Literal)}
+		case TokenPlaceholder:
+			prepared.placeholders = append(prepared.placeholders, token)
+		}
+	}
+
+	if scanner.err != nil {
+		return nil, scanner.err
+	}
+
+	return prepared, nil
+}
+
+// Placeholders returns the placeholders of the Prepared symbol in the order that they appear,
+// with the names of named placeholders (without the ':') and '?' for positional placeholders.
+func (prepared *Prepared) Placeholders() []string {
+	names := make([]string, 0, len(prepared.placeholders))
+	for _, placeholder := range prepared.placeholders {
+		names = append(names, strings.TrimPrefix(placeholder.Literal, ":"))
+	}
+
+	return names
+}
+
+// Bind renders the given values into the placeholders of the Prepared symbol and returns the symbol.
+// Values are bound to the placeholders in the order that they appear, except that a named placeholder
+// that repeats is bound to the same value as its first occurrence. If the only value is a map[string]any
+// and all the placeholders are named, the placeholders are bound to the values of their names instead.
+//
+// Values are rendered as literals, such that they cannot alter the structure of the symbol:
+//   - string -> a string quoted with '"' (or '`' with RawStrings if the string contains a '"')
+//   - []byte -> a hex literal with the 0x prefix
+//   - bool, integers, float32, float64 and *big.Int -> a boolean or numeric literal
+//   - time.Duration -> a duration literal (such as 1m30s) and time.Time -> an RFC3339 timestamp literal
+//   - Semver -> a semantic version literal
+//
+// The rendered symbol is tokenized with the options of the Prepared symbol and each rendered literal must be
+// scanned as exactly one value Token, such that a float requires ScientificNumbers (otherwise 1.5 is scanned
+// as '1', '.' and '5'), a duration requires DurationLiterals, a timestamp requires TimestampLiterals and a
+// Semver requires SemverLiterals. A literal that merges with its surroundings (such as 5 bound into `x?`)
+// is also rejected. Returns an error if the number of values does not match the placeholders or if a
+// value cannot be rendered.
+func (prepared *Prepared) Bind(values ...any) (string, error) {
+	bound, err := prepared.resolve(values)
+	if err != nil {
+		return "", err
+	}
+
+	var rendered strings.Builder
+	cursor := 0
+
+	// spans are the spans of the rendered literals within the rendered symbol
+	spans := make([]Lexeme, 0, len(prepared.placeholders))
+
+	for idx, placeholder := range prepared.placeholders {
+		literal, err := prepared.render(bound[idx])
+		if err != nil {
+			return "", &Error{Position: placeholder.Position, Message: fmt.Sprintf("cannot bind placeholder '%v': %v", placeholder.Literal, err)}
+		}
+
+		rendered.WriteString(prepared.input[cursor:placeholder.Position])
+		spans = append(spans, Lexeme{Start: rendered.Len(), End: rendered.Len() + len(literal)})
+		rendered.WriteString(literal)
+		cursor = placeholder.End
+	}
+
+	rendered.WriteString(prepared.input[cursor:])
+	symbol := rendered.String()
+
+	// Every rendered literal must be scanned as a single value Token
+	scanned := prepared.scanValues(symbol)
+	for idx, span := range spans {
+		if end, ok := scanned[span.Start]; !ok || end != span.End {
+			placeholder := prepared.placeholders[idx]
+			return "", &Error{Position: placeholder.Position, Message: fmt.Sprintf("cannot bind placeholder '%v': value is not scanned as a single literal: '%v'", placeholder.Literal, symbol[span.Start:span.End])}
+		}
+	}
+
+	return symbol, nil
+}
+
+// scanValues tokenizes the rendered symbol with the configuration of the Prepared
+// symbol and returns the end of each lexeme that can be a value, by its start
+func (prepared *Prepared) scanValues(symbol string) map[int]int {
+	scanner := newLexer([]byte(symbol), prepared.config)
+	scanned := make(map[int]int)
+
+	for lexeme := scanner.next(); lexeme.Kind != TokenEoF; lexeme = scanner.next() {
+		if lexeme.Kind.CanValue() {
+			scanned[lexeme.Start] = lexeme.End
+		}
+	}
+
+	return scanned
+}
+
+// resolve returns the value bound to each placeholder of the Prepared symbol
+func (prepared *Prepared) resolve(values []any) ([]any, error) {
+	bound := make([]any, len(prepared.placeholders))
+
+	// Bind named placeholders from a map of values, if all of them are named
+	if named, ok := singleMap(values); ok && prepared.allNamed() {
+		for idx, placeholder := range prepared.placeholders {
+			value, ok := named[placeholder.Literal[1:]]
+			if !ok {
+				return nil, &Error{Position: placeholder.Position, Message: fmt.Sprintf("missing value for placeholder '%v'", placeholder.Literal)}
+			}
+
+			bound[idx] = value
+		}
+
+		return bound, nil
+	}
+
+	// Count the distinct placeholders, since repeated named placeholders share a value
+	distinct := make(map[string]bool)
+	expected := 0
+
+	for _, placeholder := range prepared.placeholders {
+		if placeholder.Literal == "?" || !distinct[placeholder.Literal] {
+			distinct[placeholder.Literal] = true
+			expected++
+		}
+	}
+
+	if len(values) != expected {
+		return nil, fmt.Errorf("expected %d values to bind, found %d", expected, len(values))
+	}
+
+	seen := make(map[string]any)
+	for idx, placeholder := range prepared.placeholders {
+		if value, ok := seen[placeholder.Literal]; ok {
+			bound[idx] = value
+			continue
+		}
+
+		bound[idx], values = values[0], values[1:]
+		if placeholder.Literal != "?" {
+			seen[placeholder.Literal] = bound[idx]
+		}
+	}
+
+	return bound, nil
+}
+
+// allNamed returns whether all the placeholders of the Prepared symbol are named
+func (prepared *Prepared) allNamed() bool {
+	for _, placeholder := range prepared.placeholders {
+		if placeholder.Literal == "?" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// render renders a value as a literal
+func (prepared *Prepared) render(value any) (string, error) {
+	switch value := value.(type) {
+	case string:
+		switch {
+		case !strings.Contains(value, `"`):
+			return `"` + value + `"`, nil
+		case prepared.config.rawStrings && !strings.Contains(value, "`"):
+			return "`" + value + "`", nil
+		default:
+			return "", fmt.Errorf("string cannot be quoted: %q", value)
+		}
+
+	case []byte:
+		if len(value) == 0 {
+			return "", errors.New("empty bytes cannot be rendered as hex")
+		}
+
+		return "0x" + hex.EncodeToString(value), nil
+
+	case bool:
+		return strconv.FormatBool(value), nil
+	case int:
+		return strconv.FormatInt(int64(value), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(value), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(value), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(value), 10), nil
+	case int64:
+		return strconv.FormatInt(value, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(value), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(value), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(value), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(value), 10), nil
+	case uint64:
+		return strconv.FormatUint(value, 10), nil
+
+	case float32:
+		return renderFloat(float64(value), 32)
+	case float64:
+		return renderFloat(value, 64)
+
+	case *big.Int:
+		if value == nil {
+			return "", errors.New("nil *big.Int")
+		}
+
+		return value.String(), nil
+
+	case time.Duration:
+		return value.String(), nil
+	case time.Time:
+		return value.Format(time.RFC3339Nano), nil
+	case Semver:
+		return value.String(), nil
+
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+// renderFloat renders a finite floating point value as a decimal literal
+func renderFloat(value float64, bits int) (string, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return "", fmt.Errorf("non-finite float: %v", value)
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, bits), nil
+}
+
+// singleMap returns the only value as a map[string]any, if it is one
+func singleMap(values []any) (map[string]any, bool) {
+	if len(values) != 1 {
+		return nil, false
+	}
+
+	named, ok := values[0].(map[string]any)
+	return named, ok
+}
@@ -0,0 +1,92 @@
+package symbolizer
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexer_Placeholders(t *testing.T) {
+	tokens := Tokenize("f(?, :owner, a:b, : x, ?c)", Placeholders(), IgnoreWhitespaces())
+	assert.Equal(t, []Token{
+		{TokenIdent, "f", 0, 1, nil},
+		{TokenKind('('), "(", 1, 2, nil},
+		{TokenPlaceholder, "?", 2, 3, nil},
+		{TokenKind(','), ",", 3, 4, nil},
+		{TokenPlaceholder, ":owner", 5, 11, nil},
+		{TokenKind(','), ",", 11, 12, nil},
+		{TokenIdent, "a", 13, 14, nil},
+		{TokenKind(':'), ":", 14, 15, nil},
+		{TokenIdent, "b", 15, 16, nil},
+		{TokenKind(','), ",", 16, 17, nil},
+		{TokenKind(':'), ":", 18, 19, nil},
+		{TokenIdent, "x", 20, 21, nil},
+		{TokenKind(','), ",", 21, 22, nil},
+		{TokenPlaceholder, "?", 23, 24, nil},
+		{TokenIdent, "c", 24, 25, nil},
+		{TokenKind(')'), ")", 25, 26, nil},
+		{TokenEoF, "", 26, 26, nil},
+	}, tokens)
+
+	// Placeholders are not recognized unless enabled
+	assert.Equal(t, TokenKind('?'), Tokenize("?")[0].Kind)
+}
+
+func TestPrepared_Bind(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		values  []any
+		output  string
+		err     string
+	}{
+		{"transfer(to: ?, amount: ?)", nil, []any{[]byte{0xab, 0xcd}, uint64(1500)}, `transfer(to: 0xabcd, amount: 1500)`, ""},
+		{"f(:a, :b, :a)", nil, []any{"x", true}, `f("x", true, "x")`, ""},
+		{"f(:a, :b, :a)", []ParserOption{ScientificNumbers()}, []any{map[string]any{"a": -1, "b": 2.5}}, `f(-1, 2.5, -1)`, ""},
+		{
+			"f(?, ?, ?)", []ParserOption{DurationLiterals(), TimestampLiterals(), SemverLiterals()},
+			[]any{time.Minute + 30*time.Second, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), Semver{Major: 1, Minor: 2}}, `f(1m30s, 2024-01-02T03:04:05Z, 1.2.0)`, "",
+		},
+		{"f(?)", nil, []any{new(big.Int).Lsh(big.NewInt(1), 64)}, `f(18446744073709551616)`, ""},
+		{"f(?)", []ParserOption{RawStrings()}, []any{`say "hi"`}, "f(`say \"hi\"`)", ""},
+		{"f(?)", nil, []any{`say "hi"`}, "", "cannot bind placeholder '?': string cannot be quoted: \"say \\\"hi\\\"\""},
+		{"f(?)", nil, []any{math.NaN()}, "", "cannot bind placeholder '?': non-finite float: NaN"},
+		{"f(?)", nil, []any{struct{}{}}, "", "cannot bind placeholder '?': unsupported value of type struct {}"},
+		{"f(?, :a, :a)", nil, []any{1}, "", "expected 2 values to bind, found 1"},
+		{"f(:a, :b)", nil, []any{map[string]any{"a": 1}}, "", "missing value for placeholder ':b'"},
+		{"f()", nil, nil, "f()", ""},
+		{"x?", nil, []any{-5}, "x-5", ""},
+		{"x?", nil, []any{5}, "", "cannot bind placeholder '?': value is not scanned as a single literal: '5'"},
+		{"a.? b", nil, []any{1.5}, "", "cannot bind placeholder '?': value is not scanned as a single literal: '1.5'"},
+		{"f(?)", nil, []any{90 * time.Second}, "", "cannot bind placeholder '?': value is not scanned as a single literal: '1m30s'"},
+		{"f(?)", nil, []any{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, "", "cannot bind placeholder '?': value is not scanned as a single literal: '2024-01-02T03:04:05Z'"},
+		{"f(?)", nil, []any{Semver{Major: 1, Minor: 2, Patch: 3}}, "", "cannot bind placeholder '?': value is not scanned as a single literal: '1.2.3'"},
+		{"f(?)", []ParserOption{NoDefaultKeywords()}, []any{true}, "", "cannot bind placeholder '?': value is not scanned as a single literal: 'true'"},
+		{"{to:alice, amount: ?}", nil, []any{5}, `{to:alice, amount: 5}`, ""},
+		{"{to:alice, 0x01:true, amount::amount}", nil, []any{5}, `{to:alice, 0x01:true, amount:5}`, ""},
+	}
+
+	for _, test := range tests {
+		prepared, err := Prepare(test.input, test.options...)
+		require.NoError(t, err, test.input)
+
+		output, err := prepared.Bind(test.values...)
+		if test.err == "" {
+			assert.NoError(t, err, test.input)
+			assert.Equal(t, test.output, output, test.input)
+		} else {
+			assert.EqualError(t, err, test.err, test.input)
+		}
+	}
+
+	prepared, err := Prepare("f(?, :to, :to)")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"?", "to", "to"}, prepared.Placeholders())
+
+	_, err = Prepare(`f(?, "x)`)
+	assert.EqualError(t, err, "malformed token: '\"x)'")
+}
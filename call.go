@@ -0,0 +1,115 @@
+package symbolizer
+
+// Call describes a parsed function-call shaped symbol such as `name(arg1, arg2, key=val)`
+type Call struct {
+	// Callee is the identifier Token of the called function
+	Callee Token
+	// Positional are the values of the positional arguments in order
+	Positional []any
+	// Keyed are the values of the keyed arguments by their key
+	Keyed map[string]any
+}
+
+// ParseCall parses a function-call shaped symbol such as `name(arg1, arg2, key=val)` beginning at the cursor.
+// The cursor must be the identifier of the callee (see ExpectIdent) and the parser is advanced past the closing parenthesis.
+// Arguments are separated by commas and keyed arguments are an identifier and value separated by '='.
+// All positional arguments must precede the keyed arguments and keys may not be repeated.
+//
+// Argument values that are a single token are converted with Token.Value, values that are themselves
+// call-shaped are parsed as a nested Call and all other values are returned as their source text.
+func (parser *Parser) ParseCall() (Call, error) {
+	// Collect the callee identifier
+	if err := parser.checkIdent("expected callee identifier"); err != nil {
+		return Call{}, err
+	}
+
+	call := Call{Callee: parser.curr}
+	parser.Advance()
+
+	// Collect the arguments from within the parenthesis
+	args, err := parser.enclosedParser(EnclosureParens())
+	if err != nil {
+		return Call{}, err
+	}
+
+	for !args.Exhausted() {
+		// Collect the key of keyed arguments
+		var key *Token
+		if args.IsCursor(TokenIdent) && args.IsPeek('=') {
+			token := args.curr
+			key = &token
+
+			args.Advance()
+			args.Advance()
+		}
+
+		// Collect the argument value
+		position := args.curr.Position
+		tokens := args.collectValue(',')
+		if len(tokens) == 0 {
+			return Call{}, args.errorf(position, "missing argument value")
+		}
+
+		value, err := args.callValue(tokens)
+		if err != nil {
+			return Call{}, err
+		}
+
+		switch {
+		case key == nil && len(call.Keyed) != 0:
+			return Call{}, args.errorf(tokens[0].Position, "positional argument after keyed argument")
+
+		case key == nil:
+			call.Positional = append(call.Positional, value)
+
+		default:
+			if _, exists := call.Keyed[key.Literal]; exists {
+				return Call{}, args.errorf(key.Position, "duplicate keyed argument: '%v'", key.Literal)
+			}
+
+			if call.Keyed == nil {
+				call.Keyed = make(map[string]any)
+			}
+
+			call.Keyed[key.Literal] = value
+		}
+
+		// Move past the argument delimiter
+		if args.IsCursor(',') {
+			args.Advance()
+		}
+	}
+
+	return call, nil
+}
+
+// callValue converts a run of argument Tokens within a Call into a value
+func (parser *Parser) callValue(tokens []Token) (any, error) {
+	first, last := tokens[0], tokens[len(tokens)-1]
+
+	switch {
+	// Single Token Value
+	case len(tokens) == 1 && first.Kind.CanValue():
+		return parser.tokenValue(first)
+
+	// Nested Call
+	case len(tokens) > 2 && first.Kind == TokenIdent && tokens[1].Kind == '(' && last.Kind == ')':
+		nested := parser.subParser(first.Position, last.End)
+
+		call, err := nested.ParseCall()
+		if err != nil {
+			return nil, err
+		}
+
+		// Calls on the result of a call (such as f(a)(b)) are returned as source text
+		if nested.Exhausted() {
+			return call, nil
+		}
+
+		fallthrough
+
+	// Source Text
+	default:
+		return parser.scanner.collectBetween(first.Position, last.End), nil
+	}
+}
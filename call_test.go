@@ -0,0 +1,69 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_ParseCall(t *testing.T) {
+	tests := []struct {
+		input    string
+		output   Call
+		error    string
+		unparsed string
+	}{
+		{
+			`resize(800, 600, mode="fit", sharp=true)`,
+			Call{
+				Callee:     Token{TokenIdent, "resize", 0, 6, nil},
+				Positional: []any{uint64(800), uint64(600)},
+				Keyed:      map[string]any{"mode": "fit", "sharp": true},
+			},
+			"", "",
+		},
+		{
+			`now()->`,
+			Call{Callee: Token{TokenIdent, "now", 0, 3, nil}},
+			"", "->",
+		},
+		{
+			`wrap(inner(0x01, -2), [1, 2], key = map[string]string,)`,
+			Call{
+				Callee: Token{TokenIdent, "wrap", 0, 4, nil},
+				Positional: []any{
+					Call{Callee: Token{TokenIdent, "inner", 5, 10, nil}, Positional: []any{[]byte{0x01}, int64(-2)}},
+					"[1, 2]",
+				},
+				Keyed: map[string]any{"key": "map[string]string"},
+			},
+			"", "",
+		},
+		{
+			`curry(f(a)(b))`,
+			Call{Callee: Token{TokenIdent, "curry", 0, 5, nil}, Positional: []any{"f(a)(b)"}},
+			"", "",
+		},
+		{`"f"(a)`, Call{}, "expected callee identifier, found '\"f\"'", ""},
+		{`f[a]`, Call{}, "missing start of enclosure: '('", ""},
+		{`f(a, b`, Call{}, "missing end of enclosure: ')'", ""},
+		{`f(a, , b)`, Call{}, "missing argument value", ""},
+		{`f(a=1, b)`, Call{}, "positional argument after keyed argument", ""},
+		{`f(a=1, a=2)`, Call{}, "duplicate keyed argument: 'a'", ""},
+		{`f(0x123)`, Call{}, "invalid hex token: encoding/hex: odd length hex string", ""},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, IgnoreWhitespaces())
+		call, err := parser.ParseCall()
+
+		if test.error != "" {
+			assert.EqualError(t, err, test.error, test.input)
+			continue
+		}
+
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.output, call, test.input)
+		assert.Equal(t, test.unparsed, parser.Unparsed())
+	}
+}
@@ -0,0 +1,174 @@
+// Command symbolizer tokenizes, splits and unwraps symbols from the command line and emits the results as JSON.
+//
+// Usage:
+//
+//	symbolizer <command> [flags] [symbol...]
+//
+// The commands are:
+//
+//	tokens  emit the tokens of the symbol
+//	split   emit the segments of the symbol separated by a delimiter (-delim)
+//	unwrap  emit the data enclosed within an enclosure (-enclosure) at the start of the symbol
+//	values  emit the values of the tokens of the symbol that can be converted into values
+//
+// The symbol is read from the arguments (joined by spaces) or from stdin if there are no arguments.
+// Whitespaces are ignored when the -ignore-spaces flag is set.
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/manishmeganathan/symbolizer"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "symbolizer:", err)
+		os.Exit(1)
+	}
+}
+
+// jsonToken is the JSON representation of a symbolizer.Token
+type jsonToken struct {
+	Kind    string `json:"kind"`
+	Literal string `json:"literal"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+}
+
+// jsonValue is the JSON representation of a symbolizer.Token and its value
+type jsonValue struct {
+	jsonToken
+	Value any `json:"value"`
+}
+
+// run executes the command in the given arguments and writes its JSON result to stdout
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("missing command: expected one of tokens, split, unwrap or values")
+	}
+
+	command := args[0]
+
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	spaces := flags.Bool("ignore-spaces", false, "ignore whitespaces in the symbol")
+	delim := flags.String("delim", ",", "delimiter character for split")
+	enclosure := flags.String("enclosure", "()", "start and stop characters of the enclosure for unwrap")
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	input, err := readInput(flags.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	var options []symbolizer.ParserOption
+	if *spaces {
+		options = append(options, symbolizer.IgnoreWhitespaces())
+	}
+
+	var result any
+
+	switch command {
+	case "tokens":
+		tokens := make([]jsonToken, 0)
+		for _, token := range symbolizer.Tokenize(input, options...) {
+			tokens = append(tokens, toJSONToken(token))
+		}
+
+		result = tokens
+
+	case "split":
+		delimiter, size := utf8.DecodeRuneInString(*delim)
+		if size == 0 || size != len(*delim) {
+			return fmt.Errorf("invalid delimiter: '%v'", *delim)
+		}
+
+		result = symbolizer.NewParser(input, options...).Split(symbolizer.TokenKind(delimiter))
+
+	case "unwrap":
+		runes := []rune(*enclosure)
+		if len(runes) != 2 {
+			return fmt.Errorf("invalid enclosure: '%v'", *enclosure)
+		}
+
+		enc, err := symbolizer.NewEnclosure(runes[0], runes[1])
+		if err != nil {
+			return err
+		}
+
+		if result, err = symbolizer.NewParser(input, options...).Unwrap(enc); err != nil {
+			return err
+		}
+
+	case "values":
+		values := make([]jsonValue, 0)
+		for _, token := range symbolizer.Tokenize(input, options...) {
+			if !token.Kind.CanValue() {
+				continue
+			}
+
+			value, err := token.Value()
+			if err != nil {
+				return err
+			}
+
+			values = append(values, jsonValue{toJSONToken(token), toJSONValue(value)})
+		}
+
+		result = values
+
+	default:
+		return fmt.Errorf("unknown command: '%v'", command)
+	}
+
+	encoder := json.NewEncoder(stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(result)
+}
+
+// readInput returns the symbol from the arguments or from stdin if there are no arguments.
+// A trailing newline in stdin is not considered a part of the symbol.
+func readInput(args []string, stdin io.Reader) (string, error) {
+	if len(args) != 0 {
+		return strings.Join(args, " "), nil
+	}
+
+	data, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r"), nil
+}
+
+// toJSONToken converts a symbolizer.Token into its JSON representation
+func toJSONToken(token symbolizer.Token) jsonToken {
+	return jsonToken{token.Kind.String(), token.Literal, token.Position, token.End}
+}
+
+// toJSONValue converts a Token value into a value with a readable JSON representation
+func toJSONValue(value any) any {
+	switch value := value.(type) {
+	case []byte:
+		return "0x" + hex.EncodeToString(value)
+	case time.Duration:
+		return value.String()
+	default:
+		return value
+	}
+}
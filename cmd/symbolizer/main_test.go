@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args   []string
+		stdin  string
+		output string
+		err    string
+	}{
+		{
+			[]string{"tokens", "-ignore-spaces", "a", "1"}, "",
+			`[{"kind":"<ident>","literal":"a","start":0,"end":1},{"kind":"<num>","literal":"1","start":2,"end":3},{"kind":"<eof>","literal":"","start":3,"end":3}]`,
+			"",
+		},
+		{
+			[]string{"split", "-delim", ";"}, "a;b(c);e\n",
+			`["a","b(c)","e"]`,
+			"",
+		},
+		{
+			[]string{"split"}, "",
+			`[""]`,
+			"",
+		},
+		{
+			[]string{"unwrap", "-enclosure", "[]", "[a, [b]] c"}, "",
+			`"a, [b]"`,
+			"",
+		},
+		{
+			[]string{"values", "-ignore-spaces", `f("x", -2, 0xff, true)`}, "",
+			`[{"kind":"<str>","literal":"\"x\"","start":2,"end":5,"value":"x"},{"kind":"<num>","literal":"-2","start":7,"end":9,"value":-2},{"kind":"<hex>","literal":"0xff","start":11,"end":15,"value":"0xff"},{"kind":"<bool>","literal":"true","start":17,"end":21,"value":true}]`,
+			"",
+		},
+		{nil, "", "", "missing command: expected one of tokens, split, unwrap or values"},
+		{[]string{"parse", "x"}, "", "", "unknown command: 'parse'"},
+		{[]string{"split", "-delim", ",,", "x"}, "", "", "invalid delimiter: ',,'"},
+		{[]string{"unwrap", "-enclosure", "((", "x"}, "", "", "enclosure start and stop cannot be the same"},
+		{[]string{"unwrap", "x"}, "", "", "missing start of enclosure: '('"},
+	}
+
+	for _, test := range tests {
+		stdout := new(bytes.Buffer)
+		err := run(test.args, strings.NewReader(test.stdin), stdout)
+
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.args)
+			continue
+		}
+
+		assert.NoError(t, err, test.args)
+		assert.JSONEq(t, test.output, stdout.String(), test.args)
+	}
+}
@@ -0,0 +1,591 @@
+package symbolizer
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CompareOp is an enum for representing the comparison operator of a Comparison
+type CompareOp int
+
+const (
+	CompareEq CompareOp = iota
+	CompareNe
+	CompareLt
+	CompareLe
+	CompareGt
+	CompareGe
+	CompareIn
+)
+
+// String implements the Stringer interface for CompareOp
+func (op CompareOp) String() string {
+	switch op {
+	case CompareEq:
+		return "="
+	case CompareNe:
+		return "!="
+	case CompareLt:
+		return "<"
+	case CompareLe:
+		return "<="
+	case CompareGt:
+		return ">"
+	case CompareGe:
+		return ">="
+	case CompareIn:
+		return "in"
+	default:
+		return fmt.Sprintf("CompareOp(%d)", int(op))
+	}
+}
+
+// LogicalOp is an enum for representing the boolean connector of a Logical condition
+type LogicalOp int
+
+const (
+	LogicalAnd LogicalOp = iota
+	LogicalOr
+)
+
+// String implements the Stringer interface for LogicalOp
+func (op LogicalOp) String() string {
+	switch op {
+	case LogicalAnd:
+		return "and"
+	case LogicalOr:
+		return "or"
+	default:
+		return fmt.Sprintf("LogicalOp(%d)", int(op))
+	}
+}
+
+// Condition is a node of the tree of a condition parsed with ParseCondition,
+// which is either a Comparison, a Logical condition or a Not condition.
+type Condition interface {
+	// Span returns the start and end byte offsets of the source of the Condition within the input
+	Span() (int, int)
+	// String returns the Condition formatted canonically, with parenthesis only where they are required
+	String() string
+	// Evaluate evaluates the Condition with the given values of its identifiers (see Comparison.Evaluate)
+	Evaluate(vars map[string]any) (bool, error)
+
+	condition()
+}
+
+// Operand is an identifier or a literal value that is compared within a Comparison
+type Operand struct {
+	// Ident is whether the operand is an identifier (such as 'age' or 'user.name'), which is a reference to a variable
+	Ident bool
+	// Literal is the source of the operand, which is the (possibly dotted) name of an identifier
+	Literal string
+	// Value is the value of a literal operand (see Token.Value). It is nil for identifiers.
+	Value any
+	// Position and End are the byte offsets of the start and end of the operand within the input
+	Position, End int
+}
+
+// String returns the source of the Operand
+func (operand Operand) String() string { return operand.Literal }
+
+// Comparison is a condition that compares an operand with another operand such as `age > 18`,
+// or with a list of operands in parenthesis for CompareIn such as `role in ("admin", "owner")`
+type Comparison struct {
+	Left Operand
+	Op   CompareOp
+	// Right is the operand on the right of the operator. It has multiple operands only for CompareIn.
+	Right []Operand
+	// End is the byte offset of the end of the Comparison within the input
+	End int
+}
+
+// Logical is a condition that connects two conditions with 'and' or 'or'
+type Logical struct {
+	Op          LogicalOp
+	Left, Right Condition
+}
+
+// Not is a condition that negates another condition with 'not'
+type Not struct {
+	Operand Condition
+	// Position is the byte offset of the 'not' keyword within the input
+	Position int
+}
+
+// Span implements the Condition interface for Comparison
+func (cond *Comparison) Span() (int, int) { return cond.Left.Position, cond.End }
+
+// Span implements the Condition interface for Logical
+func (cond *Logical) Span() (int, int) {
+	start, _ := cond.Left.Span()
+	_, end := cond.Right.Span()
+
+	return start, end
+}
+
+// Span implements the Condition interface for Not
+func (cond *Not) Span() (int, int) {
+	_, end := cond.Operand.Span()
+	return cond.Position, end
+}
+
+// String implements the Condition interface for Comparison
+func (cond *Comparison) String() string {
+	if cond.Op != CompareIn {
+		return fmt.Sprintf("%v %v %v", cond.Left, cond.Op, cond.Right[0])
+	}
+
+	operands := make([]string, 0, len(cond.Right))
+	for _, operand := range cond.Right {
+		operands = append(operands, operand.String())
+	}
+
+	return fmt.Sprintf("%v in (%v)", cond.Left, strings.Join(operands, ", "))
+}
+
+// String implements the Condition interface for Logical
+func (cond *Logical) String() string {
+	return fmt.Sprintf("%v %v %v", cond.operand(cond.Left), cond.Op, cond.operand(cond.Right))
+}
+
+// operand formats an operand of the Logical condition, in parenthesis if it binds looser than the condition
+func (cond *Logical) operand(operand Condition) string {
+	if logical, ok := operand.(*Logical); ok && logical.Op == LogicalOr && cond.Op == LogicalAnd {
+		return "(" + operand.String() + ")"
+	}
+
+	return operand.String()
+}
+
+// String implements the Condition interface for Not
+func (cond *Not) String() string {
+	if _, ok := cond.Operand.(*Logical); ok {
+		return "not (" + cond.Operand.String() + ")"
+	}
+
+	return "not " + cond.Operand.String()
+}
+
+func (*Comparison) condition() {}
+func (*Logical) condition()    {}
+func (*Not) condition()        {}
+
+// ParseCondition parses a SQL-like condition such as `age >= 18 and (role in ("admin", "owner") or not banned = true)`
+// into a tree of Conditions. Comparisons ('=', '!=', '<', '<=', '>', '>=' and 'in') compare identifiers (which may be
+// dotted such as 'user.age') and literal values, and can be connected with 'and' and 'or', negated with 'not' and
+// grouped with parenthesis. The keywords are matched regardless of case and 'and' binds tighter than 'or'.
+// Fractional numerics (such as 9.99, see ScientificNumbers) and 'null' or 'NULL' (see NullLiterals) are
+// recognized as values. Whitespace between the components of the condition is ignored. Options such as
+// MaxDepth can be provided to limit the nesting depth of the condition. Returns an error if the input is not a valid condition or has
+// trailing data after the condition.
+func ParseCondition(input string, opts ...ParserOption) (Condition, error) {
+	parser := NewParser(input, append([]ParserOption{IgnoreWhitespaces(), ScientificNumbers(), NullLiterals("null", "NULL")}, opts...)...)
+
+	cond, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !parser.Exhausted() {
+		return nil, parser.errorf(parser.curr.Position, "unexpected token after condition: %v", describeToken(parser.curr))
+	}
+
+	return cond, nil
+}
+
+// parseOr parses conditions connected with 'or' beginning at the cursor
+func (parser *Parser) parseOr() (Condition, error) {
+	return parser.parseLogical(LogicalOr, parser.parseAnd)
+}
+
+// parseAnd parses conditions connected with 'and' beginning at the cursor
+func (parser *Parser) parseAnd() (Condition, error) {
+	return parser.parseLogical(LogicalAnd, parser.parseUnary)
+}
+
+// parseLogical parses operands connected with the LogicalOp into left-associative Logical conditions
+func (parser *Parser) parseLogical(op LogicalOp, operand func() (Condition, error)) (Condition, error) {
+	cond, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.atWord(op.String()) {
+		parser.Advance()
+
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		cond = &Logical{op, cond, right}
+	}
+
+	return cond, nil
+}
+
+// parseUnary parses a negated condition, a grouped condition or a comparison beginning at the cursor
+func (parser *Parser) parseUnary() (Condition, error) {
+	if err := parser.descend(); err != nil {
+		return nil, err
+	}
+
+	defer parser.ascend()
+
+	switch {
+	// Negated Condition
+	case parser.atWord("not"):
+		position := parser.curr.Position
+		parser.Advance()
+
+		operand, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Not{operand, position}, nil
+
+	// Grouped Condition
+	case parser.IsCursor('('):
+		position := parser.curr.Position
+		parser.Advance()
+
+		cond, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !parser.IsCursor(')') {
+			return nil, parser.sentinelf(position, ErrUnterminatedEnclosure, "missing end of condition group: ')'")
+		}
+
+		parser.Advance()
+		return cond, nil
+
+	default:
+		return parser.parseComparison()
+	}
+}
+
+// parseComparison parses a comparison beginning at the cursor
+func (parser *Parser) parseComparison() (Condition, error) {
+	left, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok := parser.compareOp()
+	if !ok {
+		return nil, parser.errorf(parser.curr.Position, "expected comparison operator, found %v", describeToken(parser.curr))
+	}
+
+	if op != CompareIn {
+		right, err := parser.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Comparison{left, op, []Operand{right}, right.End}, nil
+	}
+
+	// Collect the operands of the list
+	if !parser.IsCursor('(') {
+		return nil, parser.sentinelf(parser.curr.Position, ErrMissingEnclosureStart, "missing start of list: '('")
+	}
+
+	cond := &Comparison{Left: left, Op: CompareIn}
+	for parser.Advance(); ; parser.Advance() {
+		operand, err := parser.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+
+		cond.Right = append(cond.Right, operand)
+		if parser.IsCursor(')') {
+			break
+		}
+
+		if !parser.IsCursor(',') {
+			return nil, parser.errorf(parser.curr.Position, "expected ',' or ')', found %v", describeToken(parser.curr))
+		}
+	}
+
+	cond.End = parser.curr.End
+	parser.Advance()
+
+	return cond, nil
+}
+
+// compareOp consumes the comparison operator at the cursor and returns it, if any
+func (parser *Parser) compareOp() (CompareOp, bool) {
+	switch {
+	case parser.ConsumePrefix("!="):
+		return CompareNe, true
+	case parser.ConsumePrefix("<="):
+		return CompareLe, true
+	case parser.ConsumePrefix(">="):
+		return CompareGe, true
+	case parser.ConsumePrefix("="):
+		return CompareEq, true
+	case parser.ConsumePrefix("<"):
+		return CompareLt, true
+	case parser.ConsumePrefix(">"):
+		return CompareGt, true
+	case parser.atWord("in"):
+		parser.Advance()
+		return CompareIn, true
+	default:
+		return 0, false
+	}
+}
+
+// parseOperand parses an identifier (with any dotted components) or a literal value at the cursor
+func (parser *Parser) parseOperand() (Operand, error) {
+	token := parser.curr
+
+	switch {
+	case token.Kind.CanValue():
+		value, err := parser.tokenValue(token)
+		if err != nil {
+			return Operand{}, err
+		}
+
+		parser.Advance()
+		return Operand{Literal: token.Literal, Value: value, Position: token.Position, End: token.End}, nil
+
+	case token.Kind == TokenIdent && !parser.atKeyword():
+		operand := Operand{Ident: true, Literal: token.Literal, Position: token.Position, End: token.End}
+
+		parser.Advance()
+
+		// Collect the dotted components of the identifier
+		for parser.IsCursor('.') && parser.IsPeek(TokenIdent) {
+			parser.Advance()
+			operand.Literal, operand.End = operand.Literal+"."+parser.curr.Literal, parser.curr.End
+			parser.Advance()
+		}
+
+		return operand, nil
+
+	default:
+		return Operand{}, parser.errorf(token.Position, "expected identifier or value, found %v", describeToken(token))
+	}
+}
+
+// atWord returns whether the cursor is an identifier that matches the word regardless of case
+func (parser *Parser) atWord(word string) bool {
+	return parser.IsCursor(TokenIdent) && strings.EqualFold(parser.curr.Literal, word)
+}
+
+// atKeyword returns whether the cursor is one of the keywords of a condition
+func (parser *Parser) atKeyword() bool {
+	return parser.atWord("and") || parser.atWord("or") || parser.atWord("not") || parser.atWord("in")
+}
+
+// Evaluate implements the Condition interface for Comparison. The values of identifiers are looked up in vars by
+// their name, where dotted names (such as 'user.age') are either looked up as is or resolved through nested
+// map[string]any values. Values are compared with coercion rules that are consistent with Token.Value:
+//   - Numerics of any type (such as int, uint64, float64, time.Duration, *big.Int and *big.Rat) are compared by value.
+//   - Strings and []byte are compared lexicographically, while time.Time values are compared chronologically.
+//   - Booleans and all other values of the same type can only be compared for equality.
+//   - Null is only equal to Null and nil, while comparing a Null with '<', '<=', '>' or '>=' fails.
+//
+// Returns an error if an identifier is not defined in vars or if its values cannot be compared.
+func (cond *Comparison) Evaluate(vars map[string]any) (bool, error) {
+	left, err := cond.Left.resolve(vars)
+	if err != nil {
+		return false, err
+	}
+
+	for _, operand := range cond.Right {
+		right, err := operand.resolve(vars)
+		if err != nil {
+			return false, err
+		}
+
+		matched, err := compareOperands(cond.Op, left, right)
+		if err != nil {
+			return false, &Error{Position: cond.Left.Position, Message: err.Error(), Err: ErrInvalidConversion}
+		}
+
+		if matched {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Evaluate implements the Condition interface for Logical. The
+// right condition is not evaluated if the left condition decides the result.
+func (cond *Logical) Evaluate(vars map[string]any) (bool, error) {
+	left, err := cond.Left.Evaluate(vars)
+	if err != nil {
+		return false, err
+	}
+
+	if left == (cond.Op == LogicalOr) {
+		return left, nil
+	}
+
+	return cond.Right.Evaluate(vars)
+}
+
+// Evaluate implements the Condition interface for Not
+func (cond *Not) Evaluate(vars map[string]any) (bool, error) {
+	result, err := cond.Operand.Evaluate(vars)
+	return !result && err == nil, err
+}
+
+// resolve returns the value of the Operand, which is looked up in vars for identifiers
+func (operand Operand) resolve(vars map[string]any) (any, error) {
+	if !operand.Ident {
+		return operand.Value, nil
+	}
+
+	if value, ok := vars[operand.Literal]; ok {
+		return value, nil
+	}
+
+	// Resolve dotted names through nested maps
+	var value any = vars
+	for _, name := range strings.Split(operand.Literal, ".") {
+		nested, ok := value.(map[string]any)
+		if !ok {
+			return nil, &Error{Position: operand.Position, Message: fmt.Sprintf("undefined variable: '%v'", operand.Literal)}
+		}
+
+		if value, ok = nested[name]; !ok {
+			return nil, &Error{Position: operand.Position, Message: fmt.Sprintf("undefined variable: '%v'", operand.Literal)}
+		}
+	}
+
+	return value, nil
+}
+
+// compareOperands returns whether the values of two operands satisfy the comparison operator.
+// For CompareIn, the values are compared for equality.
+func compareOperands(op CompareOp, left, right any) (bool, error) {
+	switch op {
+	case CompareEq, CompareIn:
+		return equalValues(left, right)
+	case CompareNe:
+		equal, err := equalValues(left, right)
+		return !equal, err
+	}
+
+	order, err := orderValues(left, right)
+	if err != nil {
+		return false, err
+	}
+
+	switch op {
+	case CompareLt:
+		return order < 0, nil
+	case CompareLe:
+		return order <= 0, nil
+	case CompareGt:
+		return order > 0, nil
+	default:
+		return order >= 0, nil
+	}
+}
+
+// equalValues returns whether two values are equal with the coercion rules of Comparison.Evaluate
+func equalValues(left, right any) (bool, error) {
+	if isNullValue(left) || isNullValue(right) {
+		return isNullValue(left) && isNullValue(right), nil
+	}
+
+	if _, ok := left.(bool); ok {
+		if _, ok = right.(bool); !ok {
+			return false, fmt.Errorf("cannot compare %T with %T", left, right)
+		}
+
+		return left == right, nil
+	}
+
+	if order, err := orderValues(left, right); err == nil {
+		return order == 0, nil
+	}
+
+	if reflect.TypeOf(left) != reflect.TypeOf(right) {
+		return false, fmt.Errorf("cannot compare %T with %T", left, right)
+	}
+
+	return reflect.DeepEqual(left, right), nil
+}
+
+// orderValues returns the order of two values (-1, 0 or +1) with the coercion rules of Comparison.Evaluate
+func orderValues(left, right any) (int, error) {
+	if a, ok := numericValue(left); ok {
+		if b, ok := numericValue(right); ok {
+			return a.Cmp(b), nil
+		}
+	}
+
+	switch a := left.(type) {
+	case string:
+		if b, ok := right.(string); ok {
+			return strings.Compare(a, b), nil
+		}
+
+	case []byte:
+		if b, ok := right.([]byte); ok {
+			return bytes.Compare(a, b), nil
+		}
+
+	case time.Time:
+		if b, ok := right.(time.Time); ok {
+			switch {
+			case a.Before(b):
+				return -1, nil
+			case a.After(b):
+				return 1, nil
+			default:
+				return 0, nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("cannot order %T with %T", left, right)
+}
+
+// numericValue converts a numeric value of any type into a big.Rat.
+// Returns false if the value is not numeric or is not finite.
+func numericValue(value any) (*big.Rat, bool) {
+	switch value := value.(type) {
+	case *big.Int:
+		if value == nil {
+			return nil, false
+		}
+
+		return new(big.Rat).SetInt(value), true
+	case *big.Rat:
+		return value, value != nil
+	}
+
+	reflected := reflect.ValueOf(value)
+
+	switch reflected.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(reflected.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(reflected.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		rat := new(big.Rat).SetFloat64(reflected.Float())
+		return rat, rat != nil
+	default:
+		return nil, false
+	}
+}
+
+// isNullValue returns whether the value is Null or nil
+func isNullValue(value any) bool {
+	_, null := value.(Null)
+	return null || value == nil
+}
@@ -0,0 +1,186 @@
+package symbolizer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		input  string
+		output string
+	}{
+		{"a = 1", "a = 1"},
+		{`name != "bob"`, `name != "bob"`},
+		{"age>=18 AND age<65", "age >= 18 and age < 65"},
+		{"a <= 1 or b > 2 and c = 3", "a <= 1 or b > 2 and c = 3"},
+		{"(a = 1 or b = 2) and c = 3", "(a = 1 or b = 2) and c = 3"},
+		{"a = 1 or (b = 2 and c = 3)", "a = 1 or b = 2 and c = 3"},
+		{"not (a = 1 or b = 2)", "not (a = 1 or b = 2)"},
+		{"not not a = b", "not not a = b"},
+		{`user.role in ("admin", "owner")`, `user.role in ("admin", "owner")`},
+		{"x IN (1)", "x in (1)"},
+		{"((a = true))", "a = true"},
+		{"price < 9.99", "price < 9.99"},
+		{"a = 1.5 or b != -2e3", "a = 1.5 or b != -2e3"},
+		{"deleted = null or archived != NULL", "deleted = null or archived != NULL"},
+	}
+
+	for _, test := range tests {
+		cond, err := ParseCondition(test.input)
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.output, cond.String(), test.input)
+	}
+}
+
+func TestParseCondition_Tree(t *testing.T) {
+	// 0         1         2         3
+	// 0123456789012345678901234567890123456
+	// not a.b = 1 or c in (2, x) and d < e
+	cond, err := ParseCondition("not a.b = 1 or c in (2, x) and d < e")
+	require.NoError(t, err)
+
+	or, ok := cond.(*Logical)
+	require.True(t, ok)
+	assert.Equal(t, LogicalOr, or.Op)
+
+	not, ok := or.Left.(*Not)
+	require.True(t, ok)
+	assert.Equal(t, &Comparison{
+		Left:  Operand{Ident: true, Literal: "a.b", Position: 4, End: 7},
+		Op:    CompareEq,
+		Right: []Operand{{Literal: "1", Value: uint64(1), Position: 10, End: 11}},
+		End:   11,
+	}, not.Operand)
+
+	and, ok := or.Right.(*Logical)
+	require.True(t, ok)
+	assert.Equal(t, LogicalAnd, and.Op)
+	assert.Equal(t, &Comparison{
+		Left: Operand{Ident: true, Literal: "c", Position: 15, End: 16},
+		Op:   CompareIn,
+		Right: []Operand{
+			{Literal: "2", Value: uint64(2), Position: 21, End: 22},
+			{Ident: true, Literal: "x", Position: 24, End: 25},
+		},
+		End: 26,
+	}, and.Left)
+
+	start, end := cond.Span()
+	assert.Equal(t, 0, start)
+	assert.Equal(t, 36, end)
+
+	start, end = and.Span()
+	assert.Equal(t, 15, start)
+	assert.Equal(t, 36, end)
+}
+
+func TestParseCondition_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"", "expected identifier or value, found <eof>"},
+		{"a", "expected comparison operator, found <eof>"},
+		{"a = ", "expected identifier or value, found <eof>"},
+		{"a == 1", "expected identifier or value, found '='"},
+		{"a = 1 and", "expected identifier or value, found <eof>"},
+		{"a = 1 b = 2", "unexpected token after condition: <ident> 'b'"},
+		{"(a = 1", "missing end of condition group: ')'"},
+		{"a in 1", "missing start of list: '('"},
+		{"a in (1 2)", "expected ',' or ')', found <num> '2'"},
+		{"a in ()", "expected identifier or value, found ')'"},
+		{"and = 1", "expected identifier or value, found <ident> 'and'"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseCondition(test.input)
+		assert.EqualError(t, err, test.err, test.input)
+	}
+
+	_, err := ParseCondition("((((a = 1))))", MaxDepth(3))
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestCondition_Evaluate(t *testing.T) {
+	vars := map[string]any{
+		"age":     30,
+		"score":   2.5,
+		"name":    "alice",
+		"admin":   true,
+		"timeout": 1500 * time.Millisecond,
+		"created": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"deleted": nil,
+		"big":     new(big.Int).Lsh(big.NewInt(1), 70),
+		"user":    map[string]any{"role": "owner", "tags": map[string]any{"count": uint8(3)}},
+		"org.id":  int64(7),
+	}
+
+	tests := []struct {
+		input  string
+		output bool
+	}{
+		{"age = 30", true},
+		{"age != 30", false},
+		{"age > 18 and age < 65", true},
+		{"age >= 31 or score <= 2.5", true},
+		{"score > age", false},
+		{"-1 < age", true},
+		{"big > 18446744073709551615", true},
+		{`name = "alice"`, true},
+		{`name < "bob"`, true},
+		{"admin = true", true},
+		{"not admin = true", false},
+		{`user.role in ("admin", "owner")`, true},
+		{"user.tags.count in (1, 2)", false},
+		{"org.id = 7", true},
+		{"timeout > 1s", true},
+		{"created < 2024-06-01T00:00:00Z", true},
+		{"created = 2024-01-01T00:00:00Z", true},
+		{"deleted = null", true},
+		{"age = null", false},
+		{"age != null", true},
+		{"deleted = NULL", true},
+		{`name = "bob" and missing = 1`, false},
+		{`name = "alice" or missing = 1`, true},
+	}
+
+	for _, test := range tests {
+		cond, err := ParseCondition(test.input, DurationLiterals(), TimestampLiterals())
+		require.NoError(t, err, test.input)
+
+		result, err := cond.Evaluate(vars)
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.output, result, test.input)
+	}
+}
+
+func TestCondition_Evaluate_Errors(t *testing.T) {
+	vars := map[string]any{"age": 30, "name": "alice", "admin": true, "user": "bob"}
+
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"missing = 1", "undefined variable: 'missing'"},
+		{"user.role = 1", "undefined variable: 'user.role'"},
+		{`age = "30"`, "cannot compare int with string"},
+		{"admin < true", "cannot order bool with bool"},
+		{"admin = 1", "cannot compare bool with uint64"},
+		{"age < null", "cannot order int with symbolizer.Null"},
+		{"not missing = 1", "undefined variable: 'missing'"},
+	}
+
+	for _, test := range tests {
+		cond, err := ParseCondition(test.input)
+		require.NoError(t, err, test.input)
+
+		result, err := cond.Evaluate(vars)
+		assert.EqualError(t, err, test.err, test.input)
+		assert.False(t, result, test.input)
+	}
+}
@@ -0,0 +1,62 @@
+package symbolizer
+
+// LexerCursor provides custom scanners registered with the CustomScanner ParserOption with
+// access to the input of the lexer. The cursor begins at the symbol that triggered the scanner.
+type LexerCursor struct {
+	lexer *lexer
+	start int
+}
+
+// Char returns the unicode symbol under the cursor. If the input is exhausted, an EoF rune is returned.
+func (cursor *LexerCursor) Char() rune { return cursor.lexer.char() }
+
+// Peek returns the unicode symbol ahead of the cursor without moving it.
+// If the input is exhausted, an EoF rune is returned.
+func (cursor *LexerCursor) Peek() rune { return cursor.lexer.peek() }
+
+// Advance moves the cursor past the symbol currently under it
+func (cursor *LexerCursor) Advance() { cursor.lexer.advanceCursor() }
+
+// Done returns whether the input is exhausted
+func (cursor *LexerCursor) Done() bool { return cursor.lexer.done() }
+
+// Start returns the byte offset at which the custom scanner was triggered
+func (cursor *LexerCursor) Start() int { return cursor.start }
+
+// Position returns the current byte offset of the cursor
+func (cursor *LexerCursor) Position() int { return cursor.lexer.cursor }
+
+// Reset moves the cursor back to the offset at which the custom scanner was triggered
+func (cursor *LexerCursor) Reset() { cursor.lexer.cursor = cursor.start }
+
+// Literal returns the input symbols between the trigger offset and the current cursor position
+func (cursor *LexerCursor) Literal() string {
+	return cursor.lexer.collectBetween(cursor.start, cursor.lexer.cursor)
+}
+
+// Token returns a Token of the given kind that spans the input
+// from the trigger offset until the current cursor position.
+func (cursor *LexerCursor) Token(kind TokenKind) Token {
+	return Token{kind, cursor.Literal(), cursor.start, cursor.lexer.cursor, nil}
+}
+
+// customScanner is a user defined scanner registered with the CustomScanner ParserOption
+type customScanner struct {
+	trigger func(rune) bool
+	scan    func(*LexerCursor) Token
+}
+
+// scanCustom invokes the given custom scanner at the current position of the lexer.
+// Returns false if the scanner did not consume any input, in which case the lexer
+// is reset to where it was before the scanner was invoked.
+func (lexer *lexer) scanCustom(custom customScanner) (Lexeme, bool) {
+	cursor := &LexerCursor{lexer: lexer, start: lexer.cursor}
+
+	token := custom.scan(cursor)
+	if lexer.cursor <= cursor.start {
+		cursor.Reset()
+		return Lexeme{}, false
+	}
+
+	return Lexeme{token.Kind, cursor.start, lexer.cursor}, true
+}
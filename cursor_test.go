@@ -0,0 +1,61 @@
+package symbolizer
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomScanner(t *testing.T) {
+	const TokenIP TokenKind = -20
+	const TokenVariable TokenKind = -21
+
+	// ipScanner scans IPv4 addresses and declines if the symbols do not form one
+	ipScanner := CustomScanner(isDecChar, func(cursor *LexerCursor) Token {
+		for isDecChar(cursor.Char()) || cursor.Char() == '.' {
+			cursor.Advance()
+		}
+
+		if net.ParseIP(cursor.Literal()) == nil {
+			cursor.Reset()
+		}
+
+		return cursor.Token(TokenIP)
+	})
+
+	// variableScanner scans $-prefixed variables
+	variableScanner := CustomScanner(func(r rune) bool { return r == '$' }, func(cursor *LexerCursor) Token {
+		cursor.Advance()
+		for isIdentChar(cursor.Char()) {
+			cursor.Advance()
+		}
+
+		return cursor.Token(TokenVariable)
+	})
+
+	tests := []struct {
+		input  string
+		output []Token
+	}{
+		{
+			"host=10.0.0.1:$port",
+			[]Token{
+				{TokenIdent, "host", 0, 4, nil}, {'=', "=", 4, 5, nil}, {TokenIP, "10.0.0.1", 5, 13, nil},
+				{':', ":", 13, 14, nil}, {TokenVariable, "$port", 14, 19, nil}, EOFToken(19),
+			},
+		},
+		{
+			"10.0 $ 0x12",
+			[]Token{
+				{TokenNumber, "10", 0, 2, nil}, {'.', ".", 2, 3, nil}, {TokenNumber, "0", 3, 4, nil}, UnicodeToken(' ', 4),
+				{TokenVariable, "$", 5, 6, nil}, UnicodeToken(' ', 6), {TokenHexNumber, "0x12", 7, 11, nil}, EOFToken(11),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		lex := newLexer([]byte(test.input), newParseConfig(ipScanner, variableScanner))
+		assert.Equal(t, test.output, lex.tokens())
+	}
+}
@@ -0,0 +1,147 @@
+package symbolizer
+
+import "fmt"
+
+// EditOp is the operation of an Edit between two Token streams
+type EditOp int
+
+const (
+	// EditInsert inserts Tokens from the second stream into the first
+	EditInsert EditOp = iota + 1
+	// EditDelete deletes Tokens from the first stream
+	EditDelete
+	// EditReplace replaces Tokens from the first stream with Tokens from the second
+	EditReplace
+)
+
+// String implements the Stringer interface for EditOp
+func (op EditOp) String() string {
+	switch op {
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	case EditReplace:
+		return "replace"
+	default:
+		return fmt.Sprintf("<edit:%d>", int(op))
+	}
+}
+
+// Edit is a change between two Token streams generated by DiffTokens
+type Edit struct {
+	Op EditOp
+	// Old are the Tokens of the first stream that are deleted or replaced (empty for EditInsert)
+	Old []Token
+	// New are the Tokens of the second stream that are inserted or replace Old (empty for EditDelete)
+	New []Token
+	// Position is the byte offset in the input of the first stream at which the Edit applies.
+	// For EditInsert, it is the position of the Token before which the New Tokens are inserted.
+	Position int
+}
+
+// String implements the Stringer interface for Edit
+func (edit Edit) String() string {
+	switch edit.Op {
+	case EditInsert:
+		return fmt.Sprintf("%d: insert %v", edit.Position, literals(edit.New))
+	case EditDelete:
+		return fmt.Sprintf("%d: delete %v", edit.Position, literals(edit.Old))
+	default:
+		return fmt.Sprintf("%d: replace %v with %v", edit.Position, literals(edit.Old), literals(edit.New))
+	}
+}
+
+// DiffTokens returns the minimal Edits that transform the Token stream a into the Token stream b, for change
+// detection over symbols without character-level diffs. Tokens are equal if they have the same TokenKind and
+// literal, regardless of their positions. Adjacent deletions and insertions are combined into an EditReplace.
+// The Edits are ordered by their position in a. Returns nil if the streams are equal.
+func DiffTokens(a, b []Token) []Edit {
+	// Compute the lengths of the longest common subsequences of the suffixes of a and b
+	lengths := make([][]int, len(a)+1)
+	for idx := range lengths {
+		lengths[idx] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case sameToken(a[i], b[j]):
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] >= lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	var edits []Edit
+
+	// flush appends an Edit for the Tokens a[i:x] and b[j:y] that are not common to both streams
+	flush := func(i, x, j, y int) {
+		if i == x && j == y {
+			return
+		}
+
+		edit := Edit{Old: a[i:x:x], New: b[j:y:y], Position: diffPosition(a, i)}
+
+		switch {
+		case i == x:
+			edit.Op, edit.Old = EditInsert, nil
+		case j == y:
+			edit.Op, edit.New = EditDelete, nil
+		default:
+			edit.Op = EditReplace
+		}
+
+		edits = append(edits, edit)
+	}
+
+	// Walk the common subsequence and collect the runs of Tokens between the common Tokens
+	i, j, startI, startJ := 0, 0, 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case sameToken(a[i], b[j]):
+			flush(startI, i, startJ, j)
+			i, j = i+1, j+1
+			startI, startJ = i, j
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	flush(startI, len(a), startJ, len(b))
+
+	return edits
+}
+
+// sameToken returns whether two Tokens have the same TokenKind and literal
+func sameToken(a, b Token) bool {
+	return a.Kind == b.Kind && a.Literal == b.Literal
+}
+
+// diffPosition returns the byte offset of the Token at the given index of the stream,
+// or the end of the last Token if the index is past the end of the stream
+func diffPosition(tokens []Token, index int) int {
+	switch {
+	case index < len(tokens):
+		return tokens[index].Position
+	case len(tokens) > 0:
+		return tokens[len(tokens)-1].End
+	default:
+		return 0
+	}
+}
+
+// literals returns the literals of the given Tokens
+func literals(tokens []Token) []string {
+	collected := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		collected = append(collected, token.Literal)
+	}
+
+	return collected
+}
@@ -0,0 +1,44 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTokens(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		edits []string
+	}{
+		{"f(a, b)", "f( a,b )", nil},
+		{"f(a, b)", "f(a, b, c)", []string{"6: insert [, c]"}},
+		{"f(a, b, c)", "f(a, c)", []string{"5: delete [b ,]"}},
+		{"f(a, b)", "g(a, 0x01)", []string{"0: replace [f] with [g]", "5: replace [b] with [0x01]"}},
+		{"map[string]uint64", "map[string]string", []string{"11: replace [uint64] with [string]"}},
+		{"", "a", []string{"0: insert [a]"}},
+		{"a b", "", []string{"0: delete [a b]"}},
+	}
+
+	for _, test := range tests {
+		edits := DiffTokens(Tokenize(test.a, IgnoreWhitespaces()), Tokenize(test.b, IgnoreWhitespaces()))
+
+		var formatted []string
+		for _, edit := range edits {
+			formatted = append(formatted, edit.String())
+		}
+
+		assert.Equal(t, test.edits, formatted, "%v -> %v", test.a, test.b)
+	}
+
+	edits := DiffTokens(Tokenize("a + b"), Tokenize("a - b"))
+	assert.Equal(t, []Edit{{
+		Op:       EditReplace,
+		Old:      []Token{{TokenKind('+'), "+", 2, 3, nil}},
+		New:      []Token{{TokenKind('-'), "-", 2, 3, nil}},
+		Position: 2,
+	}}, edits)
+
+	assert.Equal(t, "replace", EditReplace.String())
+	assert.Equal(t, "<edit:0>", EditOp(0).String())
+}
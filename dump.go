@@ -0,0 +1,73 @@
+package symbolizer
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// FormatTokens formats the given Tokens as an aligned table with a row for each Token
+// containing its span within the input, its TokenKind and its quoted literal.
+// It is intended for debugging grammars and is not a stable serialization format.
+func FormatTokens(tokens []Token) string {
+	return formatTokens(tokens, false)
+}
+
+// ColorTokens formats the given Tokens like FormatTokens, but with
+// the kind of each Token colored with ANSI escape codes by its class.
+func ColorTokens(tokens []Token) string {
+	return formatTokens(tokens, true)
+}
+
+// Dump writes the formatted Tokens from the cursor until the end of the input (see FormatTokens)
+// to the given writer, including the EoF Token. The parser is not advanced.
+func (parser *Parser) Dump(w io.Writer) error {
+	tokens := append(parser.RemainingTokens(), EOFToken(len(parser.scanner.input)))
+
+	_, err := io.WriteString(w, FormatTokens(tokens))
+	return err
+}
+
+// formatTokens formats the given Tokens as an aligned table, optionally colored with ANSI escape codes
+func formatTokens(tokens []Token, color bool) string {
+	buffer := new(bytes.Buffer)
+	writer := tabwriter.NewWriter(buffer, 0, 0, 2, ' ', 0)
+
+	for _, token := range tokens {
+		kind := token.Kind.String()
+		if color {
+			kind = ansiColor(token.Kind) + kind + "\x1b[0m"
+		}
+
+		fmt.Fprintf(writer, "%d:%d\t%v\t%q\n", token.Position, token.End, kind, token.Literal)
+	}
+
+	_ = writer.Flush()
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// ansiColor returns the ANSI escape code for the color of a TokenKind. All
+// the escape codes have the same length to preserve the alignment of tables.
+func ansiColor(kind TokenKind) string {
+	switch kind {
+	case TokenMalformed:
+		return "\x1b[31m"
+	case TokenString, TokenBase64, TokenRegex:
+		return "\x1b[32m"
+	case TokenBoolean, TokenNull, TokenPlaceholder, TokenWildcard:
+		return "\x1b[33m"
+	case TokenIdent:
+		return "\x1b[34m"
+	case TokenNumber, TokenHexNumber, TokenDuration, TokenTimestamp, TokenFloat, TokenSuffixed, TokenSemver, TokenAmount:
+		return "\x1b[36m"
+	}
+
+	// Custom keyword kinds
+	if kind.custom() {
+		return "\x1b[35m"
+	}
+
+	return "\x1b[37m"
+}
@@ -0,0 +1,51 @@
+package symbolizer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatTokens(t *testing.T) {
+	tokens := Tokenize("map[string] -10", Keywords(map[string]TokenKind{"map": -10}))
+
+	expected := strings.Join([]string{
+		`0:3    <custom:-10>   "map"`,
+		`3:4    <unicode:'['>  "["`,
+		`4:10   <ident>        "string"`,
+		`10:11  <unicode:']'>  "]"`,
+		`11:12  <unicode:' '>  " "`,
+		`12:15  <num>          "-10"`,
+		`15:15  <eof>          ""`,
+	}, "\n")
+
+	assert.Equal(t, expected, FormatTokens(tokens))
+	assert.Equal(t, "", FormatTokens(nil))
+
+	colored := ColorTokens(tokens[:3])
+	assert.Equal(t, strings.Join([]string{
+		"0:3   \x1b[35m<custom:-10>\x1b[0m   \"map\"",
+		"3:4   \x1b[37m<unicode:'['>\x1b[0m  \"[\"",
+		"4:10  \x1b[34m<ident>\x1b[0m        \"string\"",
+	}, "\n"), colored)
+}
+
+func TestParser_Dump(t *testing.T) {
+	parser := NewParser("(a, \"b\")", IgnoreWhitespaces())
+	parser.Advance()
+
+	builder := new(strings.Builder)
+	assert.NoError(t, parser.Dump(builder))
+
+	assert.Equal(t, strings.Join([]string{
+		`1:2  <ident>        "a"`,
+		`2:3  <unicode:','>  ","`,
+		`4:7  <str>          "\"b\""`,
+		`7:8  <unicode:')'>  ")"`,
+		`8:8  <eof>          ""`,
+	}, "\n"), builder.String())
+
+	// Parser must not have been advanced
+	assert.Equal(t, Token{TokenIdent, "a", 1, 2, nil}, parser.Cursor())
+}
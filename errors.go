@@ -0,0 +1,205 @@
+package symbolizer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrLimitExceeded is the sentinel error for Errors that occur when the
+// input exceeds a limit specified with MaxTokens or MaxInputBytes
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// ErrNonASCII is the sentinel error for Errors that occur when the input
+// contains non-ASCII symbols while the StrictASCII option is enabled
+var ErrNonASCII = errors.New("non-ASCII symbol")
+
+// ErrMissingEnclosureStart is the sentinel error for Errors that occur
+// when an enclosure is expected but its opening symbol is not found
+var ErrMissingEnclosureStart = errors.New("missing start of enclosure")
+
+// ErrUnterminatedEnclosure is the sentinel error for Errors that occur
+// when the input ends before the closing symbol of an enclosure
+var ErrUnterminatedEnclosure = errors.New("unterminated enclosure")
+
+// ErrInvalidValue is the sentinel error for Errors that occur when the
+// literal of a Token cannot be converted into a value (such as odd length hex)
+var ErrInvalidValue = errors.New("invalid value")
+
+// ErrValueOverflow is the sentinel error for Errors that occur when the
+// value of a numeric Token is out of the range of the type it is converted into
+var ErrValueOverflow = errors.New("value overflow")
+
+// ErrInvalidConversion is the sentinel error for Errors that occur when a Token cannot
+// be converted into the requested type (such as a TokenIdent into a value or a float into an integer)
+var ErrInvalidConversion = errors.New("invalid conversion")
+
+// ErrUnexpectedToken is the sentinel error for Errors that occur when
+// the Token at the cursor is not of the TokenKind required by the parser
+var ErrUnexpectedToken = errors.New("unexpected token")
+
+// ErrTrailingInput is the sentinel error for Errors that occur when Tokens remain
+// after a value is parsed while the RejectTrailing option is enabled
+var ErrTrailingInput = errors.New("trailing input")
+
+// ErrMalformed is the sentinel error for Errors that occur when the input
+// contains a malformed symbol while the StrictMode option is enabled
+var ErrMalformed = errors.New("malformed symbol")
+
+// Error represents an error encountered while parsing along
+// with the byte offset in the input at which it occurred.
+// Errors may be classified by a sentinel error such as ErrLimitExceeded,
+// which can be checked for with errors.Is.
+type Error struct {
+	Position int
+	Message  string
+	// Err is the sentinel error that classifies the Error, if any
+	Err error
+}
+
+// Error implements the error interface for Error
+func (err *Error) Error() string {
+	return err.Message
+}
+
+// Unwrap returns the sentinel error that classifies the Error, if any
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+// ErrorList is a list of Errors accumulated by a Parser.
+// It implements the error interface, so that it can be returned as a single error.
+type ErrorList []*Error
+
+// Add appends an Error with the given position and message to the ErrorList
+func (list *ErrorList) Add(pos int, msg string) {
+	*list = append(*list, &Error{Position: pos, Message: msg})
+}
+
+// Len returns the number of Errors in the ErrorList
+func (list ErrorList) Len() int { return len(list) }
+
+// Sort sorts the Errors in the ErrorList by their position
+func (list ErrorList) Sort() {
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Position < list[j].Position })
+}
+
+// Err returns the ErrorList as an error if it contains any Errors, otherwise it returns nil
+func (list ErrorList) Err() error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	return list
+}
+
+// Error implements the error interface for ErrorList.
+// It returns the message of the first error and the number of other errors.
+func (list ErrorList) Error() string {
+	switch len(list) {
+	case 0:
+		return "no errors"
+	case 1:
+		return list[0].Error()
+	default:
+		return fmt.Sprintf("%v (and %d more errors)", list[0], len(list)-1)
+	}
+}
+
+// Errors returns all the Errors encountered by the parser and any parsers derived from it
+// for nested content. An error returned by any of the parser methods is also accumulated,
+// as are the errors that the parser recovers from when created with RecoverMalformed.
+func (parser *Parser) Errors() ErrorList {
+	return *parser.errors
+}
+
+// errorf generates a new Error at the given position with a formatted message
+// and records it in the list of errors accumulated by the parser before returning it.
+func (parser *Parser) errorf(pos int, format string, args ...any) error {
+	err := &Error{Position: pos, Message: fmt.Sprintf(format, args...)}
+	parser.record(err)
+
+	return err
+}
+
+// sentinelf generates a new Error classified by the sentinel error at the given position with a formatted
+// message and records it in the list of errors accumulated by the parser before returning it.
+func (parser *Parser) sentinelf(pos int, sentinel error, format string, args ...any) error {
+	err := &Error{Position: pos, Message: fmt.Sprintf(format, args...), Err: sentinel}
+	parser.record(err)
+
+	return err
+}
+
+// valueError records a value conversion error at the given position, retaining its sentinel error (if any)
+func (parser *Parser) valueError(pos int, err error) error {
+	var sentinel error
+	if classified, ok := err.(*Error); ok {
+		sentinel = classified.Err
+	}
+
+	return parser.sentinelf(pos, sentinel, "%v", err)
+}
+
+// valueErrorf generates a new Error classified by the sentinel error for a literal that cannot be converted
+// into a value. Its position is set to the position of the Token with Token.locate by the Token methods.
+func valueErrorf(sentinel error, format string, args ...any) error {
+	return &Error{Message: fmt.Sprintf(format, args...), Err: sentinel}
+}
+
+// numericSentinel returns the sentinel error for a failed numeric conversion from strconv,
+// which is ErrValueOverflow if the value is out of range and ErrInvalidValue otherwise.
+func numericSentinel(cause error) error {
+	if errors.Is(cause, strconv.ErrRange) {
+		return ErrValueOverflow
+	}
+
+	return ErrInvalidValue
+}
+
+// recordStrictError records an Error classified as ErrNonASCII into the list of errors accumulated by the
+// parser if the Token is malformed due to a non-ASCII symbol while StrictASCII is enabled.
+func (parser *Parser) recordStrictError(token Token) {
+	if !parser.scanner.config.strict || token.Kind != TokenMalformed {
+		return
+	}
+
+	offset := strings.IndexFunc(token.Literal, func(char rune) bool { return char >= utf8.RuneSelf })
+	if offset < 0 {
+		return
+	}
+
+	// Sub-parsers rescan the input of their parser, so the Error may already be recorded
+	position := token.Position + offset
+	for _, err := range *parser.errors {
+		if err.Position == position && err.Err == ErrNonASCII {
+			return
+		}
+	}
+
+	symbol, _ := utf8.DecodeRuneInString(token.Literal[offset:])
+	parser.record(&Error{
+		Position: position,
+		Message:  fmt.Sprintf("non-ASCII symbol not permitted: %q", symbol),
+		Err:      ErrNonASCII,
+	})
+}
+
+// recordScanError records the terminal error of the parser's lexer (if any)
+// into the list of errors accumulated by the parser, only once.
+func (parser *Parser) recordScanError() {
+	if parser.scanner.err == nil || parser.scanner.errRecorded {
+		return
+	}
+
+	*parser.errors = append(*parser.errors, parser.scanner.err)
+	parser.scanner.errRecorded = true
+
+	// Lexers that rescan an input do not report their terminal error to the metrics
+	if metrics := parser.scanner.config.metrics; metrics != nil && parser.scanner.metrics == nil {
+		metrics.ObserveError(parser.scanner.err)
+	}
+}
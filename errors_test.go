@@ -0,0 +1,297 @@
+package symbolizer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorList(t *testing.T) {
+	var list ErrorList
+	assert.NoError(t, list.Err())
+	assert.Equal(t, "no errors", list.Error())
+
+	list.Add(12, "second error")
+	assert.EqualError(t, list.Err(), "second error")
+
+	list.Add(3, "first error")
+	list.Add(20, "third error")
+	assert.Equal(t, 3, list.Len())
+	assert.EqualError(t, list.Err(), "second error (and 2 more errors)")
+
+	list.Sort()
+	assert.Equal(t, ErrorList{
+		{Position: 3, Message: "first error"},
+		{Position: 12, Message: "second error"},
+		{Position: 20, Message: "third error"},
+	}, list)
+}
+
+func TestParser_Errors(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		errors  ErrorList
+	}{
+		{
+			`{a: 1, b: 2}`, []ParserOption{IgnoreWhitespaces()},
+			nil,
+		},
+		{
+			`{a: 1, b 2, a: 3, c: {d 4}, e: 0x123}`, []ParserOption{IgnoreWhitespaces(), RecoverMalformed()},
+			ErrorList{
+				{Position: 9, Message: "missing pair separator <unicode:':'> after key: 'b'"},
+				{Position: 12, Message: "duplicate key in group: 'a'"},
+				{Position: 24, Message: "missing pair separator <unicode:':'> after key: 'd'"},
+				{Position: 31, Message: "invalid hex token: encoding/hex: odd length hex string", Err: ErrInvalidValue},
+			},
+		},
+		{
+			`{a: 1, b 2, a: 3}`, []ParserOption{IgnoreWhitespaces()},
+			ErrorList{
+				{Position: 9, Message: "missing pair separator <unicode:':'> after key: 'b'"},
+			},
+		},
+		{
+			`{a: {b: 1}`, nil,
+			ErrorList{
+				{Position: 0, Message: "missing end of enclosure: '}'", Err: ErrUnterminatedEnclosure},
+			},
+		},
+		{
+			`a: 1}`, nil,
+			ErrorList{
+				{Position: 0, Message: "missing start of enclosure: '{'", Err: ErrMissingEnclosureStart},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+		_, err := parser.KeyedGroup(EnclosureCurly(), ':', ',')
+
+		errors := parser.Errors()
+		assert.Equal(t, test.errors, errors, test.input)
+
+		// Without recovery, the first error is returned
+		if errors.Len() > 0 && !parser.scanner.config.recover {
+			assert.Equal(t, errors[0], err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	tests := []struct {
+		input    string
+		options  []ParserOption
+		tokens   []Token
+		position int
+		error    string
+	}{
+		{
+			"a,b,c", []ParserOption{MaxTokens(5)},
+			[]Token{{TokenIdent, "a", 0, 1, nil}, {',', ",", 1, 2, nil}, {TokenIdent, "b", 2, 3, nil}, {',', ",", 3, 4, nil}, {TokenIdent, "c", 4, 5, nil}},
+			0, "",
+		},
+		{
+			"a,b,c", []ParserOption{MaxTokens(3)},
+			[]Token{{TokenIdent, "a", 0, 1, nil}, {',', ",", 1, 2, nil}, {TokenIdent, "b", 2, 3, nil}},
+			3, "token limit exceeded: 3 tokens",
+		},
+		{
+			"a, b, c", []ParserOption{MaxTokens(2), IgnoreWhitespaces()},
+			[]Token{{TokenIdent, "a", 0, 1, nil}, {',', ",", 1, 2, nil}},
+			3, "token limit exceeded: 2 tokens",
+		},
+		{
+			"a,b,c", []ParserOption{MaxInputBytes(5)},
+			[]Token{{TokenIdent, "a", 0, 1, nil}, {',', ",", 1, 2, nil}, {TokenIdent, "b", 2, 3, nil}, {',', ",", 3, 4, nil}, {TokenIdent, "c", 4, 5, nil}},
+			0, "",
+		},
+		{
+			"a,b,c", []ParserOption{MaxInputBytes(4)},
+			nil,
+			4, "input size limit exceeded: 4 bytes",
+		},
+	}
+
+	for _, test := range tests {
+		// Check the limits on the Parser
+		parser := NewParser(test.input, test.options...)
+
+		var tokens []Token
+		for !parser.Exhausted() {
+			tokens = append(tokens, parser.Cursor())
+			parser.Advance()
+		}
+
+		assert.Equal(t, test.tokens, tokens)
+
+		// Check the limits on the Lexer
+		lexer := NewLexer(test.input, test.options...)
+		for !lexer.Done() {
+			lexer.Next()
+		}
+
+		if test.error == "" {
+			assert.Equal(t, 0, parser.Errors().Len())
+			assert.NoError(t, lexer.Err())
+			continue
+		}
+
+		errors := parser.Errors()
+		assert.Equal(t, 1, errors.Len())
+		assert.EqualError(t, errors[0], test.error)
+		assert.Equal(t, test.position, errors[0].Position)
+		assert.ErrorIs(t, errors[0], ErrLimitExceeded)
+
+		assert.EqualError(t, lexer.Err(), test.error)
+		assert.ErrorIs(t, lexer.Err(), ErrLimitExceeded)
+	}
+}
+
+func TestStrictASCII(t *testing.T) {
+	tokens := Tokenize(`café "naïve" x`, StrictASCII(), IgnoreWhitespaces())
+	assert.Equal(t, []Token{
+		{TokenIdent, "caf", 0, 3, nil}, {TokenMalformed, "é", 3, 5, nil},
+		{TokenMalformed, `"naïve"`, 6, 14, nil}, {TokenIdent, "x", 15, 16, nil}, EOFToken(16),
+	}, tokens)
+
+	// Errors are reported once for each malformed symbol, including those within nested content
+	parser := NewParser(`{a: é, b: "ü"}`, StrictASCII(), IgnoreWhitespaces())
+	_, err := parser.KeyedGroup(EnclosureCurly(), ':', ',')
+	assert.NoError(t, err)
+
+	errs := parser.Errors()
+	require.Equal(t, 2, errs.Len())
+	assert.EqualError(t, errs[0], "non-ASCII symbol not permitted: 'é'")
+	assert.Equal(t, 4, errs[0].Position)
+	assert.ErrorIs(t, errs[1], ErrNonASCII)
+	assert.Equal(t, 12, errs[1].Position)
+
+	// Non-ASCII symbols are unaffected without the option
+	assert.Equal(t, TokenIdent, Tokenize("café")[0].Kind)
+}
+
+func TestStrictMode(t *testing.T) {
+	tests := []struct {
+		input    string
+		options  []ParserOption
+		tokens   []string
+		err      string
+		sentinel error
+		position int
+	}{
+		{`f(a, "open`, nil, []string{"f", "(", "a", ","}, `unterminated string: '"open'`, ErrMalformed, 5},
+		{"f(0x, 1)", nil, []string{"f", "("}, "hex prefix without digits: '0x'", ErrMalformed, 2},
+		{"f(-0x)", nil, []string{"f", "("}, "hex prefix without digits: '-0x'", ErrMalformed, 2},
+		{"a \xff b", nil, []string{"a"}, "invalid UTF-8 byte: 0xff", ErrMalformed, 2},
+		{"a `raw", []ParserOption{RawStrings()}, []string{"a"}, "unterminated string: '`raw'", ErrMalformed, 2},
+		{`a "né`, []ParserOption{StrictASCII()}, []string{"a"}, "non-ASCII symbol not permitted: 'é'", ErrNonASCII, 4},
+		{"f(a, b)", nil, []string{"f", "(", "a", ",", "b", ")"}, "", nil, 0},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, append(test.options, StrictMode(), IgnoreWhitespaces())...)
+
+		var literals []string
+		for {
+			token, err := parser.Next()
+			if err != nil {
+				assert.EqualError(t, err, test.err, test.input)
+				assert.ErrorIs(t, err, test.sentinel, test.input)
+				assert.Equal(t, test.position, err.(*Error).Position, test.input)
+
+				// The Error is also reported in the Errors of the Parser
+				assert.Equal(t, 1, parser.Errors().Len(), test.input)
+				break
+			}
+
+			if token.Kind == TokenEoF {
+				assert.Empty(t, test.err, test.input)
+				break
+			}
+
+			literals = append(literals, token.Literal)
+		}
+
+		assert.Equal(t, test.tokens, literals, test.input)
+	}
+
+	// The Lexer reports the Error once it terminates
+	lexer := NewLexer(`a "b`, StrictMode())
+	for lexer.Next().Kind != TokenEoF {
+	}
+
+	assert.ErrorIs(t, lexer.Err(), ErrMalformed)
+
+	// Malformed Tokens are generated without the option
+	assert.Equal(t, TokenMalformed, Tokenize(`"a`)[0].Kind)
+}
+
+func TestSentinelErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      func() error
+		sentinel error
+		position int
+	}{
+		{"missing start", func() error {
+			_, err := NewParser("a (b)").Unwrap(EnclosureParens())
+			return err
+		}, ErrMissingEnclosureStart, 0},
+		{"unterminated", func() error {
+			_, err := NewParser("(a, (b)").Unwrap(EnclosureParens())
+			return err
+		}, ErrUnterminatedEnclosure, 0},
+		{"depth", func() error {
+			_, err := NewParser("((()))", MaxDepth(2)).Unwrap(EnclosureParens())
+			return err
+		}, ErrLimitExceeded, 2},
+		{"overflow", func() error {
+			_, err := Token{Kind: TokenNumber, Literal: "18446744073709551616", Position: 7}.Value()
+			return err
+		}, ErrValueOverflow, 7},
+		{"suffixed overflow", func() error {
+			_, err := Token{Kind: TokenSuffixed, Literal: "-10E", Position: 3}.Value()
+			return err
+		}, ErrValueOverflow, 3},
+		{"int64 overflow", func() error {
+			_, err := Token{Kind: TokenNumber, Literal: "9223372036854775808", Position: 1}.Int64()
+			return err
+		}, ErrValueOverflow, 1},
+		{"invalid hex", func() error {
+			_, err := Token{Kind: TokenHexNumber, Literal: "0x123", Position: 5}.Value()
+			return err
+		}, ErrInvalidValue, 5},
+		{"invalid semver", func() error {
+			_, err := Token{Kind: TokenSemver, Literal: "1.2", Position: 4}.Value()
+			return err
+		}, ErrInvalidValue, 4},
+		{"conversion", func() error {
+			_, err := Token{Kind: TokenIdent, Literal: "a", Position: 2}.Value()
+			return err
+		}, ErrInvalidConversion, 2},
+		{"fractional", func() error {
+			_, err := Token{Kind: TokenFloat, Literal: "0.5", Position: 6}.Uint64()
+			return err
+		}, ErrInvalidConversion, 6},
+		{"parsed value", func() error {
+			_, err := NewParser("(a: 0x1)", IgnoreWhitespaces()).KeyedGroup(EnclosureParens(), ':', ',')
+			return err
+		}, ErrInvalidValue, 4},
+	}
+
+	for _, test := range tests {
+		err := test.err()
+		assert.ErrorIs(t, err, test.sentinel, test.name)
+
+		var located *Error
+		require.True(t, errors.As(err, &located), test.name)
+		assert.Equal(t, test.position, located.Position, test.name)
+	}
+}
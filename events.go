@@ -0,0 +1,221 @@
+package symbolizer
+
+// EventHandler receives the events of a symbol parsed with ParseEvents, in the order that they occur in the input.
+// Parsing stops at the first error returned by any of the callbacks, which is then returned by ParseEvents.
+type EventHandler interface {
+	// OnGroupStart is called with the opening Token of a group such as '{', '[' or '('
+	OnGroupStart(open Token) error
+	// OnKey is called with the key Token of a keyed element within a group, before the events of its value
+	OnKey(key Token) error
+	// OnValue is called with the value of an element (which is not a group) and the byte offsets of its source
+	OnValue(value any, start, end int) error
+	// OnGroupEnd is called with the closing Token of a group such as '}', ']' or ')'
+	OnGroupEnd(close Token) error
+}
+
+// Events is an EventHandler composed of callback functions. Callbacks that are nil are ignored.
+type Events struct {
+	GroupStart func(open Token) error
+	Key        func(key Token) error
+	Value      func(value any, start, end int) error
+	GroupEnd   func(close Token) error
+}
+
+// OnGroupStart implements the EventHandler interface for Events
+func (events Events) OnGroupStart(open Token) error {
+	if events.GroupStart == nil {
+		return nil
+	}
+
+	return events.GroupStart(open)
+}
+
+// OnKey implements the EventHandler interface for Events
+func (events Events) OnKey(key Token) error {
+	if events.Key == nil {
+		return nil
+	}
+
+	return events.Key(key)
+}
+
+// OnValue implements the EventHandler interface for Events
+func (events Events) OnValue(value any, start, end int) error {
+	if events.Value == nil {
+		return nil
+	}
+
+	return events.Value(value, start, end)
+}
+
+// OnGroupEnd implements the EventHandler interface for Events
+func (events Events) OnGroupEnd(close Token) error {
+	if events.GroupEnd == nil {
+		return nil
+	}
+
+	return events.GroupEnd(close)
+}
+
+// ParseEvents parses a symbol such as `{name: "alice", tags: [admin, 0x01], opts: (a: 1)}` and pushes its structure
+// to the handler as events, without materializing it. Groups are wrapped in curly brackets, square brackets or
+// parenthesis and contain elements separated by ','. Each element is either a value or a key Token and a value
+// separated by ':'. Values that are a single token are converted with Token.Value, values that are groups generate
+// their own events and all other values are returned as their source text. Whitespaces are always ignored.
+//
+// Returns an error if the symbol is malformed, if the input remains after the symbol or if a callback fails.
+// Events are pushed as the input is parsed, so the handler may receive events before an error is encountered.
+func ParseEvents(input string, handler EventHandler, opts ...ParserOption) error {
+	parser := NewParser(input, opts...)
+
+	parser.skipSpaces()
+	if err := parser.parseEventValue(handler); err != nil {
+		return err
+	}
+
+	if parser.skipSpaces(); !parser.Exhausted() {
+		return parser.errorf(parser.curr.Position, "unexpected trailing input: '%v'", parser.curr.Literal)
+	}
+
+	return parser.Errors().Err()
+}
+
+// parseEventValue parses the value at the cursor and pushes its events to the handler.
+// The parser is left at the first Token after the value that is not a whitespace.
+func (parser *Parser) parseEventValue(handler EventHandler) error {
+	if closer, ok := groupCloser(parser.curr.Kind); ok {
+		return parser.parseEventGroup(handler, closer)
+	}
+
+	// Collect the Tokens of the value until a delimiter or closer at the top nesting level
+	var tokens []Token
+	for nesting := 0; !parser.Exhausted(); parser.Advance() {
+		if nesting == 0 && (parser.IsCursor(',') || isGroupCloser(parser.curr.Kind)) {
+			break
+		}
+
+		nesting += nestingDelta(parser.curr.Kind)
+		tokens = append(tokens, parser.curr)
+	}
+
+	// Trim the trailing whitespace Tokens
+	for len(tokens) != 0 && isSpaceToken(tokens[len(tokens)-1]) {
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	if len(tokens) == 0 {
+		return parser.errorf(parser.curr.Position, "missing value")
+	}
+
+	first, last := tokens[0], tokens[len(tokens)-1]
+
+	var value any = parser.scanner.collectBetween(first.Position, last.End)
+	if len(tokens) == 1 && first.Kind.CanValue() {
+		var err error
+		if value, err = parser.tokenValue(first); err != nil {
+			return err
+		}
+	}
+
+	return handler.OnValue(value, first.Position, last.End)
+}
+
+// parseEventGroup parses the group that opens at the cursor and pushes its events to the handler.
+// The parser is left at the first Token after the closing Token that is not a whitespace.
+func (parser *Parser) parseEventGroup(handler EventHandler, closer TokenKind) error {
+	if err := parser.descend(); err != nil {
+		return err
+	}
+
+	defer parser.ascend()
+
+	open := parser.curr
+	if err := handler.OnGroupStart(open); err != nil {
+		return err
+	}
+
+	parser.Advance()
+
+	for parser.skipSpaces(); !parser.IsCursor(closer); parser.skipSpaces() {
+		if parser.Exhausted() {
+			return parser.sentinelf(open.Position, ErrUnterminatedEnclosure, "missing end of enclosure: '%v'", string(rune(closer)))
+		}
+
+		// Collect the key of keyed elements
+		if _, opens := groupCloser(parser.curr.Kind); !opens && parser.peekPastSpaces(':') {
+			if err := handler.OnKey(parser.curr); err != nil {
+				return err
+			}
+
+			parser.Advance()
+			parser.skipSpaces()
+			parser.Advance()
+			parser.skipSpaces()
+		}
+
+		if err := parser.parseEventValue(handler); err != nil {
+			return err
+		}
+
+		// Move past the element delimiter
+		switch {
+		case parser.IsCursor(','):
+			parser.Advance()
+		case !parser.IsCursor(closer) && !parser.Exhausted():
+			return parser.errorf(parser.curr.Position, "expected ',' or '%v', found '%v'", string(rune(closer)), parser.curr.Literal)
+		}
+	}
+
+	if err := handler.OnGroupEnd(parser.curr); err != nil {
+		return err
+	}
+
+	parser.Advance()
+	parser.skipSpaces()
+
+	return nil
+}
+
+// skipSpaces advances the parser past any whitespace Tokens under the cursor
+func (parser *Parser) skipSpaces() {
+	for isSpaceToken(parser.curr) {
+		parser.Advance()
+	}
+}
+
+// peekPastSpaces returns whether the first Token after the cursor that is not a whitespace is of the given kind.
+// Since whitespaces may be ignored by the Parser, the next Token is checked first to avoid scanning ahead.
+func (parser *Parser) peekPastSpaces(kind TokenKind) bool {
+	if parser.IsPeek(kind) {
+		return true
+	}
+
+	if !isSpaceToken(parser.next) {
+		return false
+	}
+
+	clone := parser.Clone()
+	clone.Advance()
+	clone.skipSpaces()
+
+	return clone.IsCursor(kind)
+}
+
+// groupCloser returns the closing TokenKind for a group opening TokenKind
+func groupCloser(kind TokenKind) (TokenKind, bool) {
+	switch kind {
+	case '{':
+		return '}', true
+	case '[':
+		return ']', true
+	case '(':
+		return ')', true
+	default:
+		return 0, false
+	}
+}
+
+// isGroupCloser returns whether the TokenKind closes a group
+func isGroupCloser(kind TokenKind) bool {
+	return kind == '}' || kind == ']' || kind == ')'
+}
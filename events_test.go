@@ -0,0 +1,110 @@
+package symbolizer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordEvents returns an EventHandler that records the events it receives as strings
+func recordEvents(events *[]string) Events {
+	return Events{
+		GroupStart: func(open Token) error {
+			*events = append(*events, "start "+open.Literal)
+			return nil
+		},
+		Key: func(key Token) error {
+			*events = append(*events, "key "+key.Literal)
+			return nil
+		},
+		Value: func(value any, start, end int) error {
+			*events = append(*events, fmt.Sprintf("value %v %d:%d", value, start, end))
+			return nil
+		},
+		GroupEnd: func(close Token) error {
+			*events = append(*events, "end "+close.Literal)
+			return nil
+		},
+	}
+}
+
+func TestParseEvents(t *testing.T) {
+	tests := []struct {
+		input  string
+		events []string
+		err    string
+	}{
+		{
+			`{name: "alice", tags: [admin, 0x01], opts: ( a : f(x, y) )}`,
+			[]string{
+				"start {", "key name", "value alice 7:14", "key tags", "start [", "value admin 23:28", "value [1] 30:34", "end ]",
+				"key opts", "start (", "key a", "value f(x, y) 49:56", "end )", "end }",
+			},
+			"",
+		},
+		{
+			` 42 `,
+			[]string{"value 42 1:3"},
+			"",
+		},
+		{
+			`[]`,
+			[]string{"start [", "end ]"},
+			"",
+		},
+		{
+			`{a: 1] `,
+			[]string{"start {", "key a", "value 1 4:5"},
+			"expected ',' or '}', found ']'",
+		},
+		{
+			`{a: 1, b: }`,
+			[]string{"start {", "key a", "value 1 4:5", "key b"},
+			"missing value",
+		},
+		{
+			`[1, 2`,
+			[]string{"start [", "value 1 1:2", "value 2 4:5"},
+			"missing end of enclosure: ']'",
+		},
+		{
+			`(a) b`,
+			[]string{"start (", "value a 1:2", "end )"},
+			"unexpected trailing input: 'b'",
+		},
+	}
+
+	for _, test := range tests {
+		var events []string
+		err := ParseEvents(test.input, recordEvents(&events))
+
+		assert.Equal(t, test.events, events, test.input)
+		if test.err == "" {
+			assert.NoError(t, err, test.input)
+		} else {
+			assert.EqualError(t, err, test.err, test.input)
+		}
+	}
+}
+
+func TestParseEvents_HandlerError(t *testing.T) {
+	stop := errors.New("stop")
+
+	var values int
+	err := ParseEvents(`[1, 2, 3]`, Events{Value: func(any, int, int) error {
+		if values++; values == 2 {
+			return stop
+		}
+
+		return nil
+	}})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, values)
+
+	// The nesting depth is limited with MaxDepth
+	err = ParseEvents(`[[[1]]]`, Events{}, MaxDepth(2))
+	assert.EqualError(t, err, "maximum nesting depth exceeded: 2")
+}
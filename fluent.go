@@ -0,0 +1,127 @@
+package symbolizer
+
+// Chain is a fluent sequence of parsing steps over a Parser, for small fixed-shape symbols such as `(name, 42)`:
+//
+//	err := parser.Expect('(').CaptureIdent(&name).Expect(',').CaptureNumber(&age).Expect(')').Err()
+//
+// Each step consumes the Token at the cursor. The Chain short-circuits on the first step that fails, such
+// that the subsequent steps are skipped and Err returns the Error of the failed step (with its position).
+// The Errors of failed steps are also accumulated by the Parser.
+type Chain struct {
+	parser *Parser
+	err    error
+}
+
+// Chain returns a new Chain of parsing steps that begins at the cursor of the parser
+func (parser *Parser) Chain() *Chain {
+	return &Chain{parser: parser}
+}
+
+// Expect returns a new Chain of parsing steps that begins by consuming a Token of the specified TokenKind
+// at the cursor of the parser. It is equivalent to calling Expect on a new Chain (see Parser.Chain).
+func (parser *Parser) Expect(kind TokenKind) *Chain {
+	return parser.Chain().Expect(kind)
+}
+
+// Err returns the Error of the step that failed, if any
+func (chain *Chain) Err() error {
+	return chain.err
+}
+
+// Then runs a custom parsing step with the Parser of the Chain, if no step has failed yet.
+// If the step returns an error, the Chain fails with it.
+func (chain *Chain) Then(step func(*Parser) error) *Chain {
+	if chain.err == nil {
+		chain.err = step(chain.parser)
+	}
+
+	return chain
+}
+
+// Expect consumes a Token of the specified TokenKind at the cursor (see Parser.Require)
+func (chain *Chain) Expect(kind TokenKind) *Chain {
+	return chain.ExpectAny(kind)
+}
+
+// ExpectAny consumes a Token of any of the specified TokenKinds at the cursor (see Parser.RequireAny)
+func (chain *Chain) ExpectAny(kinds ...TokenKind) *Chain {
+	return chain.Then(func(parser *Parser) error {
+		_, err := parser.RequireAny(kinds...)
+		return err
+	})
+}
+
+// Capture consumes a Token of the specified TokenKind at the cursor and stores it into dst
+func (chain *Chain) Capture(kind TokenKind, dst *Token) *Chain {
+	return chain.Then(func(parser *Parser) (err error) {
+		*dst, err = parser.Require(kind)
+		return err
+	})
+}
+
+// CaptureIdent consumes an identifier at the cursor and stores its literal into dst (see Parser.ExpectIdent)
+func (chain *Chain) CaptureIdent(dst *string) *Chain {
+	return chain.Then(func(parser *Parser) error {
+		token, err := parser.ExpectIdent()
+		if err != nil {
+			return err
+		}
+
+		*dst = token.Literal
+		return nil
+	})
+}
+
+// CaptureString consumes a TokenString at the cursor and stores its unquoted value into dst
+func (chain *Chain) CaptureString(dst *string) *Chain {
+	return chain.capture(func(token Token) (err error) {
+		value, err := token.Value()
+		if err == nil {
+			*dst = value.(string)
+		}
+
+		return err
+	}, TokenString)
+}
+
+// CaptureNumber consumes a numeric Token at the cursor and stores its value into dst (see Token.Int64).
+// The Chain fails if the value of the Token is not a whole number or overflows an int64.
+func (chain *Chain) CaptureNumber(dst *int64) *Chain {
+	return chain.capture(func(token Token) (err error) {
+		*dst, err = token.Int64()
+		return err
+	}, TokenNumber, TokenHexNumber, TokenFloat, TokenSuffixed)
+}
+
+// CaptureFloat consumes a numeric Token at the cursor and stores its value into dst (see Token.Float64)
+func (chain *Chain) CaptureFloat(dst *float64) *Chain {
+	return chain.capture(func(token Token) (err error) {
+		*dst, err = token.Float64()
+		return err
+	}, TokenNumber, TokenHexNumber, TokenFloat, TokenSuffixed)
+}
+
+// CaptureBool consumes a TokenBoolean at the cursor and stores its value into dst
+func (chain *Chain) CaptureBool(dst *bool) *Chain {
+	return chain.capture(func(token Token) (err error) {
+		*dst, err = token.Bool()
+		return err
+	}, TokenBoolean)
+}
+
+// capture consumes a Token of any of the specified TokenKinds at the cursor and stores its value with the given
+// function. Errors from converting the value of the Token are recorded by the Parser at the position of the Token.
+func (chain *Chain) capture(store func(Token) error, kinds ...TokenKind) *Chain {
+	return chain.Then(func(parser *Parser) error {
+		token, err := parser.RequireAny(kinds...)
+		if err != nil {
+			return err
+		}
+
+		if err = store(token); err != nil {
+			return parser.valueError(token.Position, err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,90 @@
+package symbolizer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain(t *testing.T) {
+	var (
+		name   string
+		age    int64
+		score  float64
+		active bool
+		title  string
+		tag    Token
+	)
+
+	parser := NewParser(`(alice, 42, 9.5, true, "dr") #x`, IgnoreWhitespaces(), ScientificNumbers())
+	err := parser.Expect('(').
+		CaptureIdent(&name).Expect(',').
+		CaptureNumber(&age).Expect(',').
+		CaptureFloat(&score).Expect(',').
+		CaptureBool(&active).Expect(',').
+		CaptureString(&title).Expect(')').
+		Then(func(parser *Parser) error {
+			parser.Advance()
+			return nil
+		}).
+		Capture(TokenIdent, &tag).
+		Err()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", name)
+	assert.Equal(t, int64(42), age)
+	assert.Equal(t, 9.5, score)
+	assert.True(t, active)
+	assert.Equal(t, "dr", title)
+	assert.Equal(t, Token{TokenIdent, "x", 30, 31, nil}, tag)
+	assert.True(t, parser.Exhausted())
+}
+
+func TestChain_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   *Error
+	}{
+		{"(bob, 7)", nil},
+		{"[bob, 7]", &Error{0, "expected '(', found '['", ErrUnexpectedToken}},
+		{"(7, bob)", &Error{1, "expected identifier, found '7'", nil}},
+		{"(bob; 7)", &Error{4, "expected ',', found ';'", ErrUnexpectedToken}},
+		{"(bob, 1.5)", &Error{6, "value is not a whole number: '1.5'", ErrInvalidConversion}},
+		{"(bob, 99999999999999999999)", &Error{6, "value out of range for int64: '99999999999999999999'", ErrValueOverflow}},
+		{"(bob, 7", &Error{7, "expected ')', found <eof>", ErrUnexpectedToken}},
+	}
+
+	for _, test := range tests {
+		var (
+			name string
+			age  int64
+		)
+
+		steps := 0
+		step := func(*Parser) error { steps++; return nil }
+
+		parser := NewParser(test.input, IgnoreWhitespaces(), ScientificNumbers())
+		err := parser.Expect('(').Then(step).
+			CaptureIdent(&name).Then(step).
+			Expect(',').Then(step).
+			CaptureNumber(&age).Then(step).
+			Expect(')').Then(step).
+			Err()
+
+		if test.err == nil {
+			assert.NoError(t, err, test.input)
+			assert.Equal(t, 5, steps, test.input)
+			assert.Equal(t, "bob", name)
+			assert.Equal(t, int64(7), age)
+
+			continue
+		}
+
+		// The chain short-circuits at the failed step
+		assert.Equal(t, test.err, err, test.input)
+		assert.Equal(t, test.err.Err, errors.Unwrap(err), test.input)
+		assert.Less(t, steps, 5, test.input)
+		assert.Equal(t, ErrorList{test.err}, parser.Errors(), test.input)
+	}
+}
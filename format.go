@@ -0,0 +1,126 @@
+package symbolizer
+
+import (
+	"strings"
+)
+
+// FormatStyle configures how Format re-emits a symbol.
+// The zero value formats symbols in the canonical style.
+type FormatStyle struct {
+	// Compact omits the space after the ',' between elements
+	// of a group and after the ':' between a key and its value
+	Compact bool
+	// UpperHex formats the digits of hex literals in uppercase instead of lowercase
+	UpperHex bool
+	// Options are the ParserOptions used to parse the symbol,
+	// such as for enabling raw strings or other optional literals
+	Options []ParserOption
+}
+
+// Format re-emits a symbol in a canonical form, so that symbols from different producers can be compared.
+// The symbol is parsed with ParseTree and formatted from its syntax tree, which has the following effects:
+//   - Whitespaces (and Tokens dropped by a TokenFilter, such as comments) around delimiters are removed, and the
+//     elements of a group are separated by ', ' while keys are followed by ': ' or '=' (see FormatStyle.Compact).
+//   - Whitespace between juxtaposed expressions is collapsed into a single space, adjacent expressions remain adjacent.
+//   - A trailing ',' within a group is removed.
+//   - Raw strings and heredocs are quoted with '"' if their content does not contain a '"'.
+//   - The digits of hex literals are lowercased (see FormatStyle.UpperHex).
+//
+// Returns an error if the symbol cannot be parsed with ParseTree.
+func Format(input string, style FormatStyle) (string, error) {
+	node, err := ParseTree(input, style.Options...)
+	if err != nil {
+		return "", err
+	}
+
+	var formatted strings.Builder
+	style.format(&formatted, node)
+
+	return formatted.String(), nil
+}
+
+// format writes the canonical form of a Node into the builder
+func (style FormatStyle) format(formatted *strings.Builder, node Node) {
+	switch node := node.(type) {
+	case *LiteralNode:
+		formatted.WriteString(style.formatLiteral(node.Token))
+
+	case *GroupNode:
+		separator := ", "
+		if style.Compact {
+			separator = ","
+		}
+
+		formatted.WriteString(node.Open.Literal)
+
+		for idx, element := range node.Elements {
+			if idx > 0 {
+				formatted.WriteString(separator)
+			}
+
+			style.format(formatted, element)
+		}
+
+		formatted.WriteString(node.Close.Literal)
+
+	case *KeyValueNode:
+		style.format(formatted, node.Key)
+		formatted.WriteString(node.Separator.Literal)
+
+		if node.Separator.Kind == ':' && !style.Compact {
+			formatted.WriteByte(' ')
+		}
+
+		style.format(formatted, node.Value)
+
+	case *CallNode:
+		style.format(formatted, node.Callee)
+		style.format(formatted, node.Args)
+
+	case *IndexNode:
+		style.format(formatted, node.Target)
+		style.format(formatted, node.Index)
+
+	case *SequenceNode:
+		for idx, element := range node.Nodes {
+			if idx > 0 {
+				if _, end := node.Nodes[idx-1].Span(); end != startOf(element) {
+					formatted.WriteByte(' ')
+				}
+			}
+
+			style.format(formatted, element)
+		}
+
+	default:
+		formatted.WriteString(node.String())
+	}
+}
+
+// formatLiteral returns the canonical form of a literal Token
+func (style FormatStyle) formatLiteral(token Token) string {
+	switch token.Kind {
+	case TokenString:
+		// Strings are already quoted with '"'
+		if strings.HasPrefix(token.Literal, `"`) {
+			return token.Literal
+		}
+
+		value, _ := token.Value()
+		if content := value.(string); !strings.Contains(content, `"`) {
+			return `"` + content + `"`
+		}
+
+		return token.Literal
+
+	case TokenHexNumber:
+		if style.UpperHex {
+			return strings.Replace(strings.ToUpper(token.Literal), "0X", "0x", 1)
+		}
+
+		return strings.ToLower(token.Literal)
+
+	default:
+		return token.Literal
+	}
+}
@@ -0,0 +1,42 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		input  string
+		style  FormatStyle
+		output string
+		err    string
+	}{
+		{"map[string]uint64", FormatStyle{}, "map[string]uint64", ""},
+		{" { a:1 ,b : [ 1,2, ] , c = f( x )[0] } ", FormatStyle{}, "{a: 1, b: [1, 2], c=f(x)[0]}", ""},
+		{" { a:1 ,b : [ 1,2, ] , c = f( x )[0] } ", FormatStyle{Compact: true}, "{a:1,b:[1,2],c=f(x)[0]}", ""},
+		{"a  +\n\tb*c", FormatStyle{}, "a + b*c", ""},
+		{"(0xABcd, -0xFF)", FormatStyle{}, "(0xabcd, -0xff)", ""},
+		{"(0xABcd, -0xff)", FormatStyle{UpperHex: true}, "(0xABCD, -0xFF)", ""},
+		{"f(`raw`, `say \"hi\"`, \"x\")", FormatStyle{Options: []ParserOption{RawStrings()}}, "f(\"raw\", `say \"hi\"`, \"x\")", ""},
+		{"f(<<END\nline\nEND\n)", FormatStyle{Options: []ParserOption{Heredocs()}}, "f(\"line\")", ""},
+		{"(a, b", FormatStyle{}, "", "missing end of enclosure: ')'"},
+	}
+
+	for _, test := range tests {
+		output, err := Format(test.input, test.style)
+
+		if test.err == "" {
+			assert.NoError(t, err, test.input)
+			assert.Equal(t, test.output, output, test.input)
+		} else {
+			assert.EqualError(t, err, test.err, test.input)
+		}
+	}
+
+	// Symbols from different producers must be formatted identically
+	first, _ := Format("transfer( to:0xAB ,amount : 15 )", FormatStyle{})
+	second, _ := Format("transfer(to: 0xab, amount: 15,)", FormatStyle{})
+	assert.Equal(t, first, second)
+}
@@ -2,7 +2,10 @@ module github.com/manishmeganathan/symbolizer
 
 go 1.18
 
-require github.com/stretchr/testify v1.8.1
+require (
+	github.com/stretchr/testify v1.8.1
+	golang.org/x/text v0.14.0
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
@@ -0,0 +1,85 @@
+package symbolizer
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// CanonicalHash returns the SHA-256 hash of the canonical Token stream of a symbol, for deduplicating
+// semantically identical symbols in an index. The symbol is tokenized with the given options and each
+// Token is normalized before it is hashed along with its TokenKind:
+//   - Runs of whitespaces are collapsed into a single space, while leading and trailing whitespaces are dropped.
+//     Whitespaces are dropped entirely with IgnoreWhitespaces and Tokens dropped by a TokenFilter are not hashed.
+//   - Keywords are hashed with their words separated by a single space, and folded to lowercase
+//     with CaseInsensitiveKeywords. Booleans and the digits of hex literals are always lowercased.
+//
+// Returns an error if the symbol contains a malformed Token or if the lexer is terminated (such as by a limit).
+func CanonicalHash(input string, opts ...ParserOption) ([32]byte, error) {
+	scanner := newLexer([]byte(input), newParseConfig(opts...))
+	digest := sha256.New()
+
+	// space indicates if a whitespace run precedes the next Token
+	space, started := false, false
+
+	for {
+		token := scanner.nextToken()
+		if token.Kind == TokenEoF {
+			break
+		}
+
+		if token.Kind == TokenMalformed {
+			return [32]byte{}, &Error{Position: token.Position, Message: fmt.Sprintf("malformed token: '%v'", token.Literal)}
+		}
+
+		if isSpaceToken(token) {
+			space = started
+			continue
+		}
+
+		if space {
+			writeCanonicalToken(digest, Token{Kind: ' ', Literal: " "})
+			space = false
+		}
+
+		writeCanonicalToken(digest, scanner.canonicalToken(token))
+		started = true
+	}
+
+	if scanner.err != nil {
+		return [32]byte{}, scanner.err
+	}
+
+	var hash [32]byte
+	copy(hash[:], digest.Sum(nil))
+
+	return hash, nil
+}
+
+// canonicalToken returns the Token with its literal normalized for CanonicalHash
+func (lexer *lexer) canonicalToken(token Token) Token {
+	switch {
+	case token.Kind == TokenBoolean, token.Kind == TokenHexNumber:
+		token.Literal = strings.ToLower(token.Literal)
+
+	case token.Kind != TokenIdent && token.Kind <= 0:
+		if keyword := lexer.config.normalizeKeyword(token.Literal); lexer.keywordKind(keyword) == token.Kind {
+			token.Literal = keyword
+		}
+	}
+
+	return token
+}
+
+// writeCanonicalToken writes the TokenKind and the length-prefixed literal of a Token into the digest
+func writeCanonicalToken(digest io.Writer, token Token) {
+	var buffer [4 + binary.MaxVarintLen64]byte
+
+	binary.BigEndian.PutUint32(buffer[:4], uint32(token.Kind))
+	length := binary.PutUvarint(buffer[4:], uint64(len(token.Literal)))
+
+	_, _ = digest.Write(buffer[:4+length])
+	_, _ = digest.Write([]byte(token.Literal))
+}
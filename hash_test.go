@@ -0,0 +1,46 @@
+package symbolizer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalHash(t *testing.T) {
+	tests := []struct {
+		first, second string
+		options       []ParserOption
+		equal         bool
+	}{
+		{"map[string]uint64", "map[string]uint64", nil, true},
+		{" f(a,  b)\n", "f(a, b)", nil, true},
+		{"f(a,\n\tb)", "f(a, b)", nil, true},
+		{"f(a,b)", "f(a, b)", nil, false},
+		{"f(a,b)", "f( a , b )", []ParserOption{IgnoreWhitespaces()}, true},
+		{"f(0xABCD, true)", "f(0xabcd, true)", nil, true},
+		{"f(a)", "f(b)", nil, false},
+		{`f("a")`, "f(a)", nil, false},
+		{"SELECT a", "select a", []ParserOption{Keywords(map[string]TokenKind{"select": -10}), CaseInsensitiveKeywords()}, true},
+		{"SELECT a", "select a", []ParserOption{Keywords(map[string]TokenKind{"select": -10})}, false},
+		{"order   BY a", "ORDER by a", []ParserOption{Keywords(map[string]TokenKind{"order by": -10}), CaseInsensitiveKeywords()}, true},
+		{"Abc", "abc", []ParserOption{CaseInsensitiveKeywords()}, false},
+		{"a # one", "a # two", []ParserOption{TokenFilter(func(token Token) (Token, bool) { return token, token.Kind != TokenIdent || token.Literal == "a" })}, true},
+	}
+
+	for _, test := range tests {
+		first, err := CanonicalHash(test.first, test.options...)
+		assert.NoError(t, err, test.first)
+
+		second, err := CanonicalHash(test.second, test.options...)
+		assert.NoError(t, err, test.second)
+
+		assert.Equal(t, test.equal, first == second, "%v == %v", test.first, test.second)
+	}
+
+	_, err := CanonicalHash(`f("a`)
+	assert.EqualError(t, err, "malformed token: '\"a'")
+
+	_, err = CanonicalHash("a b c", MaxTokens(2))
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+}
@@ -0,0 +1,59 @@
+package symbolizer
+
+// Style is the name of a style (such as a CSS class) applied to the Tokens of a TokenKind when highlighting
+type Style string
+
+// Span is a styled range of an input, between the Start and End byte offsets
+type Span struct {
+	Start, End int
+	Style      Style
+}
+
+// DefaultTheme returns a theme for Highlight that styles the standard Tokens
+// classes with the names of their classes such as 'string' and 'number'.
+func DefaultTheme() map[TokenKind]Style {
+	return map[TokenKind]Style{
+		TokenMalformed:   "malformed",
+		TokenIdent:       "ident",
+		TokenNumber:      "number",
+		TokenString:      "string",
+		TokenBoolean:     "boolean",
+		TokenHexNumber:   "number",
+		TokenDuration:    "duration",
+		TokenTimestamp:   "timestamp",
+		TokenBase64:      "string",
+		TokenFloat:       "number",
+		TokenSuffixed:    "number",
+		TokenSemver:      "version",
+		TokenAmount:      "number",
+		TokenPlaceholder: "placeholder",
+		TokenWildcard:    "wildcard",
+		TokenRegex:       "regex",
+		TokenNull:        "null",
+	}
+}
+
+// Highlight tokenizes the input with the given options and returns a styled Span for each Token whose
+// TokenKind has a Style in the theme. Tokens without a Style are left unstyled and have no Span, so that
+// renderers can output the input between Spans as is. Adjacent Tokens with the same Style share a Span.
+func Highlight(input string, theme map[TokenKind]Style, opts ...ParserOption) []Span {
+	var spans []Span
+
+	scanner := newLexer([]byte(input), newParseConfig(opts...))
+	for token := scanner.nextToken(); token.Kind != TokenEoF; token = scanner.nextToken() {
+		style, ok := theme[token.Kind]
+		if !ok {
+			continue
+		}
+
+		// Extend the previous span if it is adjacent and has the same style
+		if last := len(spans) - 1; last >= 0 && spans[last].End == token.Position && spans[last].Style == style {
+			spans[last].End = token.End
+			continue
+		}
+
+		spans = append(spans, Span{token.Position, token.End, style})
+	}
+
+	return spans
+}
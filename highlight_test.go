@@ -0,0 +1,40 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+	theme := DefaultTheme()
+	theme[-10] = "keyword"
+	theme['('], theme[')'] = "paren", "paren"
+
+	tests := []struct {
+		input   string
+		options []ParserOption
+		spans   []Span
+	}{
+		{
+			"map(\"a\", 10, true)", []ParserOption{Keywords(map[string]TokenKind{"map": -10})},
+			[]Span{{0, 3, "keyword"}, {3, 4, "paren"}, {4, 7, "string"}, {9, 11, "number"}, {13, 17, "boolean"}, {17, 18, "paren"}},
+		},
+		{
+			"f(()) x", nil,
+			[]Span{{0, 1, "ident"}, {1, 5, "paren"}, {6, 7, "ident"}},
+		},
+		{
+			"\"open", nil,
+			[]Span{{0, 5, "malformed"}},
+		},
+		{
+			"", nil,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.spans, Highlight(test.input, theme, test.options...), test.input)
+	}
+}
@@ -0,0 +1,113 @@
+package symbolizer
+
+import "sort"
+
+// TokenIndex is an index over the Tokens of a fully tokenized input that supports random access by byte offset
+// in O(log n), for tools (such as editors and linters) that query positions within large tokenized documents.
+// The Tokens are indexed by their span and by their TokenKind. A TokenIndex is immutable once built.
+type TokenIndex struct {
+	// tokens are the indexed Tokens, ordered by their position
+	tokens []Token
+	// kinds are the indexed Tokens grouped by their TokenKind, ordered by their position
+	kinds map[TokenKind][]Token
+}
+
+// NewTokenIndex tokenizes the input with the given options and returns a TokenIndex of its Tokens
+func NewTokenIndex(input string, opts ...ParserOption) *TokenIndex {
+	return IndexTokens(Tokenize(input, opts...))
+}
+
+// IndexTokens returns a TokenIndex of the given Tokens, which must be ordered by their position without
+// overlapping (such as the Tokens returned by Tokenize). The EoF Token is not indexed.
+func IndexTokens(tokens []Token) *TokenIndex {
+	index := &TokenIndex{tokens: make([]Token, 0, len(tokens)), kinds: make(map[TokenKind][]Token)}
+
+	for _, token := range tokens {
+		if token.Kind == TokenEoF {
+			continue
+		}
+
+		index.tokens = append(index.tokens, token)
+		index.kinds[token.Kind] = append(index.kinds[token.Kind], token)
+	}
+
+	return index
+}
+
+// Len returns the number of Tokens in the TokenIndex
+func (index *TokenIndex) Len() int {
+	return len(index.tokens)
+}
+
+// Tokens returns all the Tokens in the TokenIndex, ordered by their position.
+// The returned slice must not be modified.
+func (index *TokenIndex) Tokens() []Token {
+	return index.tokens
+}
+
+// AtOffset returns the Token whose span contains the given byte offset.
+// Returns false if the offset is not within any Token (such as within
+// a whitespace that was ignored or past the end of the input).
+func (index *TokenIndex) AtOffset(pos int) (Token, bool) {
+	found := sort.Search(len(index.tokens), func(idx int) bool { return index.tokens[idx].End > pos })
+	if found == len(index.tokens) || index.tokens[found].Position > pos {
+		return Token{}, false
+	}
+
+	return index.tokens[found], true
+}
+
+// Between returns the Tokens that overlap the byte range [start, end), ordered by their position.
+// The returned slice is a view into the TokenIndex and must not be modified.
+func (index *TokenIndex) Between(start, end int) []Token {
+	return between(index.tokens, start, end)
+}
+
+// KindBetween returns the Tokens of the given TokenKind that overlap the byte range [start, end),
+// ordered by their position. The returned slice is a view into the TokenIndex and must not be modified.
+func (index *TokenIndex) KindBetween(kind TokenKind, start, end int) []Token {
+	return between(index.kinds[kind], start, end)
+}
+
+// Count returns the number of Tokens of the given TokenKind in the TokenIndex
+func (index *TokenIndex) Count(kind TokenKind) int {
+	return len(index.kinds[kind])
+}
+
+// NextOfKind returns the first Token of the given TokenKind that starts at or after the given byte offset.
+// Returns false if there is no such Token.
+func (index *TokenIndex) NextOfKind(kind TokenKind, pos int) (Token, bool) {
+	tokens := index.kinds[kind]
+
+	found := sort.Search(len(tokens), func(idx int) bool { return tokens[idx].Position >= pos })
+	if found == len(tokens) {
+		return Token{}, false
+	}
+
+	return tokens[found], true
+}
+
+// PrevOfKind returns the last Token of the given TokenKind that ends at or before the given byte offset.
+// Returns false if there is no such Token.
+func (index *TokenIndex) PrevOfKind(kind TokenKind, pos int) (Token, bool) {
+	tokens := index.kinds[kind]
+
+	found := sort.Search(len(tokens), func(idx int) bool { return tokens[idx].End > pos })
+	if found == 0 {
+		return Token{}, false
+	}
+
+	return tokens[found-1], true
+}
+
+// between returns the sub-slice of the ordered Tokens that overlap the byte range [start, end)
+func between(tokens []Token, start, end int) []Token {
+	first := sort.Search(len(tokens), func(idx int) bool { return tokens[idx].End > start })
+	last := sort.Search(len(tokens), func(idx int) bool { return tokens[idx].Position >= end })
+
+	if first >= last {
+		return nil
+	}
+
+	return tokens[first:last:last]
+}
@@ -0,0 +1,66 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenIndex(t *testing.T) {
+	// 0         1         2
+	// 0123456789012345678901234
+	// f(a, 0x01)  g(b, "c")
+	index := NewTokenIndex(`f(a, 0x01)  g(b, "c")`, IgnoreWhitespaces())
+
+	assert.Equal(t, 12, index.Len())
+	assert.Equal(t, 2, index.Count(TokenKind('(')))
+	assert.Equal(t, 0, index.Count(TokenNumber))
+
+	token, ok := index.AtOffset(6)
+	assert.True(t, ok)
+	assert.Equal(t, Token{TokenHexNumber, "0x01", 5, 9, nil}, token)
+
+	token, ok = index.AtOffset(0)
+	assert.True(t, ok)
+	assert.Equal(t, Token{TokenIdent, "f", 0, 1, nil}, token)
+
+	_, ok = index.AtOffset(11)
+	assert.False(t, ok)
+
+	_, ok = index.AtOffset(21)
+	assert.False(t, ok)
+
+	assert.Equal(t, []Token{
+		{TokenHexNumber, "0x01", 5, 9, nil},
+		{TokenKind(')'), ")", 9, 10, nil},
+		{TokenIdent, "g", 12, 13, nil},
+	}, index.Between(8, 13))
+
+	assert.Nil(t, index.Between(10, 12))
+	assert.Nil(t, index.Between(5, 5))
+	assert.Len(t, index.Between(0, 100), 12)
+
+	assert.Equal(t, []Token{
+		{TokenIdent, "a", 2, 3, nil},
+		{TokenIdent, "g", 12, 13, nil},
+		{TokenIdent, "b", 14, 15, nil},
+	}, index.KindBetween(TokenIdent, 2, 21))
+
+	token, ok = index.NextOfKind(TokenKind('('), 2)
+	assert.True(t, ok)
+	assert.Equal(t, 13, token.Position)
+
+	_, ok = index.NextOfKind(TokenKind('('), 14)
+	assert.False(t, ok)
+
+	token, ok = index.PrevOfKind(TokenIdent, 14)
+	assert.True(t, ok)
+	assert.Equal(t, "g", token.Literal)
+
+	_, ok = index.PrevOfKind(TokenIdent, 0)
+	assert.False(t, ok)
+
+	// The EoF Token is not indexed
+	assert.Equal(t, 0, index.Count(TokenEoF))
+	assert.Equal(t, index.Tokens(), IndexTokens(Tokenize(`f(a, 0x01)  g(b, "c")`, IgnoreWhitespaces())).Tokens())
+}
@@ -0,0 +1,311 @@
+package symbolizer
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// keywordTrie is a trie of keywords in which each edge is a word of a keyword.
+// It is used instead of the keyword map when keywords span multiple words
+// (such as 'ORDER BY') or when keywords are matched case-insensitively.
+type keywordTrie struct {
+	// kind is the TokenKind of the keyword that ends at the node, if terminal
+	kind     TokenKind
+	terminal bool
+	// children are the nodes for the next words of the keywords
+	children map[string]*keywordTrie
+}
+
+// newKeywordTrie generates a keywordTrie for the given keywords. If fold is
+// true, the words of the keywords are inserted with their case folded.
+func newKeywordTrie(keywords map[string]TokenKind, fold bool) *keywordTrie {
+	root := new(keywordTrie)
+
+	for keyword, kind := range keywords {
+		node := root
+		for _, word := range strings.FieldsFunc(keyword, unicode.IsSpace) {
+			if fold {
+				word = strings.ToLower(word)
+			}
+
+			if node.children == nil {
+				node.children = make(map[string]*keywordTrie)
+			}
+
+			child, ok := node.children[word]
+			if !ok {
+				child = new(keywordTrie)
+				node.children[word] = child
+			}
+
+			node = child
+		}
+
+		node.kind, node.terminal = kind, true
+	}
+
+	return root
+}
+
+// child returns the child node for the given word, if it exists
+func (trie *keywordTrie) child(word []byte, fold bool) *keywordTrie {
+	if fold {
+		return trie.children[strings.ToLower(string(word))]
+	}
+
+	// The string conversion within the map index expression does not allocate.
+	return trie.children[string(word)]
+}
+
+// matchKeyword matches the longest keyword in the trie that begins with the identifier between start and the
+// cursor. The words of a multi-word keyword may be separated by any whitespace in the input. If a keyword is
+// matched, the cursor is moved to its end and its TokenKind is returned, otherwise TokenIdent is returned.
+func (lexer *lexer) matchKeyword(start int) TokenKind {
+	fold := lexer.config.foldKeywords
+
+	node := lexer.config.trie.child(lexer.input[start:lexer.cursor], fold)
+	if node == nil {
+		return TokenIdent
+	}
+
+	// Track the end of the longest matched keyword
+	kind, end := TokenIdent, lexer.cursor
+	if node.terminal {
+		kind = node.kind
+	}
+
+	for len(node.children) != 0 {
+		// Words must be separated by whitespace
+		last := lexer.cursor
+		if lexer.consumeSpaces(); lexer.cursor == last {
+			break
+		}
+
+		// Collect the next word and descend into its node
+		word := lexer.cursor
+		for lexer.identChar(lexer.char()) {
+			lexer.advanceCursor()
+		}
+
+		if node = node.child(lexer.input[word:lexer.cursor], fold); node == nil {
+			break
+		}
+
+		if node.terminal {
+			kind, end = node.kind, lexer.cursor
+		}
+	}
+
+	// Rewind the cursor to the end of the longest keyword
+	lexer.cursor = end
+	return kind
+}
+
+// ExpectKeyword returns the Token at the cursor and advances the parser if it is a keyword of the given
+// TokenKind. Otherwise, the parser does not advance and an error describing the expected keyword is returned.
+func (parser *Parser) ExpectKeyword(kind TokenKind) (Token, error) {
+	parser.trace("ExpectKeyword", kind)
+
+	if !parser.IsCursor(kind) {
+		expected := strings.Join(parser.scanner.config.keywordsOf(kind), "' or '")
+		return Token{}, parser.errorf(parser.curr.Position, "expected keyword '%v', found '%v'", expected, parser.curr.Literal)
+	}
+
+	token := parser.curr
+	parser.Advance()
+
+	return token, nil
+}
+
+// ExpectIdent returns the Token at the cursor and advances the parser if it can be used as an identifier.
+// Identifiers are either TokenIdent Tokens or keywords of a custom TokenKind that has not been reserved with
+// the ReservedKeywords option. Otherwise, the parser does not advance and an error is returned.
+func (parser *Parser) ExpectIdent() (Token, error) {
+	parser.trace("ExpectIdent")
+
+	if err := parser.checkIdent("expected identifier"); err != nil {
+		return Token{}, err
+	}
+
+	token := parser.curr
+	parser.Advance()
+
+	return token, nil
+}
+
+// checkIdent returns an error if the Token at the cursor cannot be used as an identifier.
+// Reserved keywords are reported as such, while other Tokens are reported with the given description.
+func (parser *Parser) checkIdent(description string) error {
+	kind := parser.curr.Kind
+	config := parser.scanner.config
+
+	switch {
+	case kind == TokenIdent:
+		return nil
+	case config.reserved[kind]:
+		return parser.errorf(parser.curr.Position, "reserved keyword cannot be used as an identifier: '%v'", parser.curr.Literal)
+	case kind.custom() && len(config.keywordsOf(kind)) != 0:
+		return nil
+	default:
+		return parser.errorf(parser.curr.Position, "%v, found '%v'", description, parser.curr.Literal)
+	}
+}
+
+// keywordsOf returns the sorted keywords that generate Tokens of the given TokenKind
+func (config *parseConfig) keywordsOf(kind TokenKind) []string {
+	var keywords []string
+	for keyword, keywordKind := range config.keywords {
+		if keywordKind == kind {
+			keywords = append(keywords, keyword)
+		}
+	}
+
+	sort.Strings(keywords)
+	return keywords
+}
+
+// PushKeywords adds the given keywords to the keywords of the parser until they are removed with PopKeywords, for
+// context-sensitive grammars where a word is only a keyword within certain clauses. The pushed keywords shadow any
+// existing keywords with the same literal (and their keyword data). Pushes can be nested, and each is scoped to
+// the Parser (and any sub-parsers and clones created while it is in effect). The Token at the cursor is not
+// affected, since it has already been consumed, but the Token after the cursor is rescanned with the keywords.
+func (parser *Parser) PushKeywords(keywords map[string]TokenKind) {
+	parser.scopes = append(parser.scopes, parser.scanner.config)
+	parser.scanner.config = parser.scanner.config.withKeywords(keywords)
+
+	parser.rescanPeek()
+}
+
+// PopKeywords removes the keywords that were most recently added with PushKeywords,
+// and rescans the Token after the cursor without them. It does nothing if no keywords were pushed.
+func (parser *Parser) PopKeywords() {
+	if len(parser.scopes) == 0 {
+		return
+	}
+
+	last := len(parser.scopes) - 1
+	parser.scanner.config, parser.scopes = parser.scopes[last], parser.scopes[:last]
+
+	parser.rescanPeek()
+}
+
+// withKeywords returns a copy of the parseConfig with the given keywords added to its keywords
+func (config *parseConfig) withKeywords(keywords map[string]TokenKind) *parseConfig {
+	derived := *config
+	derived.keywords = make(map[string]TokenKind, len(config.keywords)+len(keywords))
+
+	for keyword, kind := range config.keywords {
+		derived.keywords[keyword] = kind
+	}
+
+	pushed := make([]string, 0, len(keywords))
+	for keyword, kind := range keywords {
+		if config.nfc {
+			keyword = norm.NFC.String(keyword)
+		}
+
+		derived.keywords[keyword] = kind
+		pushed = append(pushed, keyword)
+	}
+
+	derived.trie = nil
+	derived.compileKeywords()
+
+	// Remove the keyword data of the shadowed keywords
+	if config.keywordData != nil {
+		derived.keywordData = make(map[string]*KeywordData, len(config.keywordData))
+		for keyword, data := range config.keywordData {
+			derived.keywordData[keyword] = data
+		}
+
+		derived.compileKeywordData()
+
+		for _, keyword := range pushed {
+			delete(derived.keywordData, derived.normalizeKeyword(keyword))
+		}
+	}
+
+	return &derived
+}
+
+// Enum is the constraint for integer enums (such as iota enums with a generated Stringer) whose
+// String values are used as keywords with KeywordsFromEnum and recovered with EnumOf
+type Enum interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+	String() string
+}
+
+// KeywordsFromStrings returns a map of keywords for Keywords, in which each keyword is assigned a custom TokenKind
+// descending from base in the order of the keywords (base, base-1, base-2 ...), such that KeywordsFromStrings(-10,
+// "select", "from") returns {"select": -10, "from": -11}. Repeated keywords retain the TokenKind of their first use.
+// The base must be -10 or below, like every custom TokenKind.
+func KeywordsFromStrings(base TokenKind, keywords ...string) map[string]TokenKind {
+	mapping := make(map[string]TokenKind, len(keywords))
+	for idx, keyword := range keywords {
+		if _, ok := mapping[keyword]; !ok {
+			mapping[keyword] = base - TokenKind(idx)
+		}
+	}
+
+	return mapping
+}
+
+// KeywordsFromEnum returns a map of keywords for Keywords from the String values of the given enum values, in which
+// each keyword is assigned the TokenKind base - TokenKind(value). This keeps the keywords of a grammar in sync with
+// an enum of its keywords, without a parallel keyword map. The enum value of a keyword Token can be recovered with
+// EnumOf using the same base. The enum values must not be negative, such that their TokenKinds descend from base.
+func KeywordsFromEnum[E Enum](base TokenKind, values ...E) map[string]TokenKind {
+	mapping := make(map[string]TokenKind, len(values))
+	for _, value := range values {
+		mapping[value.String()] = base - TokenKind(value)
+	}
+
+	return mapping
+}
+
+// EnumOf returns the enum value for a keyword TokenKind generated by KeywordsFromEnum with the same base
+func EnumOf[E Enum](base, kind TokenKind) E {
+	return E(base - kind)
+}
+
+// KeywordsFromStruct returns a map of keywords for Keywords from the exported fields of a struct (or a pointer to
+// one), in which the keyword of each field is assigned the TokenKind base - TokenKind(index) for the index of the
+// field in the struct. The keyword of a field is its name, unless it has a `symbolizer` tag that specifies another
+// keyword (such as `symbolizer:"order by"`). Fields with the `symbolizer:"-"` tag are skipped.
+// Returns an error if v is not a struct.
+func KeywordsFromStruct(base TokenKind, v any) (map[string]TokenKind, error) {
+	structure := reflect.TypeOf(v)
+	if structure != nil && structure.Kind() == reflect.Pointer {
+		structure = structure.Elem()
+	}
+
+	if structure == nil || structure.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot extract keywords from non-struct type: %v", structure)
+	}
+
+	mapping := make(map[string]TokenKind, structure.NumField())
+	for idx := 0; idx < structure.NumField(); idx++ {
+		field := structure.Field(idx)
+		if !field.IsExported() {
+			continue
+		}
+
+		keyword := field.Name
+		if tag, ok := field.Tag.Lookup("symbolizer"); ok {
+			if tag == "-" {
+				continue
+			}
+
+			keyword = tag
+		}
+
+		mapping[keyword] = base - TokenKind(idx)
+	}
+
+	return mapping, nil
+}
@@ -0,0 +1,364 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexer_KeywordPhrases(t *testing.T) {
+	keywords := map[string]TokenKind{
+		"ORDER BY":         -10,
+		"GROUP BY":         -11,
+		"GROUP":            -12,
+		"NOT NULL":         -13,
+		"IS NOT NULL":      -14,
+		"LEFT OUTER  JOIN": -15,
+	}
+
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			"ORDER BY id", nil,
+			[]Token{{-10, "ORDER BY", 0, 8, nil}, UnicodeToken(' ', 8), {TokenIdent, "id", 9, 11, nil}, EOFToken(11)},
+		},
+		{
+			"ORDER\t\n BY", nil,
+			[]Token{{-10, "ORDER\t\n BY", 0, 10, nil}, EOFToken(10)},
+		},
+		{
+			"GROUP BY x", []ParserOption{IgnoreWhitespaces()},
+			[]Token{{-11, "GROUP BY", 0, 8, nil}, {TokenIdent, "x", 9, 10, nil}, EOFToken(10)},
+		},
+		{
+			"GROUP x", nil,
+			[]Token{{-12, "GROUP", 0, 5, nil}, UnicodeToken(' ', 5), {TokenIdent, "x", 6, 7, nil}, EOFToken(7)},
+		},
+		{
+			"ORDER x", nil,
+			[]Token{{TokenIdent, "ORDER", 0, 5, nil}, UnicodeToken(' ', 5), {TokenIdent, "x", 6, 7, nil}, EOFToken(7)},
+		},
+		{
+			"ORDER(", nil,
+			[]Token{{TokenIdent, "ORDER", 0, 5, nil}, UnicodeToken('(', 5), EOFToken(6)},
+		},
+		{
+			"IS NOT x", nil,
+			[]Token{{TokenIdent, "IS", 0, 2, nil}, UnicodeToken(' ', 2), {TokenIdent, "NOT", 3, 6, nil}, UnicodeToken(' ', 6), {TokenIdent, "x", 7, 8, nil}, EOFToken(8)},
+		},
+		{
+			"x IS NOT NULL", nil,
+			[]Token{{TokenIdent, "x", 0, 1, nil}, UnicodeToken(' ', 1), {-14, "IS NOT NULL", 2, 13, nil}, EOFToken(13)},
+		},
+		{
+			"LEFT OUTER JOIN", nil,
+			[]Token{{-15, "LEFT OUTER JOIN", 0, 15, nil}, EOFToken(15)},
+		},
+		{
+			"order by true", nil,
+			[]Token{{TokenIdent, "order", 0, 5, nil}, UnicodeToken(' ', 5), {TokenIdent, "by", 6, 8, nil}, UnicodeToken(' ', 8), {TokenBoolean, "true", 9, 13, nil}, EOFToken(13)},
+		},
+		{
+			"order By TRUE", []ParserOption{CaseInsensitiveKeywords()},
+			[]Token{{-10, "order By", 0, 8, nil}, UnicodeToken(' ', 8), {TokenBoolean, "TRUE", 9, 13, nil}, EOFToken(13)},
+		},
+	}
+
+	for _, test := range tests {
+		options := append([]ParserOption{Keywords(keywords)}, test.options...)
+		assert.Equal(t, test.output, Tokenize(test.input, options...), test.input)
+	}
+}
+
+func TestLexer_CaseInsensitiveKeywords(t *testing.T) {
+	options := []ParserOption{CaseInsensitiveKeywords(), Keywords(map[string]TokenKind{"Select": -10})}
+
+	assert.Equal(t,
+		[]Token{{-10, "SELECT", 0, 6, nil}, UnicodeToken(' ', 6), {-10, "select", 7, 13, nil}, UnicodeToken(' ', 13), {TokenIdent, "selected", 14, 22, nil}, EOFToken(22)},
+		Tokenize("SELECT select selected", options...),
+	)
+}
+
+func TestParser_ExpectKeyword(t *testing.T) {
+	options := []ParserOption{IgnoreWhitespaces(), Keywords(map[string]TokenKind{"select": -10, "SELECT": -10, "from": -11})}
+
+	parser := NewParser("select x from", options...)
+
+	token, err := parser.ExpectKeyword(-10)
+	assert.NoError(t, err)
+	assert.Equal(t, Token{-10, "select", 0, 6, nil}, token)
+
+	_, err = parser.ExpectKeyword(-11)
+	assert.EqualError(t, err, "expected keyword 'from', found 'x'")
+	assert.Equal(t, Token{TokenIdent, "x", 7, 8, nil}, parser.Cursor())
+
+	_, err = parser.ExpectKeyword(-10)
+	assert.EqualError(t, err, "expected keyword 'SELECT' or 'select', found 'x'")
+	assert.Equal(t, 2, parser.Errors().Len())
+}
+
+func TestParser_ExpectIdent(t *testing.T) {
+	options := []ParserOption{
+		IgnoreWhitespaces(),
+		Keywords(map[string]TokenKind{"select": -10, "count": -11}),
+		ReservedKeywords(-10),
+	}
+
+	tests := []struct {
+		input string
+		token Token
+		err   string
+	}{
+		{"name", Token{TokenIdent, "name", 0, 4, nil}, ""},
+		{"count", Token{-11, "count", 0, 5, nil}, ""},
+		{"select", Token{}, "reserved keyword cannot be used as an identifier: 'select'"},
+		{"true", Token{}, "expected identifier, found 'true'"},
+		{"(", Token{}, "expected identifier, found '('"},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, options...)
+		token, err := parser.ExpectIdent()
+
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.input)
+			assert.Equal(t, test.input, parser.Unparsed())
+			continue
+		}
+
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.token, token)
+		assert.True(t, parser.Exhausted())
+	}
+
+	// Reserved keywords cannot be the callee of a call
+	_, err := NewParser("select(x)", options...).ParseCall()
+	assert.EqualError(t, err, "reserved keyword cannot be used as an identifier: 'select'")
+
+	call, err := NewParser("count(x)", options...).ParseCall()
+	assert.NoError(t, err)
+	assert.Equal(t, Token{-11, "count", 0, 5, nil}, call.Callee)
+}
+
+func TestLexer_KeywordsWithData(t *testing.T) {
+	type op int
+
+	keywords := map[string]KeywordSpec{
+		"add":      {-10, op(1)},
+		"sub":      {-10, op(2)},
+		"ORDER BY": {-11, "order"},
+	}
+
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+		data    []any
+	}{
+		{
+			"add x sub", []ParserOption{KeywordsWithData(keywords), IgnoreWhitespaces()},
+			[]Token{{-10, "add", 0, 3, nil}, {TokenIdent, "x", 4, 5, nil}, {-10, "sub", 6, 9, nil}, EOFToken(9)},
+			[]any{op(1), nil, op(2), nil},
+		},
+		{
+			"ADD order  by true", []ParserOption{KeywordsWithData(keywords), CaseInsensitiveKeywords(), IgnoreWhitespaces()},
+			[]Token{{-10, "ADD", 0, 3, nil}, {-11, "order  by", 4, 13, nil}, {TokenBoolean, "true", 14, 18, nil}, EOFToken(18)},
+			[]any{op(1), "order", nil, nil},
+		},
+		{
+			"add sub", []ParserOption{KeywordsWithData(keywords), Keywords(map[string]TokenKind{"sub": -12}), IgnoreWhitespaces()},
+			[]Token{{-10, "add", 0, 3, nil}, {-12, "sub", 4, 7, nil}, EOFToken(7)},
+			[]any{op(1), nil, nil},
+		},
+	}
+
+	for _, test := range tests {
+		lexer := NewLexer(test.input, test.options...)
+
+		for idx, expected := range test.output {
+			token := lexer.Next()
+			assert.Equal(t, test.data[idx], token.Data.Value(), test.input)
+
+			token.Data = nil
+			assert.Equal(t, expected, token, test.input)
+		}
+	}
+
+	// The Tokens of a keyword share the handle of its data, such that they remain comparable with a func payload
+	tokens := Tokenize("run run", KeywordsWithData(map[string]KeywordSpec{"run": {-10, func() {}}}), IgnoreWhitespaces())
+	assert.Same(t, tokens[0].Data, tokens[1].Data)
+	assert.NotPanics(t, func() { _ = tokens[0] == tokens[1] })
+}
+
+func TestLexer_BooleanKeywords(t *testing.T) {
+	spellings := map[string]bool{"yes": true, "no": false, "on": true, "off": false, "1": true, "0": false}
+
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+		values  []any
+	}{
+		{
+			"yes off true", []ParserOption{BooleanKeywords(spellings), IgnoreWhitespaces()},
+			[]Token{{TokenBoolean, "yes", 0, 3, nil}, {TokenBoolean, "off", 4, 7, nil}, {TokenBoolean, "true", 8, 12, nil}, EOFToken(12)},
+			[]any{true, false, true},
+		},
+		{
+			"1 0 10", []ParserOption{BooleanKeywords(spellings), IgnoreWhitespaces()},
+			[]Token{{TokenBoolean, "1", 0, 1, nil}, {TokenBoolean, "0", 2, 3, nil}, {TokenNumber, "10", 4, 6, nil}, EOFToken(6)},
+			[]any{true, false, uint64(10)},
+		},
+		{
+			"YES true", []ParserOption{BooleanKeywords(spellings), CaseInsensitiveKeywords(), DisableDefaultBooleans(), IgnoreWhitespaces()},
+			[]Token{{TokenBoolean, "YES", 0, 3, nil}, {TokenIdent, "true", 4, 8, nil}, EOFToken(8)},
+			[]any{true},
+		},
+		{
+			"yes 1", []ParserOption{IgnoreWhitespaces()},
+			[]Token{{TokenIdent, "yes", 0, 3, nil}, {TokenNumber, "1", 4, 5, nil}, EOFToken(5)},
+			[]any{uint64(1)},
+		},
+	}
+
+	for _, test := range tests {
+		tokens := Tokenize(test.input, test.options...)
+
+		// Boolean values are derived from the mapping by Token.Value
+		var values []any
+		for idx, token := range tokens {
+			if token.Kind.CanValue() {
+				value, err := token.Value()
+				assert.NoError(t, err, test.input)
+				values = append(values, value)
+			}
+
+			tokens[idx].Data = nil
+		}
+
+		assert.Equal(t, test.output, tokens, test.input)
+		assert.Equal(t, test.values, values, test.input)
+	}
+
+	value, err := NewParser("no", BooleanKeywords(spellings)).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, false, value)
+}
+
+func TestParser_PushKeywords(t *testing.T) {
+	const (
+		kindSelect TokenKind = -10 - iota
+		kindFrom
+		kindLimit
+		kindOrderBy
+	)
+
+	parser := NewParser(
+		"select limit from t limit 5 order by x", IgnoreWhitespaces(),
+		KeywordsWithData(map[string]KeywordSpec{"select": {kindSelect, "select"}, "from": {kindFrom, "from"}}),
+	)
+
+	// 'limit' is an identifier within the select clause
+	token, err := parser.ExpectKeyword(kindSelect)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "select", token.Data.Value())
+	assert.Equal(t, Token{TokenIdent, "limit", 7, 12, nil}, parser.Cursor())
+
+	parser.Advance()
+	assert.Equal(t, Token{TokenIdent, "t", 18, 19, nil}, parser.Peek())
+
+	// 'limit' and 'order by' are keywords after the from clause, which shadows the 'from' keyword.
+	// The 'from' keyword at the cursor was scanned before the push and keeps the data of its keyword.
+	parser.PushKeywords(map[string]TokenKind{"limit": kindLimit, "order by": kindOrderBy, "from": kindFrom})
+	from := parser.Cursor()
+	assert.Equal(t, "from", from.Data.Value())
+
+	clone := parser.Clone()
+	assert.Equal(t, []Token{
+		{kindFrom, "from", 13, 17, from.Data},
+		{TokenIdent, "t", 18, 19, nil},
+		{kindLimit, "limit", 20, 25, nil},
+		{TokenNumber, "5", 26, 27, nil},
+		{kindOrderBy, "order by", 28, 36, nil},
+		{TokenIdent, "x", 37, 38, nil},
+	}, parser.RemainingTokens())
+
+	// The keywords are removed once popped, in the parser but not its clone
+	parser.Advance()
+	parser.PopKeywords()
+	assert.Equal(t, Token{TokenIdent, "limit", 20, 25, nil}, parser.Peek())
+	assert.True(t, clone.SeekTo(kindLimit))
+	assert.Equal(t, Token{kindLimit, "limit", 20, 25, nil}, clone.Cursor())
+
+	parser.PopKeywords()
+	assert.Equal(t, Token{TokenIdent, "limit", 20, 25, nil}, parser.Peek())
+
+	// The pushed keywords are removed when the parser is reset
+	parser.PushKeywords(map[string]TokenKind{"limit": kindLimit})
+	parser.ResetInput("limit")
+	assert.Equal(t, Token{TokenIdent, "limit", 0, 5, nil}, parser.Cursor())
+}
+
+func TestLexer_NFCNormalize(t *testing.T) {
+	// 'café' with a precomposed 'é' and with an 'e' followed by a combining acute accent
+	composed, decomposed := "caf\u00e9", "cafe\u0301"
+	keywords := Keywords(map[string]TokenKind{decomposed: -10})
+
+	tokens := Tokenize(composed+" "+decomposed, NFCNormalize(), keywords, IgnoreWhitespaces())
+	assert.Equal(t, []Token{{-10, composed, 0, 5, nil}, {-10, composed, 6, 11, nil}, EOFToken(11)}, tokens)
+
+	// Without normalization, the combining accent is not part of the identifier
+	tokens = Tokenize(composed+" "+decomposed, keywords, IgnoreWhitespaces())
+	assert.Equal(t, []Token{{TokenIdent, composed, 0, 5, nil}, {TokenIdent, "cafe", 6, 10, nil}, UnicodeToken('\u0301', 10), EOFToken(12)}, tokens)
+}
+
+// testOp is an iota enum of keywords for TestKeywordsFromEnum
+type testOp int
+
+const (
+	testSelect testOp = iota
+	testFrom
+	testWhere
+)
+
+func (op testOp) String() string {
+	return [...]string{"select", "from", "where"}[op]
+}
+
+func TestKeywordsFromStrings(t *testing.T) {
+	assert.Equal(t, map[string]TokenKind{"select": -10, "from": -11, "where": -13}, KeywordsFromStrings(-10, "select", "from", "select", "where"))
+	assert.Equal(t, map[string]TokenKind{}, KeywordsFromStrings(-10))
+}
+
+func TestKeywordsFromEnum(t *testing.T) {
+	keywords := KeywordsFromEnum(-20, testSelect, testFrom, testWhere)
+	assert.Equal(t, map[string]TokenKind{"select": -20, "from": -21, "where": -22}, keywords)
+
+	tokens := Tokenize("select a from b", Keywords(keywords), IgnoreWhitespaces())
+	assert.Equal(t, testSelect, EnumOf[testOp](-20, tokens[0].Kind))
+	assert.Equal(t, testFrom, EnumOf[testOp](-20, tokens[2].Kind))
+}
+
+func TestKeywordsFromStruct(t *testing.T) {
+	type grammar struct {
+		Select  bool
+		OrderBy bool `symbolizer:"order by"`
+		Skipped bool `symbolizer:"-"`
+		hidden  bool
+		Limit   bool
+	}
+
+	keywords, err := KeywordsFromStruct(-10, &grammar{})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]TokenKind{"Select": -10, "order by": -11, "Limit": -14}, keywords)
+
+	_, err = KeywordsFromStruct(-10, 42)
+	assert.EqualError(t, err, "cannot extract keywords from non-struct type: int")
+
+	_, err = KeywordsFromStruct(-10, nil)
+	assert.EqualError(t, err, "cannot extract keywords from non-struct type: <nil>")
+}
@@ -0,0 +1,137 @@
+package symbolizer
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// KindSet is an immutable set of TokenKinds for multi-kind checks, such as with Parser.ExpectPeekIn.
+// Membership of the ASCII unicode TokenKinds is checked with a bitset, which makes checks for delimiters
+// and brackets cheap, while other TokenKinds are checked with a map. The zero value is an empty set.
+type KindSet struct {
+	// ascii is the bitset of the ASCII unicode TokenKinds in the set
+	ascii [2]uint64
+	// other are the TokenKinds in the set that are not ASCII unicode TokenKinds
+	other map[TokenKind]struct{}
+}
+
+var (
+	// LiteralKinds is the KindSet of the TokenKinds whose Tokens can be converted into values (see TokenKind.CanValue)
+	LiteralKinds = NewKindSet(
+		TokenNumber, TokenString, TokenBoolean, TokenHexNumber, TokenDuration, TokenTimestamp,
+		TokenBase64, TokenFloat, TokenSuffixed, TokenSemver, TokenAmount, TokenRegex, TokenNull,
+	)
+
+	// UnicodeDelimiters is the KindSet of the unicode TokenKinds that commonly delimit values: ',', ';', ':' and '|'
+	UnicodeDelimiters = NewKindSet(',', ';', ':', '|')
+
+	// UnicodeBrackets is the KindSet of the unicode TokenKinds of the
+	// opening and closing characters of the predefined Enclosures
+	UnicodeBrackets = NewKindSet('(', ')', '[', ']', '{', '}', '<', '>')
+)
+
+// NewKindSet generates a new KindSet of the given TokenKinds
+func NewKindSet(kinds ...TokenKind) KindSet {
+	var set KindSet
+	for _, kind := range kinds {
+		set.add(kind)
+	}
+
+	return set
+}
+
+// add adds the TokenKind to the KindSet. It must only be called while building a new KindSet.
+func (set *KindSet) add(kind TokenKind) {
+	if kind >= 0 && kind < 128 {
+		set.ascii[kind/64] |= 1 << (kind % 64)
+		return
+	}
+
+	if set.other == nil {
+		set.other = make(map[TokenKind]struct{})
+	}
+
+	set.other[kind] = struct{}{}
+}
+
+// Contains returns whether the TokenKind is in the KindSet
+func (set KindSet) Contains(kind TokenKind) bool {
+	if kind >= 0 && kind < 128 {
+		return set.ascii[kind/64]&(1<<(kind%64)) != 0
+	}
+
+	_, ok := set.other[kind]
+	return ok
+}
+
+// Union returns a new KindSet of the TokenKinds that are in the KindSet or any of the other KindSets
+func (set KindSet) Union(others ...KindSet) KindSet {
+	union := KindSet{ascii: set.ascii}
+	for kind := range set.other {
+		union.add(kind)
+	}
+
+	for _, other := range others {
+		union.ascii[0] |= other.ascii[0]
+		union.ascii[1] |= other.ascii[1]
+
+		for kind := range other.other {
+			union.add(kind)
+		}
+	}
+
+	return union
+}
+
+// With returns a new KindSet of the TokenKinds in the KindSet and the given TokenKinds
+func (set KindSet) With(kinds ...TokenKind) KindSet {
+	return set.Union(NewKindSet(kinds...))
+}
+
+// Len returns the number of TokenKinds in the KindSet
+func (set KindSet) Len() int {
+	return bits.OnesCount64(set.ascii[0]) + bits.OnesCount64(set.ascii[1]) + len(set.other)
+}
+
+// Kinds returns the TokenKinds in the KindSet in ascending order
+func (set KindSet) Kinds() []TokenKind {
+	kinds := make([]TokenKind, 0, len(set.other))
+	for kind := range set.other {
+		kinds = append(kinds, kind)
+	}
+
+	for kind := TokenKind(0); kind < 128; kind++ {
+		if set.Contains(kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	return kinds
+}
+
+// IsPeekIn checks if the next token is of any of the TokenKinds in the KindSet.
+// This look ahead is performed without moving the parser's cursor
+func (parser *Parser) IsPeekIn(set KindSet) bool {
+	return set.Contains(parser.next.Kind)
+}
+
+// IsCursorIn checks if the current token is of any of the TokenKinds in the KindSet.
+func (parser *Parser) IsCursorIn(set KindSet) bool {
+	return set.Contains(parser.curr.Kind)
+}
+
+// ExpectPeekIn advances the cursor if the next token is of any of the TokenKinds in the KindSet, like
+// ExpectPeekAny. The returned boolean indicates if the parser was advanced and the returned Token is
+// the matched token (now under the cursor).
+func (parser *Parser) ExpectPeekIn(set KindSet) (Token, bool) {
+	parser.trace("ExpectPeekIn", set.Kinds())
+
+	if !parser.IsPeekIn(set) {
+		return Token{}, false
+	}
+
+	parser.Advance()
+
+	return parser.curr, true
+}
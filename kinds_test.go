@@ -0,0 +1,65 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindSet(t *testing.T) {
+	set := NewKindSet(',', TokenIdent, 'é', '\x7f', 0)
+
+	for _, kind := range []TokenKind{',', TokenIdent, 'é', '\x7f', 0} {
+		assert.True(t, set.Contains(kind), kind)
+	}
+
+	for _, kind := range []TokenKind{';', TokenNumber, 'e', '\x80', TokenEoF} {
+		assert.False(t, set.Contains(kind), kind)
+	}
+
+	assert.Equal(t, []TokenKind{TokenIdent, 0, ',', '\x7f', 'é'}, set.Kinds())
+	assert.Equal(t, 5, set.Len())
+
+	// Unions do not modify their operands
+	union := UnicodeDelimiters.Union(UnicodeBrackets, NewKindSet(TokenEoF))
+	assert.True(t, union.Contains(';'))
+	assert.True(t, union.Contains('>'))
+	assert.True(t, union.Contains(TokenEoF))
+	assert.Equal(t, 13, union.Len())
+	assert.False(t, UnicodeDelimiters.Contains('('))
+	assert.False(t, UnicodeDelimiters.Contains(TokenEoF))
+
+	with := set.With(TokenString)
+	assert.True(t, with.Contains(TokenString))
+	assert.False(t, set.Contains(TokenString))
+
+	assert.False(t, KindSet{}.Contains(0))
+	assert.Empty(t, KindSet{}.Kinds())
+
+	// The literal kinds are those that can be converted into values
+	for _, kind := range LiteralKinds.Kinds() {
+		assert.True(t, kind.CanValue(), kind)
+	}
+
+	assert.False(t, LiteralKinds.Contains(TokenIdent))
+}
+
+func TestParser_KindSets(t *testing.T) {
+	parser := NewParser("a, 0x1f; true", IgnoreWhitespaces())
+
+	assert.True(t, parser.IsCursorIn(NewKindSet(TokenIdent)))
+	assert.True(t, parser.IsPeekIn(UnicodeDelimiters))
+
+	_, ok := parser.ExpectPeekIn(LiteralKinds)
+	assert.False(t, ok)
+	assert.Equal(t, "a", parser.Cursor().Literal)
+
+	token, ok := parser.ExpectPeekIn(UnicodeDelimiters)
+	assert.True(t, ok)
+	assert.Equal(t, Token{TokenKind(','), ",", 1, 2, nil}, token)
+
+	token, ok = parser.ExpectPeekIn(LiteralKinds)
+	assert.True(t, ok)
+	assert.Equal(t, Token{TokenHexNumber, "0x1f", 3, 7, nil}, token)
+	assert.False(t, parser.IsCursorIn(UnicodeDelimiters))
+}
@@ -1,13 +1,209 @@
 package symbolizer
 
-import "unicode"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Tokenize generates all the Tokens for a given input string with some options that modify
+// the tokenization behaviour such as ignoring whitespaces or using custom keywords.
+// The returned Tokens always end with the EoF Token.
+func Tokenize(input string, opts ...ParserOption) []Token {
+	return newLexer([]byte(input), newParseConfig(opts...)).tokens()
+}
+
+// TokenizeAll generates the Tokens for each of the given input strings, distributing the
+// tokenization across a pool of workers (one for each available CPU). The options are compiled
+// once and shared by all the workers, so any token filters or custom scanners must be safe
+// for concurrent use. The returned slices are in the same order as the inputs.
+func TokenizeAll(inputs []string, opts ...ParserOption) [][]Token {
+	config := newParseConfig(opts...)
+	results := make([][]Token, len(inputs))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+
+	// Distribute the input indices to the workers
+	indices := make(chan int)
+	group := new(sync.WaitGroup)
+
+	for worker := 0; worker < workers; worker++ {
+		group.Add(1)
+
+		go func() {
+			defer group.Done()
+
+			for index := range indices {
+				results[index] = newLexer([]byte(inputs[index]), config).tokens()
+			}
+		}()
+	}
+
+	for index := range inputs {
+		indices <- index
+	}
+
+	close(indices)
+	group.Wait()
+
+	return results
+}
+
+// Lexer is an incremental token stream over an input string. It exposes the raw Tokens
+// of the input without any of the Parser semantics, for tools such as formatters and
+// highlighters. Options such as token filters and custom keywords are still honoured.
+type Lexer struct {
+	// scanner represents the token scanner
+	scanner *lexer
+	// peeked represents the Token that has been scanned ahead, if any
+	peeked *Token
+}
+
+// NewLexer generates a new Lexer for a given input string and some options that modify
+// the tokenization behaviour such as ignoring whitespaces or using custom keywords
+func NewLexer(input string, opts ...ParserOption) *Lexer {
+	return &Lexer{scanner: newLexer([]byte(input), newParseConfig(opts...))}
+}
+
+// Next advances the Lexer and returns the next Token.
+// Once the input is exhausted, the EoF Token is returned for every call.
+func (lexer *Lexer) Next() Token {
+	if lexer.peeked != nil {
+		token := *lexer.peeked
+		lexer.peeked = nil
+
+		return token
+	}
+
+	return lexer.scanner.nextToken()
+}
+
+// Peek returns the next Token without advancing the Lexer
+func (lexer *Lexer) Peek() Token {
+	if lexer.peeked == nil {
+		token := lexer.scanner.nextToken()
+		lexer.peeked = &token
+	}
+
+	return *lexer.peeked
+}
+
+// Done returns whether the Lexer is exhausted i.e., the next Token is the EoF Token
+func (lexer *Lexer) Done() bool {
+	return lexer.Peek().Kind == TokenEoF
+}
+
+// Err returns the error that terminated the Lexer (such as an exceeded limit or a malformed symbol with StrictMode), if any
+func (lexer *Lexer) Err() error {
+	if lexer.scanner.err == nil {
+		return nil
+	}
+
+	return lexer.scanner.err
+}
 
-// lexer is a lexical analyser that can tokenize a given string input into its unicode
+// lexer is a lexical analyser that can tokenize a given input into its unicode
 // characters while also generating tokens for identifiers, strings and numerics symbols.
+// The input is decoded as UTF-8 in place and the cursor is a byte offset into it.
 type lexer struct {
-	cursor  int
-	symbols []rune
-	config  *parseConfig
+	cursor int
+	input  []byte
+	config *parseConfig
+
+	// count is the number of lexemes scanned
+	count int
+	// prev is the TokenKind of the last lexeme scanned that is not a whitespace
+	prev TokenKind
+	// err is the terminal error of the lexer, after which it only produces EoF
+	err *Error
+	// errRecorded indicates if err has been recorded by a Parser
+	errRecorded bool
+	// eatSpaces indicates if whitespaces are consumed, which defaults to IgnoreWhitespaces
+	// but can be overridden by the Parser with SetIgnoreWhitespaces
+	eatSpaces bool
+	// source is the chain of middleware over the lexer, built when the first Token is scanned
+	source TokenSource
+
+	// metrics observes the Tokens emitted by the lexer (nil for lexers that rescan an input)
+	metrics Metrics
+	// started is the time at which the first Token was scanned, if metrics are observed
+	started time.Time
+	// observed indicates if the scan has been reported to the metrics
+	observed bool
+	// observedEnd is the end of the last Token reported to the metrics
+	observedEnd int
+}
+
+// newLexer generates a new lexer for the given input bytes and parse configuration.
+func newLexer(input []byte, config *parseConfig) *lexer {
+	lexer := &lexer{config: config, metrics: config.metrics}
+	lexer.reset(input)
+
+	return lexer
+}
+
+// reset rewinds the lexer to the start of the given input bytes and clears any terminal error.
+// If the input exceeds the MaxInputBytes limit, the lexer is terminated immediately.
+// If NFCNormalize is enabled, the input is normalized unless it is already in NFC.
+func (lexer *lexer) reset(input []byte) {
+	if lexer.config.nfc && !norm.NFC.IsNormal(input) {
+		input = norm.NFC.Bytes(input)
+	}
+
+	lexer.cursor, lexer.input = 0, input
+	lexer.count, lexer.err, lexer.errRecorded = 0, nil, false
+	lexer.prev = TokenEoF
+	lexer.eatSpaces = lexer.config.eatSpaces
+	lexer.source = nil
+	lexer.started, lexer.observed, lexer.observedEnd = time.Time{}, false, 0
+
+	if limit := lexer.config.maxInputBytes; limit > 0 && len(input) > limit {
+		lexer.terminate(limit, ErrLimitExceeded, "input size limit exceeded: %d bytes", limit)
+	}
+}
+
+// terminate stops the lexer at the given position with a terminal error.
+// All subsequent calls to next will return an EoF lexeme at that position.
+func (lexer *lexer) terminate(pos int, sentinel error, format string, args ...any) {
+	lexer.cursor = pos
+	lexer.input = lexer.input[:pos]
+	lexer.err = &Error{Position: pos, Message: fmt.Sprintf(format, args...), Err: sentinel}
+}
+
+// terminateMalformed terminates the lexer at a malformed lexeme with an Error that describes the problem
+func (lexer *lexer) terminateMalformed(lexeme Lexeme) {
+	literal := lexeme.Literal(lexer.input)
+
+	if lexer.config.strict {
+		if offset := nonASCII([]byte(literal)); offset >= 0 {
+			symbol, _ := utf8.DecodeRuneInString(literal[offset:])
+			lexer.terminate(lexeme.Start+offset, ErrNonASCII, "non-ASCII symbol not permitted: %q", symbol)
+			return
+		}
+	}
+
+	switch trimmed := strings.TrimLeft(literal, "+-"); {
+	case strings.HasPrefix(literal, `"`), strings.HasPrefix(literal, "`"), strings.HasPrefix(literal, "<<"),
+		lexer.config.base64Prefix != "" && strings.HasPrefix(literal, lexer.config.base64Prefix+`"`):
+		lexer.terminate(lexeme.Start, ErrMalformed, "unterminated string: '%v'", literal)
+	case strings.HasPrefix(trimmed, "0x"):
+		lexer.terminate(lexeme.Start, ErrMalformed, "hex prefix without digits: '%v'", literal)
+	case !utf8.ValidString(literal):
+		lexer.terminate(lexeme.Start, ErrMalformed, "invalid UTF-8 byte: %#x", literal[0])
+	default:
+		lexer.terminate(lexeme.Start, ErrMalformed, "malformed token: '%v'", literal)
+	}
 }
 
 // char returns the unicode symbols that is currently under the Lexer's cursor.
@@ -17,19 +213,38 @@ func (lexer *lexer) char() rune {
 		return rune(TokenEoF)
 	}
 
-	return lexer.symbols[lexer.cursor]
+	symbol, _ := lexer.decode(lexer.cursor)
+	return symbol
 }
 
 // peek returns the unicode symbol that is ahead of the Lexer's cursor.
 // This look ahead is performed without moving the Lexer's cursor.
 // If the Lexer tap is exhausted, an EoF rune is returned.
 func (lexer *lexer) peek() rune {
-	// If lexer is done or cannot peek, return EoF
-	if lexer.done() || lexer.cursor+1 == len(lexer.symbols) {
+	// If lexer is done, return EoF
+	if lexer.done() {
 		return rune(TokenEoF)
 	}
 
-	return lexer.symbols[lexer.cursor+1]
+	// Determine the position of the next symbol
+	_, width := lexer.decode(lexer.cursor)
+	// If the lexer cannot peek, return EoF
+	if lexer.cursor+width >= len(lexer.input) {
+		return rune(TokenEoF)
+	}
+
+	symbol, _ := lexer.decode(lexer.cursor + width)
+	return symbol
+}
+
+// decode returns the unicode symbol at the given byte offset and its width in bytes.
+// ASCII symbols are returned directly without going through the UTF-8 decoder.
+func (lexer *lexer) decode(offset int) (rune, int) {
+	if symbol := lexer.input[offset]; symbol < utf8.RuneSelf {
+		return rune(symbol), 1
+	}
+
+	return utf8.DecodeRune(lexer.input[offset:])
 }
 
 // tokens returns all the remaining Tokens in the lexer, by parsing
@@ -38,7 +253,7 @@ func (lexer *lexer) peek() rune {
 // returned Tokens do not represent all the Tokens for a given input.
 func (lexer *lexer) tokens() (tokens []Token) {
 	for {
-		token := lexer.next()
+		token := lexer.nextToken()
 		tokens = append(tokens, token)
 
 		if token.Kind == TokenEoF {
@@ -49,46 +264,249 @@ func (lexer *lexer) tokens() (tokens []Token) {
 	return tokens
 }
 
+// nextToken returns the next Token emitted by the lexer and reports it to the Metrics, if any
+func (lexer *lexer) nextToken() Token {
+	if lexer.metrics == nil {
+		return lexer.sourceToken()
+	}
+
+	if lexer.started.IsZero() {
+		lexer.started = time.Now()
+	}
+
+	return lexer.observe(lexer.sourceToken())
+}
+
+// sourceToken returns the next Token from the chain of middleware over the lexer, if any are configured with Use.
+// Otherwise, it returns the next Token from the lexer itself (see filteredToken).
+func (lexer *lexer) sourceToken() Token {
+	if lexer.config.middleware == nil {
+		return lexer.filteredToken()
+	}
+
+	if lexer.source == nil {
+		lexer.source = lexer.config.chain(lexerSource{lexer})
+	}
+
+	return lexer.source.Next()
+}
+
+// fork returns a copy of the lexer at its current position. The copy builds its own
+// chain of middleware and does not report the Tokens that it rescans to the metrics.
+func (lexer *lexer) fork() *lexer {
+	scanner := *lexer
+	scanner.source, scanner.metrics = nil, nil
+
+	return &scanner
+}
+
+// filteredToken advances the Lexer's cursor and returns the next Token (with any keyword data) after
+// applying any configured token filters to it. Tokens dropped by a filter are skipped over.
+// The EoF Token is never passed to the filters.
+func (lexer *lexer) filteredToken() Token {
+Scan:
+	for {
+		token := lexer.next().Token(lexer.input)
+		if token.Kind == TokenEoF {
+			return token
+		}
+
+		// Convert numeric boolean spellings into booleans
+		if token.Kind == TokenNumber && lexer.config.numBooleans != nil {
+			if data, ok := lexer.config.numBooleans[token.Literal]; ok {
+				token.Kind, token.Data = TokenBoolean, data
+			}
+		}
+
+		if lexer.config.keywordData != nil {
+			lexer.attachKeywordData(&token)
+		}
+
+		for _, filter := range lexer.config.filters {
+			var keep bool
+			if token, keep = filter(token); !keep {
+				continue Scan
+			}
+		}
+
+		return token
+	}
+}
+
 // done returns whether the Lexer tape is exhausted i.e., EoF has been reached
 func (lexer *lexer) done() bool {
-	return lexer.cursor >= len(lexer.symbols)
+	return lexer.cursor >= len(lexer.input)
+}
+
+// next advances the Lexer's cursor and returns the encountered Lexeme.
+// If the MaxTokens limit is exceeded, the lexer is terminated and returns EoF.
+// If StrictASCII is enabled, lexemes that contain non-ASCII symbols are malformed.
+func (lexer *lexer) next() Lexeme {
+	lexeme := lexer.scan()
+	if lexeme.Kind == TokenEoF {
+		return lexeme
+	}
+
+	// Every lexeme must advance the cursor, a scanner that does not consume
+	// the symbol under the cursor generates a malformed lexeme for it instead
+	if lexeme.End == lexeme.Start {
+		lexer.advanceCursor()
+		lexeme = Lexeme{TokenMalformed, lexeme.Start, lexer.cursor}
+	}
+
+	if lexer.config.strict && nonASCII(lexer.input[lexeme.Start:lexeme.End]) >= 0 {
+		lexeme.Kind = TokenMalformed
+	}
+
+	// Terminate at malformed lexemes, if StrictMode is enabled
+	if lexeme.Kind == TokenMalformed && lexer.config.failFast {
+		lexer.terminateMalformed(lexeme)
+		return Lexeme{TokenEoF, lexer.cursor, lexer.cursor}
+	}
+
+	// Enforce the token count limit
+	lexer.count++
+	if limit := lexer.config.maxTokens; limit > 0 && lexer.count > limit {
+		lexer.terminate(lexeme.Start, ErrLimitExceeded, "token limit exceeded: %d tokens", limit)
+		return Lexeme{TokenEoF, lexer.cursor, lexer.cursor}
+	}
+
+	if !unicode.IsSpace(rune(lexeme.Kind)) {
+		lexer.prev = lexeme.Kind
+	}
+
+	return lexeme
+}
+
+// afterOperand returns whether the last lexeme that is not a whitespace ends an operand,
+// which is an identifier, a keyword, a value, a placeholder or a closing bracket
+func (lexer *lexer) afterOperand() bool {
+	switch kind := lexer.prev; {
+	case kind == TokenIdent, kind == TokenPlaceholder, kind.CanValue(), kind.custom():
+		return true
+	default:
+		return kind == ')' || kind == ']' || kind == '}'
+	}
 }
 
-// next advances the Lexer's cursor and returns the encountered Token.
-func (lexer *lexer) next() (token Token) {
-	// If lexer configuration specifies to ignore whitespaces, consume them
-	if lexer.config.eatSpaces {
+// scan scans the input at the Lexer's cursor and returns the encountered Lexeme.
+func (lexer *lexer) scan() Lexeme {
+	// If lexer is set to ignore whitespaces, consume them
+	if lexer.eatSpaces {
 		lexer.consumeSpaces()
 	}
 
-	// Get the current symbol of the Lexer and check conditions
-	switch symbol := lexer.char(); {
+	// Get the current symbol of the Lexer
+	symbol := lexer.char()
+
+	// Non-ASCII Symbol -> Malformed, if StrictASCII is enabled
+	if lexer.config.strict && symbol >= utf8.RuneSelf {
+		start := lexer.cursor
+		lexer.advanceCursor()
+
+		return Lexeme{TokenMalformed, start, lexer.cursor}
+	}
+
+	// Attempt any custom scanners that are triggered by the symbol
+	if symbol != rune(TokenEoF) {
+		for _, custom := range lexer.config.scanners {
+			if !custom.trigger(symbol) {
+				continue
+			}
+
+			if lexeme, ok := lexer.scanCustom(custom); ok {
+				return lexeme
+			}
+		}
+	}
+
+	// Generate a lexeme of the configured kind for runs of symbols that match a run class
+	if symbol != rune(TokenEoF) {
+		for _, run := range lexer.config.runs {
+			if run.class(symbol) {
+				return lexer.scanRun(run)
+			}
+		}
+	}
+
+	// Generate a lexeme of the configured kind if the symbol belongs to a symbol class
+	if kind, ok := lexer.config.symbolClass(symbol); ok {
+		start := lexer.cursor
+		lexer.advanceCursor()
+
+		return Lexeme{kind, start, lexer.cursor}
+	}
+
+	// Currency Sigil -> Scan for Amount, if enabled
+	if lexer.config.amounts && unicode.Is(unicode.Sc, symbol) {
+		if lexeme, ok := lexer.scanAmount(); ok {
+			return lexeme
+		}
+	}
+
+	// Slash -> Scan for Regex, if enabled
+	if symbol == '/' && lexer.config.regexes {
+		if lexeme, ok := lexer.scanRegex(); ok {
+			return lexeme
+		}
+	}
+
+	// Wildcard -> Scan for Wildcard, if enabled
+	if lexer.config.wildcards && (symbol == '*' || symbol == '?') {
+		return lexer.scanWildcard()
+	}
+
+	// Placeholder Marker -> Scan for Placeholder, if enabled
+	if lexer.config.placeholders && (symbol == '?' || symbol == ':') {
+		if lexeme, ok := lexer.scanPlaceholder(); ok {
+			return lexeme
+		}
+	}
+
+	// Heredoc Marker -> Scan for Heredoc String, if enabled
+	if symbol == '<' && lexer.config.heredocs {
+		if lexeme, ok := lexer.scanHeredoc(); ok {
+			return lexeme
+		}
+	}
+
+	// Check conditions on the symbol
+	switch {
 	// End of File
 	case symbol == rune(TokenEoF):
-		token = EOFToken(lexer.cursor)
+		return Lexeme{TokenEoF, lexer.cursor, lexer.cursor}
 
 	// Quotes -> Scan for String
 	case symbol == '"':
-		token = lexer.scanString()
+		return lexer.scanString()
+
+	// Backtick -> Scan for Raw String, if enabled
+	case symbol == '`' && lexer.config.rawStrings:
+		return lexer.scanRawString()
 
 	// Hex Prefix
 	case symbol == '0':
-		if lexer.peek() == 'x' {
+		if lexer.peek() == 'x' && !lexer.config.noHex {
 			return lexer.scanHexadecimal()
 		}
 
 		fallthrough
 
-	// Digit -> Scan for Numeric (Integer/Float)
-	case unicode.IsDigit(symbol):
+	// Decimal Digit -> Scan for Numeric (Integer/Float)
+	// Non-ASCII digits (such as '٣') generate unicode lexemes
+	case isDecChar(symbol):
 		return lexer.scanNumeric()
 
 	// Letter -> Scan for Identifier or Keyword
-	case unicode.IsLetter(symbol):
+	case unicode.IsLetter(symbol) || lexer.config.identClass(symbol):
 		return lexer.scanIdentOrKeyword()
 
-	// Negative Sign -> Scan for Numeric
-	case symbol == '-':
+	// Sign -> Scan for signed Hex or Numeric
+	case symbol == '-' || symbol == '+':
+		if bytes.HasPrefix(lexer.input[lexer.cursor+1:], []byte("0x")) && !lexer.config.noHex {
+			return lexer.scanHexadecimal()
+		}
+
 		if isDecChar(lexer.peek()) {
 			return lexer.scanNumeric()
 		}
@@ -96,23 +514,63 @@ func (lexer *lexer) next() (token Token) {
 		fallthrough
 
 	default:
-		// Generate a token for the Unicode symbol
-		token = UnicodeToken(symbol, lexer.cursor)
-	}
+		// Generate a lexeme for the Unicode symbol
+		start := lexer.cursor
+		lexer.advanceCursor()
 
-	// Push the cursor for the next iteration
-	lexer.advanceCursor()
-	// Return the generated token
-	return token
+		// Invalid UTF-8 -> Malformed (a correctly encoded U+FFFD is 3 bytes wide)
+		if symbol == utf8.RuneError && lexer.cursor-start == 1 {
+			return Lexeme{TokenMalformed, start, lexer.cursor}
+		}
+
+		return Lexeme{TokenKind(symbol), start, lexer.cursor}
+	}
 }
 
-// advanceCursor increments the Lexer's cursor
-func (lexer *lexer) advanceCursor() { lexer.cursor++ }
+// advanceCursor moves the Lexer's cursor past the symbol currently under it
+func (lexer *lexer) advanceCursor() {
+	if lexer.done() {
+		return
+	}
+
+	_, width := lexer.decode(lexer.cursor)
+	lexer.cursor += width
+}
 
-// collectBetween collects all symbols from a specified index
-// until the current cursor position and return it as a string
+// collectBetween collects all symbols between the specified
+// byte offsets of the input and return it as a string
 func (lexer *lexer) collectBetween(start, stop int) string {
-	return string(lexer.symbols[start:stop])
+	return string(lexer.input[start:stop])
+}
+
+// collectUnescaped collects the input between the specified byte offsets
+// without the escape characters at the given byte offsets (see EscapeRune)
+func (lexer *lexer) collectUnescaped(start, stop int, escapes []int) string {
+	if len(escapes) == 0 {
+		return lexer.collectBetween(start, stop)
+	}
+
+	var collected strings.Builder
+	_ = lexer.writeUnescaped(&collected, start, stop, escapes)
+
+	return collected.String()
+}
+
+// writeUnescaped writes the input between the specified byte offsets into the
+// writer without the escape characters at the given byte offsets (see EscapeRune)
+func (lexer *lexer) writeUnescaped(w io.Writer, start, stop int, escapes []int) error {
+	width := utf8.RuneLen(lexer.config.escape)
+
+	for _, escape := range escapes {
+		if _, err := w.Write(lexer.input[start:escape]); err != nil {
+			return err
+		}
+
+		start = escape + width
+	}
+
+	_, err := w.Write(lexer.input[start:stop])
+	return err
 }
 
 // consumeSpaces moves its cursor to the next character by skips all unicode whitespaces in between.
@@ -127,14 +585,15 @@ func (lexer *lexer) consumeSpaces() {
 // If there exists rule entry for the identifier, then the TokenKind
 // in the rule is returned, otherwise the literal is treated as a
 // regular identifier and TokenIdentifier is returned.
-func (lexer *lexer) lookupKeyword(ident string) TokenKind {
+func (lexer *lexer) lookupKeyword(ident []byte) TokenKind {
 	// If no keywords available, immediately return TokenIdentifier
 	if lexer.config.keywords == nil {
 		return TokenIdent
 	}
 
-	// Retrieve the token kind for the ident from the keyword registry and return if it exists
-	if tok, ok := lexer.config.keywords[ident]; ok {
+	// Retrieve the token kind for the ident from the keyword registry and return if it exists.
+	// The string conversion within the map index expression does not allocate.
+	if tok, ok := lexer.config.keywords[string(ident)]; ok {
 		// Return the user defined identifier
 		return tok
 	}
@@ -142,29 +601,95 @@ func (lexer *lexer) lookupKeyword(ident string) TokenKind {
 	return TokenIdent
 }
 
-// scanIdentOrKeyword scans for an Identifier token, If the literal has a special
-// TokenKind in the keyword registry, the returned Token has the appropriate TokenKind.
-func (lexer *lexer) scanIdentOrKeyword() Token {
+// attachKeywordData sets the Data of a keyword Token to the handle of its keyword data, if any
+func (lexer *lexer) attachKeywordData(token *Token) {
+	if token.Kind == TokenIdent || token.Kind > 0 || token.Data != nil {
+		return
+	}
+
+	keyword := lexer.config.normalizeKeyword(token.Literal)
+	if data, ok := lexer.config.keywordData[keyword]; ok && lexer.keywordKind(keyword) == token.Kind {
+		token.Data = data
+	}
+}
+
+// keywordKind returns the TokenKind of a normalized keyword
+func (lexer *lexer) keywordKind(keyword string) TokenKind {
+	if lexer.config.trie == nil {
+		return lexer.lookupKeyword([]byte(keyword))
+	}
+
+	node := lexer.config.trie
+	for _, word := range strings.Split(keyword, " ") {
+		if node = node.children[word]; node == nil {
+			return TokenIdent
+		}
+	}
+
+	if !node.terminal {
+		return TokenIdent
+	}
+
+	return node.kind
+}
+
+// scanRun scans for a lexeme of the run's kind by collecting symbols until one does not match the run's class
+func (lexer *lexer) scanRun(run runClass) Lexeme {
+	start := lexer.cursor
+	for !lexer.done() && run.class(lexer.char()) {
+		lexer.advanceCursor()
+	}
+
+	return Lexeme{run.kind, start, lexer.cursor}
+}
+
+// scanIdentOrKeyword scans for an Identifier lexeme, If the literal has a special
+// TokenKind in the keyword registry, the returned Lexeme has the appropriate TokenKind.
+func (lexer *lexer) scanIdentOrKeyword() Lexeme {
 	// Retrieve the starting position of the identifier
 	start := lexer.cursor
 
+	// Check for semantic version literals with a 'v' prefix, if enabled
+	if lexer.config.semvers && lexer.char() == 'v' {
+		if length := matchSemver(lexer.input[start+1:]); length > 0 {
+			lexer.cursor += 1 + length
+			return Lexeme{TokenSemver, start, lexer.cursor}
+		}
+	}
+
 	// Iterate over the input until characters are letters
-	for unicode.IsLetter(lexer.char()) || unicode.IsDigit(lexer.char()) || lexer.char() == '_' {
+	for lexer.identChar(lexer.char()) {
 		lexer.advanceCursor()
 	}
 
-	// Extract the identifier from the input with the start and current position
-	identifier := lexer.collectBetween(start, lexer.cursor)
+	// Check for base64 literals (prefix immediately followed by a string), if enabled
+	if prefix := lexer.config.base64Prefix; prefix != "" && lexer.char() == '"' && string(lexer.input[start:lexer.cursor]) == prefix {
+		lexeme := lexer.scanString()
+		if lexeme.Kind == TokenString {
+			lexeme.Kind = TokenBase64
+		}
+
+		lexeme.Start = start
+		return lexeme
+	}
+
+	// Match multi-word or case-insensitive keywords from the trie, if compiled
+	if lexer.config.trie != nil {
+		kind := lexer.matchKeyword(start)
+		return Lexeme{Kind: kind, Start: start, End: lexer.cursor}
+	}
 
-	return Token{
-		Kind:     lexer.lookupKeyword(identifier),
-		Literal:  identifier,
-		Position: start,
+	return Lexeme{
+		Kind:  lexer.lookupKeyword(lexer.input[start:lexer.cursor]),
+		Start: start,
+		End:   lexer.cursor,
 	}
 }
 
-// scanString scans for a String token by collecting characters until another '"' is encountered.
-func (lexer *lexer) scanString() Token {
+// scanString scans for a String lexeme by collecting characters until another '"' is encountered.
+// If the input is exhausted before the closing quote, a malformed lexeme spanning the
+// rest of the input is returned and scanning resumes at the end of the input.
+func (lexer *lexer) scanString() Lexeme {
 	// Retrieve the starting position
 	start := lexer.cursor
 
@@ -176,36 +701,75 @@ func (lexer *lexer) scanString() Token {
 			break
 		}
 
-		// If EoF encountered prematurely, return malformed token
+		// If EoF encountered prematurely, return malformed lexeme
 		if lexer.char() == rune(TokenEoF) {
-			token := Token{
-				Kind:     TokenMalformed,
-				Literal:  lexer.collectBetween(start, lexer.cursor),
-				Position: start,
-			}
-
-			lexer.cursor--
-			return token
+			return Lexeme{TokenMalformed, start, lexer.cursor}
 		}
 	}
 
-	// Extract the string from input and set as text token literal
-	// Includes the quote characters as well
-	return Token{
-		Kind:     TokenString,
-		Literal:  lexer.collectBetween(start, lexer.cursor+1),
-		Position: start,
-	}
+	// Move past the closing quote, the literal
+	// includes the quote characters as well
+	lexer.advanceCursor()
+
+	return Lexeme{TokenString, start, lexer.cursor}
 }
 
-// scanNumeric scans for a Numeric token (decimal or hexadecimal).
+// scanNumeric scans for a Numeric lexeme (decimal or hexadecimal).
 // If it encounters '0x', it will attempt to read the rest of the
 // character as hexadecimal using scanHexadecimal
-func (lexer *lexer) scanNumeric() Token {
+func (lexer *lexer) scanNumeric() Lexeme {
 	// Retrieve the starting position of the number
 	start := lexer.cursor
 
-	if lexer.char() == '-' {
+	// Check for timestamp literals, if enabled
+	if lexer.config.timestamps {
+		if length := matchTimestamp(lexer.input[start:]); length > 0 {
+			lexer.cursor += length
+			return Lexeme{TokenTimestamp, start, lexer.cursor}
+		}
+	}
+
+	// Check for amounts with a currency code, if enabled
+	if lexer.config.amounts {
+		if length := matchCodedAmount(lexer.input[start:], lexer.config.currencyCodes); length > 0 {
+			lexer.cursor += length
+			return Lexeme{TokenAmount, start, lexer.cursor}
+		}
+	}
+
+	// Check for duration literals, if enabled
+	if lexer.config.durations {
+		if length := matchDuration(lexer.input[start:]); length > 0 {
+			lexer.cursor += length
+			return Lexeme{TokenDuration, start, lexer.cursor}
+		}
+	}
+
+	// Check for semantic version literals, if enabled
+	if lexer.config.semvers {
+		if length := matchSemver(lexer.input[start:]); length > 0 {
+			lexer.cursor += length
+			return Lexeme{TokenSemver, start, lexer.cursor}
+		}
+	}
+
+	// Check for floating point literals, if enabled
+	if lexer.config.floats {
+		if length := matchFloat(lexer.input[start:]); length > 0 {
+			lexer.cursor += length
+			return Lexeme{TokenFloat, start, lexer.cursor}
+		}
+	}
+
+	// Check for suffixed numeric literals, if enabled
+	if lexer.config.suffixes {
+		if length := matchSuffixed(lexer.input[start:]); length > 0 {
+			lexer.cursor += length
+			return Lexeme{TokenSuffixed, start, lexer.cursor}
+		}
+	}
+
+	if isSignChar(lexer.char()) {
 		lexer.advanceCursor()
 	}
 
@@ -214,34 +778,66 @@ func (lexer *lexer) scanNumeric() Token {
 		lexer.advanceCursor()
 	}
 
-	// Extract the number from input and set as number token literal
-	return Token{
-		Kind:     TokenNumber,
-		Literal:  lexer.collectBetween(start, lexer.cursor),
-		Position: start,
-	}
+	return Lexeme{TokenNumber, start, lexer.cursor}
 }
 
-// scanHexadecimal scans for a Hex Numeric Token. It must be invoked after
-// encountering a '0x' and attempts to read hex characters A-F, a-f, 0-9.
-func (lexer *lexer) scanHexadecimal() Token {
+// scanHexadecimal scans for a Hex Numeric lexeme. It must be invoked after
+// encountering a '0x' (optionally signed) and attempts to read hex characters A-F, a-f, 0-9.
+// If the '0x' prefix is not followed by any hex characters, a malformed lexeme
+// spanning the prefix is returned and scanning resumes after the prefix.
+func (lexer *lexer) scanHexadecimal() Lexeme {
 	// Retrieve the starting position of the identifier
 	start := lexer.cursor
 
+	if isSignChar(lexer.char()) {
+		lexer.advanceCursor()
+	}
+
 	lexer.advanceCursor()
 	lexer.advanceCursor()
 
+	// Prefix without digits -> Malformed
+	if !isHexChar(lexer.char()) {
+		return Lexeme{TokenMalformed, start, lexer.cursor}
+	}
+
 	// Iterate over the input until characters are hex characters
 	for isHexChar(lexer.char()) {
 		lexer.advanceCursor()
 	}
 
-	// Extract the number from input and set as digits token literal
-	return Token{
-		Kind:     TokenHexNumber,
-		Literal:  lexer.collectBetween(start, lexer.cursor),
-		Position: start,
+	return Lexeme{TokenHexNumber, start, lexer.cursor}
+}
+
+// identChar returns true if ch can be part of an identifier with the configured identifier classes.
+// If StrictASCII is enabled, non-ASCII symbols are never part of an identifier.
+func (lexer *lexer) identChar(ch rune) bool {
+	if lexer.config.strict && ch >= utf8.RuneSelf {
+		return false
 	}
+
+	return isIdentChar(ch) || lexer.config.identClass(ch)
+}
+
+// nonASCII returns the offset of the first non-ASCII byte in data or -1 if all of its bytes are ASCII
+func nonASCII(data []byte) int {
+	for offset, char := range data {
+		if char >= utf8.RuneSelf {
+			return offset
+		}
+	}
+
+	return -1
+}
+
+// isIdentChar returns true if ch can be part of an identifier
+func isIdentChar(ch rune) bool {
+	return unicode.IsLetter(ch) || unicode.IsDigit(ch) || ch == '_'
+}
+
+// isSignChar returns true if ch is a numeric sign character
+func isSignChar(ch rune) bool {
+	return ch == '-' || ch == '+'
 }
 
 // isDecChar returns true if ch is a decimal character
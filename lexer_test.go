@@ -1,7 +1,11 @@
 package symbolizer
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -23,185 +27,185 @@ func TestLexer(t *testing.T) {
 		{
 			`hello123,^`,
 			[]Token{
-				{TokenIdent, "hello123", 0},
-				{TokenKind(','), ",", 8},
-				{TokenKind('^'), "^", 9},
+				{TokenIdent, "hello123", 0, 8, nil},
+				{TokenKind(','), ",", 8, 9, nil},
+				{TokenKind('^'), "^", 9, 10, nil},
 				EOFToken(10),
 			},
 			[]Token{
-				{TokenIdent, "hello123", 0},
-				{TokenKind(','), ",", 8},
-				{TokenKind('^'), "^", 9},
+				{TokenIdent, "hello123", 0, 8, nil},
+				{TokenKind(','), ",", 8, 9, nil},
+				{TokenKind('^'), "^", 9, 10, nil},
 				EOFToken(10),
 			},
 			[]Token{
-				{TokenIdent, "hello123", 0},
-				{TokenKind(','), ",", 8},
-				{TokenKind('^'), "^", 9},
+				{TokenIdent, "hello123", 0, 8, nil},
+				{TokenKind(','), ",", 8, 9, nil},
+				{TokenKind('^'), "^", 9, 10, nil},
 				EOFToken(10),
 			},
 		},
 		{
 			`true = True`,
 			[]Token{
-				{TokenBoolean, "true", 0},
+				{TokenBoolean, "true", 0, 4, nil},
 				UnicodeToken(' ', 4),
-				{TokenKind('='), "=", 5},
+				{TokenKind('='), "=", 5, 6, nil},
 				UnicodeToken(' ', 6),
-				{TokenIdent, "True", 7},
+				{TokenIdent, "True", 7, 11, nil},
 				EOFToken(11),
 			},
 			[]Token{
-				{TokenBoolean, "true", 0},
-				{TokenKind('='), "=", 5},
-				{TokenIdent, "True", 7},
+				{TokenBoolean, "true", 0, 4, nil},
+				{TokenKind('='), "=", 5, 6, nil},
+				{TokenIdent, "True", 7, 11, nil},
 				EOFToken(11),
 			},
 			[]Token{
-				{TokenBoolean, "true", 0},
+				{TokenBoolean, "true", 0, 4, nil},
 				UnicodeToken(' ', 4),
-				{TokenKind('='), "=", 5},
+				{TokenKind('='), "=", 5, 6, nil},
 				UnicodeToken(' ', 6),
-				{TokenBoolean, "True", 7},
+				{TokenBoolean, "True", 7, 11, nil},
 				EOFToken(11),
 			},
 		},
 		{
 			`classes:: MyClass`,
 			[]Token{
-				{TokenIdent, "classes", 0},
-				{TokenKind(':'), ":", 7},
-				{TokenKind(':'), ":", 8},
+				{TokenIdent, "classes", 0, 7, nil},
+				{TokenKind(':'), ":", 7, 8, nil},
+				{TokenKind(':'), ":", 8, 9, nil},
 				UnicodeToken(' ', 9),
-				{TokenIdent, "MyClass", 10},
+				{TokenIdent, "MyClass", 10, 17, nil},
 				EOFToken(17),
 			},
 			[]Token{
-				{TokenIdent, "classes", 0},
-				{TokenKind(':'), ":", 7},
-				{TokenKind(':'), ":", 8},
-				{TokenIdent, "MyClass", 10},
+				{TokenIdent, "classes", 0, 7, nil},
+				{TokenKind(':'), ":", 7, 8, nil},
+				{TokenKind(':'), ":", 8, 9, nil},
+				{TokenIdent, "MyClass", 10, 17, nil},
 				EOFToken(17),
 			},
 			[]Token{
-				{-10, "classes", 0},
-				{TokenKind(':'), ":", 7},
-				{TokenKind(':'), ":", 8},
+				{-10, "classes", 0, 7, nil},
+				{TokenKind(':'), ":", 7, 8, nil},
+				{TokenKind(':'), ":", 8, 9, nil},
 				UnicodeToken(' ', 9),
-				{TokenIdent, "MyClass", 10},
+				{TokenIdent, "MyClass", 10, 17, nil},
 				EOFToken(17),
 			},
 		},
 		{
 			`"this is the text" -> "hello"`,
 			[]Token{
-				{TokenString, `"this is the text"`, 0},
+				{TokenString, `"this is the text"`, 0, 18, nil},
 				UnicodeToken(' ', 18),
 				UnicodeToken('-', 19),
 				UnicodeToken('>', 20),
 				UnicodeToken(' ', 21),
-				{TokenString, `"hello"`, 22},
+				{TokenString, `"hello"`, 22, 29, nil},
 				EOFToken(29),
 			},
 			[]Token{
-				{TokenString, `"this is the text"`, 0},
+				{TokenString, `"this is the text"`, 0, 18, nil},
 				UnicodeToken('-', 19),
 				UnicodeToken('>', 20),
-				{TokenString, `"hello"`, 22},
+				{TokenString, `"hello"`, 22, 29, nil},
 				EOFToken(29),
 			},
 			[]Token{
-				{TokenString, `"this is the text"`, 0},
+				{TokenString, `"this is the text"`, 0, 18, nil},
 				UnicodeToken(' ', 18),
 				UnicodeToken('-', 19),
 				UnicodeToken('>', 20),
 				UnicodeToken(' ', 21),
-				{TokenString, `"hello"`, 22},
+				{TokenString, `"hello"`, 22, 29, nil},
 				EOFToken(29),
 			},
 		},
 		{
 			`12345. 2231`,
 			[]Token{
-				{TokenNumber, "12345", 0},
+				{TokenNumber, "12345", 0, 5, nil},
 				UnicodeToken('.', 5),
 				UnicodeToken(' ', 6),
-				{TokenNumber, "2231", 7},
+				{TokenNumber, "2231", 7, 11, nil},
 				EOFToken(11),
 			},
 			[]Token{
-				{TokenNumber, "12345", 0},
+				{TokenNumber, "12345", 0, 5, nil},
 				UnicodeToken('.', 5),
-				{TokenNumber, "2231", 7},
+				{TokenNumber, "2231", 7, 11, nil},
 				EOFToken(11),
 			},
 			[]Token{
-				{TokenNumber, "12345", 0},
+				{TokenNumber, "12345", 0, 5, nil},
 				UnicodeToken('.', 5),
 				UnicodeToken(' ', 6),
-				{TokenNumber, "2231", 7},
+				{TokenNumber, "2231", 7, 11, nil},
 				EOFToken(11),
 			},
 		},
 		{
 			"person.age = 0x18",
 			[]Token{
-				{TokenIdent, "person", 0},
+				{TokenIdent, "person", 0, 6, nil},
 				UnicodeToken('.', 6),
-				{TokenIdent, "age", 7},
+				{TokenIdent, "age", 7, 10, nil},
 				UnicodeToken(' ', 10),
 				UnicodeToken('=', 11),
 				UnicodeToken(' ', 12),
-				{TokenHexNumber, "0x18", 13},
+				{TokenHexNumber, "0x18", 13, 17, nil},
 				EOFToken(17),
 			},
 			[]Token{
-				{TokenIdent, "person", 0},
+				{TokenIdent, "person", 0, 6, nil},
 				UnicodeToken('.', 6),
-				{TokenIdent, "age", 7},
+				{TokenIdent, "age", 7, 10, nil},
 				UnicodeToken('=', 11),
-				{TokenHexNumber, "0x18", 13},
+				{TokenHexNumber, "0x18", 13, 17, nil},
 				EOFToken(17),
 			},
 			[]Token{
-				{TokenIdent, "person", 0},
+				{TokenIdent, "person", 0, 6, nil},
 				UnicodeToken('.', 6),
-				{-11, "age", 7},
+				{-11, "age", 7, 10, nil},
 				UnicodeToken(' ', 10),
 				UnicodeToken('=', 11),
 				UnicodeToken(' ', 12),
-				{TokenHexNumber, "0x18", 13},
+				{TokenHexNumber, "0x18", 13, 17, nil},
 				EOFToken(17),
 			},
 		},
 		{
 			`person.mark = -923`,
 			[]Token{
-				{TokenIdent, "person", 0},
+				{TokenIdent, "person", 0, 6, nil},
 				UnicodeToken('.', 6),
-				{TokenIdent, "mark", 7},
+				{TokenIdent, "mark", 7, 11, nil},
 				UnicodeToken(' ', 11),
 				UnicodeToken('=', 12),
 				UnicodeToken(' ', 13),
-				{TokenNumber, "-923", 14},
+				{TokenNumber, "-923", 14, 18, nil},
 				EOFToken(18),
 			},
 			[]Token{
-				{TokenIdent, "person", 0},
+				{TokenIdent, "person", 0, 6, nil},
 				UnicodeToken('.', 6),
-				{TokenIdent, "mark", 7},
+				{TokenIdent, "mark", 7, 11, nil},
 				UnicodeToken('=', 12),
-				{TokenNumber, "-923", 14},
+				{TokenNumber, "-923", 14, 18, nil},
 				EOFToken(18),
 			},
 			[]Token{
-				{TokenIdent, "person", 0},
+				{TokenIdent, "person", 0, 6, nil},
 				UnicodeToken('.', 6),
-				{-12, "mark", 7},
+				{-12, "mark", 7, 11, nil},
 				UnicodeToken(' ', 11),
 				UnicodeToken('=', 12),
 				UnicodeToken(' ', 13),
-				{TokenNumber, "-923", 14},
+				{TokenNumber, "-923", 14, 18, nil},
 				EOFToken(18),
 			},
 		},
@@ -209,15 +213,15 @@ func TestLexer(t *testing.T) {
 		{
 			`"abcdefg`,
 			[]Token{
-				{TokenMalformed, `"abcdefg`, 0},
+				{TokenMalformed, `"abcdefg`, 0, 8, nil},
 				EOFToken(8),
 			},
 			[]Token{
-				{TokenMalformed, `"abcdefg`, 0},
+				{TokenMalformed, `"abcdefg`, 0, 8, nil},
 				EOFToken(8),
 			},
 			[]Token{
-				{TokenMalformed, `"abcdefg`, 0},
+				{TokenMalformed, `"abcdefg`, 0, 8, nil},
 				EOFToken(8),
 			},
 		},
@@ -225,22 +229,324 @@ func TestLexer(t *testing.T) {
 
 	t.Run("Standard Lexer", func(t *testing.T) {
 		for _, test := range tests {
-			lex := lexer{0, []rune(test.input), newParseConfig()}
+			lex := newLexer([]byte(test.input), newParseConfig())
 			assert.Equal(t, test.standardOutput, lex.tokens())
 		}
 	})
 
+	t.Run("Token Spans", func(t *testing.T) {
+		for _, test := range tests {
+			lex := newLexer([]byte(test.input), newParseConfig())
+			for _, token := range lex.tokens() {
+				start, end := token.Span()
+				assert.Equal(t, token.Literal, test.input[start:end])
+			}
+		}
+	})
+
 	t.Run("No Spaces Lexer", func(t *testing.T) {
 		for _, test := range tests {
-			lex := lexer{0, []rune(test.input), newParseConfig(IgnoreWhitespaces())}
+			lex := newLexer([]byte(test.input), newParseConfig(IgnoreWhitespaces()))
 			assert.Equal(t, test.noSpaceOutput, lex.tokens())
 		}
 	})
 
 	t.Run("Custom Keyword Lexer", func(t *testing.T) {
 		for _, test := range tests {
-			lex := lexer{0, []rune(test.input), newParseConfig(Keywords(customKeywords))}
+			lex := newLexer([]byte(test.input), newParseConfig(Keywords(customKeywords)))
 			assert.Equal(t, test.customOutput, lex.tokens())
 		}
 	})
 }
+
+func TestLexer_UnicodeClasses(t *testing.T) {
+	const TokenMath TokenKind = -20
+	const TokenCurrency TokenKind = -21
+
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			"a×b≤$5", nil,
+			[]Token{
+				{TokenIdent, "a", 0, 1, nil}, UnicodeToken('×', 1), {TokenIdent, "b", 3, 4, nil},
+				UnicodeToken('≤', 4), UnicodeToken('$', 7), {TokenNumber, "5", 8, 9, nil}, EOFToken(9),
+			},
+		},
+		{
+			"a×b≤$5", []ParserOption{SymbolClass(TokenMath, unicode.Sm), SymbolClass(TokenCurrency, unicode.Sc)},
+			[]Token{
+				{TokenIdent, "a", 0, 1, nil}, {TokenMath, "×", 1, 3, nil}, {TokenIdent, "b", 3, 4, nil},
+				{TokenMath, "≤", 4, 7, nil}, {TokenCurrency, "$", 7, 8, nil}, {TokenNumber, "5", 8, 9, nil}, EOFToken(9),
+			},
+		},
+		{
+			"🚀launch=go🔥", []ParserOption{IdentifierClass(EmojiTable)},
+			[]Token{{TokenIdent, "🚀launch", 0, 10, nil}, {'=', "=", 10, 11, nil}, {TokenIdent, "go🔥", 11, 17, nil}, EOFToken(17)},
+		},
+		{
+			"🚀launch", nil,
+			[]Token{UnicodeToken('🚀', 0), {TokenIdent, "launch", 4, 10, nil}, EOFToken(10)},
+		},
+	}
+
+	for _, test := range tests {
+		lex := newLexer([]byte(test.input), newParseConfig(test.options...))
+		assert.Equal(t, test.output, lex.tokens(), test.input)
+	}
+}
+
+func TestLexer_RunsOf(t *testing.T) {
+	const TokenPhone TokenKind = -20
+	const TokenPunct TokenKind = -21
+
+	phone := func(r rune) bool { return unicode.IsDigit(r) || r == '-' }
+
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			"call 555-123-4567 now", []ParserOption{RunsOf(phone, TokenPhone)},
+			[]Token{
+				{TokenIdent, "call", 0, 4, nil}, UnicodeToken(' ', 4), {TokenPhone, "555-123-4567", 5, 17, nil},
+				UnicodeToken(' ', 17), {TokenIdent, "now", 18, 21, nil}, EOFToken(21),
+			},
+		},
+		{
+			"wait...!? ok", []ParserOption{RunsOf(unicode.IsPunct, TokenPunct), IgnoreWhitespaces()},
+			[]Token{{TokenIdent, "wait", 0, 4, nil}, {TokenPunct, "...!?", 4, 9, nil}, {TokenIdent, "ok", 10, 12, nil}, EOFToken(12)},
+		},
+		{
+			"1-2;-3", []ParserOption{RunsOf(unicode.IsPunct, TokenPunct), RunsOf(phone, TokenPhone)},
+			[]Token{{TokenPhone, "1-2", 0, 3, nil}, {TokenPunct, ";-", 3, 5, nil}, {TokenPhone, "3", 5, 6, nil}, EOFToken(6)},
+		},
+	}
+
+	for _, test := range tests {
+		lex := newLexer([]byte(test.input), newParseConfig(test.options...))
+		assert.Equal(t, test.output, lex.tokens(), test.input)
+	}
+}
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			"[32]uint64", nil,
+			[]Token{{'[', "[", 0, 1, nil}, {TokenNumber, "32", 1, 3, nil}, {']', "]", 3, 4, nil}, {TokenIdent, "uint64", 4, 10, nil}, EOFToken(10)},
+		},
+		{
+			"flag = true", []ParserOption{IgnoreWhitespaces(), Keywords(map[string]TokenKind{"flag": -10})},
+			[]Token{{-10, "flag", 0, 4, nil}, {'=', "=", 5, 6, nil}, {TokenBoolean, "true", 7, 11, nil}, EOFToken(11)},
+		},
+		{
+			"", nil,
+			[]Token{EOFToken(0)},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, Tokenize(test.input, test.options...))
+	}
+}
+
+func TestTokenizeAll(t *testing.T) {
+	inputs := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		inputs = append(inputs, fmt.Sprintf("flag%d = %d", i%3, i))
+	}
+
+	options := []ParserOption{IgnoreWhitespaces(), Keywords(map[string]TokenKind{"flag1": -10})}
+	results := TokenizeAll(inputs, options...)
+
+	assert.Len(t, results, len(inputs))
+	for index, input := range inputs {
+		assert.Equal(t, Tokenize(input, options...), results[index], input)
+	}
+
+	assert.Empty(t, TokenizeAll(nil))
+}
+
+func TestLexer_Incremental(t *testing.T) {
+	lexer := NewLexer("map[string] x", IgnoreWhitespaces())
+
+	assert.False(t, lexer.Done())
+	assert.Equal(t, Token{TokenIdent, "map", 0, 3, nil}, lexer.Peek())
+	assert.Equal(t, Token{TokenIdent, "map", 0, 3, nil}, lexer.Peek())
+	assert.Equal(t, Token{TokenIdent, "map", 0, 3, nil}, lexer.Next())
+	assert.Equal(t, Token{'[', "[", 3, 4, nil}, lexer.Next())
+	assert.Equal(t, Token{TokenIdent, "string", 4, 10, nil}, lexer.Peek())
+	assert.Equal(t, Token{TokenIdent, "string", 4, 10, nil}, lexer.Next())
+	assert.Equal(t, Token{']', "]", 10, 11, nil}, lexer.Next())
+	assert.False(t, lexer.Done())
+	assert.Equal(t, Token{TokenIdent, "x", 12, 13, nil}, lexer.Next())
+
+	assert.True(t, lexer.Done())
+	assert.Equal(t, EOFToken(13), lexer.Peek())
+	assert.Equal(t, EOFToken(13), lexer.Next())
+	assert.Equal(t, EOFToken(13), lexer.Next())
+}
+
+func TestLexer_Malformed(t *testing.T) {
+	tests := []struct {
+		input  string
+		output []Token
+	}{
+		{
+			`x="abc`,
+			[]Token{{TokenIdent, "x", 0, 1, nil}, {'=', "=", 1, 2, nil}, {TokenMalformed, `"abc`, 2, 6, nil}, EOFToken(6)},
+		},
+		{
+			`0x,0xg`,
+			[]Token{{TokenMalformed, "0x", 0, 2, nil}, {',', ",", 2, 3, nil}, {TokenMalformed, "0x", 3, 5, nil}, {TokenIdent, "g", 5, 6, nil}, EOFToken(6)},
+		},
+		{
+			"a\xffb�",
+			[]Token{{TokenIdent, "a", 0, 1, nil}, {TokenMalformed, "\xff", 1, 2, nil}, {TokenIdent, "b", 2, 3, nil}, UnicodeToken('�', 3), EOFToken(6)},
+		},
+		{
+			`- -x->`,
+			[]Token{UnicodeToken('-', 0), UnicodeToken(' ', 1), UnicodeToken('-', 2), {TokenIdent, "x", 3, 4, nil}, UnicodeToken('-', 4), UnicodeToken('>', 5), EOFToken(6)},
+		},
+		{
+			"a ٣ 0۵",
+			[]Token{{TokenIdent, "a", 0, 1, nil}, UnicodeToken(' ', 1), UnicodeToken('٣', 2), UnicodeToken(' ', 4), {TokenNumber, "0", 5, 6, nil}, UnicodeToken('۵', 6), EOFToken(8)},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, Tokenize(test.input), test.input)
+	}
+}
+
+func TestLexer_SignedNumerics(t *testing.T) {
+	tests := []struct {
+		input  string
+		output []Token
+	}{
+		{
+			"-0xFF,+42,+0x1a",
+			[]Token{{TokenHexNumber, "-0xFF", 0, 5, nil}, {',', ",", 5, 6, nil}, {TokenNumber, "+42", 6, 9, nil}, {',', ",", 9, 10, nil}, {TokenHexNumber, "+0x1a", 10, 15, nil}, EOFToken(15)},
+		},
+		{
+			"a+1 -0x",
+			[]Token{{TokenIdent, "a", 0, 1, nil}, {TokenNumber, "+1", 1, 3, nil}, UnicodeToken(' ', 3), {TokenMalformed, "-0x", 4, 7, nil}, EOFToken(7)},
+		},
+		{
+			"+x -01",
+			[]Token{UnicodeToken('+', 0), {TokenIdent, "x", 1, 2, nil}, UnicodeToken(' ', 2), {TokenNumber, "-01", 3, 6, nil}, EOFToken(6)},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, Tokenize(test.input), test.input)
+	}
+}
+
+func TestLexer_DisableHexLiterals(t *testing.T) {
+	tokens := Tokenize("0x18 -0xf", DisableHexLiterals(), IgnoreWhitespaces())
+	assert.Equal(t, []Token{
+		{TokenNumber, "0", 0, 1, nil}, {TokenIdent, "x18", 1, 4, nil},
+		{TokenNumber, "-0", 5, 7, nil}, {TokenIdent, "xf", 7, 9, nil}, EOFToken(9),
+	}, tokens)
+}
+
+func FuzzLexer(f *testing.F) {
+	for _, seed := range []string{
+		`hello123,^`, `"this is the text" -> "hello"`, `person.mark = -923`, `"abcdefg`,
+		`0x`, `0x1F`, `-`, "a\xffb", `5m30s 2022-11-04T10:15:30Z`, `{a: [1, 2], b: f(x)}`, "a ٣ b", "0۵",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		tokens := Tokenize(input, DurationLiterals(), TimestampLiterals())
+
+		// Tokens must cover the input contiguously and end with EoF
+		cursor := 0
+		for idx, token := range tokens {
+			if token.Position != cursor || token.End < token.Position || input[token.Position:token.End] != token.Literal {
+				t.Fatalf("token %v does not continue from %d in %q", token, cursor, input)
+			}
+
+			if (token.Kind == TokenEoF) != (idx == len(tokens)-1) {
+				t.Fatalf("unexpected eof token placement in %q", input)
+			}
+
+			cursor = token.End
+		}
+
+		if cursor != len(input) {
+			t.Fatalf("tokens do not cover the input %q", input)
+		}
+	})
+}
+
+func TestLexer_PeekBounds(t *testing.T) {
+	tests := []struct {
+		input string
+		chars []rune
+		peeks []rune
+	}{
+		{"ab", []rune{'a', 'b'}, []rune{'b', rune(TokenEoF)}},
+		{"aé", []rune{'a', 'é'}, []rune{'é', rune(TokenEoF)}},
+		{"é→", []rune{'é', '→'}, []rune{'→', rune(TokenEoF)}},
+		{"a\xe2\x86", []rune{'a', utf8.RuneError, utf8.RuneError}, []rune{utf8.RuneError, utf8.RuneError, rune(TokenEoF)}},
+		{"", nil, nil},
+	}
+
+	for _, test := range tests {
+		lex := newLexer([]byte(test.input), newParseConfig())
+
+		var chars, peeks []rune
+		for !lex.done() {
+			chars = append(chars, lex.char())
+			peeks = append(peeks, lex.peek())
+			lex.advanceCursor()
+		}
+
+		assert.Equal(t, test.chars, chars, test.input)
+		assert.Equal(t, test.peeks, peeks, test.input)
+		assert.Equal(t, rune(TokenEoF), lex.char())
+		assert.Equal(t, rune(TokenEoF), lex.peek())
+	}
+}
+
+func BenchmarkTokenize(b *testing.B) {
+	inputs := map[string]string{
+		"ascii":   strings.Repeat(`transfer(to: 0xabcdef0123, amount: 1500, memo: "hello world") `, 64),
+		"unicode": strings.Repeat(`überweisung(an: 0xabcdef0123, betrag: 1500, notiz: "grüße → welt") `, 64),
+	}
+
+	for name, input := range inputs {
+		b.Run(name, func(b *testing.B) {
+			b.SetBytes(int64(len(input)))
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				Tokenize(input, IgnoreWhitespaces())
+			}
+		})
+	}
+}
+
+func BenchmarkScanner(b *testing.B) {
+	input := []byte(strings.Repeat(`überweisung(an: 0xabcdef0123, betrag: 1500, notiz: "grüße → welt") `, 64))
+
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		scanner := NewScanner(input)
+		for !scanner.Done() {
+			scanner.Next()
+		}
+	}
+}
@@ -0,0 +1,707 @@
+package symbolizer
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// durationUnits is the set of units accepted by time.ParseDuration.
+// Longer units are listed first so that they are matched before their prefixes.
+var durationUnits = [][]byte{
+	[]byte("ns"), []byte("us"), []byte("µs"), []byte("μs"), []byte("ms"), []byte("s"), []byte("m"), []byte("h"),
+}
+
+// matchDuration returns the length of the duration literal (such as 5m30s or -1.5h) at the start of the given
+// input or 0 if the input does not begin with a valid duration. The duration must not be followed by an
+// identifier character, so that numerics followed by identifiers (such as 5min) are not split apart.
+func matchDuration(input []byte) int {
+	cursor := 0
+	if cursor < len(input) && isSignChar(rune(input[cursor])) {
+		cursor++
+	}
+
+	// Scan each segment of the duration (a decimal followed by a unit)
+	segments := 0
+
+	for cursor < len(input) && (isDecChar(rune(input[cursor])) || input[cursor] == '.') {
+		for cursor < len(input) && (isDecChar(rune(input[cursor])) || input[cursor] == '.') {
+			cursor++
+		}
+
+		// Match the unit of the segment
+		unit := matchDurationUnit(input[cursor:])
+		if unit == 0 {
+			return 0
+		}
+
+		cursor += unit
+		segments++
+	}
+
+	if segments == 0 || (cursor < len(input) && isIdentChar(rune(input[cursor]))) {
+		return 0
+	}
+
+	// Confirm that the literal is a valid duration
+	if _, err := time.ParseDuration(string(input[:cursor])); err != nil {
+		return 0
+	}
+
+	return cursor
+}
+
+// matchDurationUnit returns the length of the duration unit at the start of the input or 0 if there is none
+func matchDurationUnit(input []byte) int {
+	for _, unit := range durationUnits {
+		if bytes.HasPrefix(input, unit) {
+			return len(unit)
+		}
+	}
+
+	return 0
+}
+
+// numericSuffixes are the multiplier suffixes of suffixed numeric literals, with the SI (decimal) prefixes
+// and IEC (binary) prefixes. Binary prefixes are listed first so that they are matched before their SI prefix.
+var numericSuffixes = []struct {
+	suffix     string
+	multiplier uint64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"k", 1e3}, {"K", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+}
+
+// matchDecimal returns the length of the decimal digits at the start of the input,
+// which may be preceded by a sign (if signed is true) and followed by a fractional part.
+// The returned boolean indicates if the decimal has a fractional part.
+func matchDecimal(input []byte, signed bool) (int, bool) {
+	cursor := 0
+	if signed && cursor < len(input) && isSignChar(rune(input[cursor])) {
+		cursor++
+	}
+
+	digits := cursor
+	for cursor < len(input) && isDecChar(rune(input[cursor])) {
+		cursor++
+	}
+
+	if cursor == digits {
+		return 0, false
+	}
+
+	// Match the fractional part, which must have at least one digit
+	if cursor+1 < len(input) && input[cursor] == '.' && isDecChar(rune(input[cursor+1])) {
+		cursor++
+		for cursor < len(input) && isDecChar(rune(input[cursor])) {
+			cursor++
+		}
+
+		return cursor, true
+	}
+
+	return cursor, false
+}
+
+// matchFloat returns the length of the floating point literal (such as 1.5, 2e10 or -1.5E-3) at the start of the
+// given input or 0 if the input does not begin with one. The literal must have a fractional part or an exponent
+// (or both) and must not be followed by an identifier character.
+func matchFloat(input []byte) int {
+	cursor, fractional := matchDecimal(input, true)
+	if cursor == 0 {
+		return 0
+	}
+
+	// Match the exponent, which must have at least one digit
+	exponent := false
+	if cursor < len(input) && (input[cursor] == 'e' || input[cursor] == 'E') {
+		digits := cursor + 1
+		if digits < len(input) && isSignChar(rune(input[digits])) {
+			digits++
+		}
+
+		end := digits
+		for end < len(input) && isDecChar(rune(input[end])) {
+			end++
+		}
+
+		if end > digits {
+			cursor, exponent = end, true
+		}
+	}
+
+	if !(fractional || exponent) || (cursor < len(input) && isIdentChar(rune(input[cursor]))) {
+		return 0
+	}
+
+	return cursor
+}
+
+// matchSuffixed returns the length of the suffixed numeric literal (such as 2k, 1.5M or 3MiB) at the start of the
+// given input or 0 if the input does not begin with one. The suffix is an SI or IEC multiplier prefix that may be
+// followed by 'B' (for bytes) or 'B' by itself, and it must not be followed by an identifier character.
+func matchSuffixed(input []byte) int {
+	cursor, _ := matchDecimal(input, true)
+	if cursor == 0 {
+		return 0
+	}
+
+	suffix := matchNumericSuffix(input[cursor:])
+	if cursor+suffix < len(input) && input[cursor+suffix] == 'B' {
+		suffix++
+	}
+
+	cursor += suffix
+	if suffix == 0 || (cursor < len(input) && isIdentChar(rune(input[cursor]))) {
+		return 0
+	}
+
+	return cursor
+}
+
+// matchNumericSuffix returns the length of the multiplier suffix at the start of the input or 0 if there is none
+func matchNumericSuffix(input []byte) int {
+	for _, suffix := range numericSuffixes {
+		if bytes.HasPrefix(input, []byte(suffix.suffix)) {
+			return len(suffix.suffix)
+		}
+	}
+
+	return 0
+}
+
+// suffixedValue returns the value of a suffixed numeric literal with its multiplier applied.
+// Literals with a fractional part generate a float64, while negative literals generate
+// an int64 and all other literals generate a uint64, if the value is within range.
+func suffixedValue(literal string) (any, error) {
+	decimal, multiplier := splitSuffixed(literal)
+
+	// Fractional Number
+	if strings.Contains(decimal, ".") {
+		number, err := strconv.ParseFloat(decimal, 64)
+		if err != nil {
+			return nil, valueErrorf(numericSentinel(err), "invalid suffixed numeric token: %v", err)
+		}
+
+		return number * float64(multiplier), nil
+	}
+
+	number, err := strconv.ParseUint(strings.TrimLeft(decimal, "+-"), 10, 64)
+	if err != nil {
+		return nil, valueErrorf(numericSentinel(err), "invalid suffixed numeric token: %v", err)
+	}
+
+	high, value := bits.Mul64(number, multiplier)
+
+	// Negative Number
+	if strings.HasPrefix(decimal, "-") {
+		if high != 0 || value > 1<<63 {
+			return nil, valueErrorf(ErrValueOverflow, "invalid suffixed numeric token: value out of range: '%v'", literal)
+		}
+
+		if value == 1<<63 {
+			return int64(math.MinInt64), nil
+		}
+
+		return -int64(value), nil
+	}
+
+	if high != 0 {
+		return nil, valueErrorf(ErrValueOverflow, "invalid suffixed numeric token: value out of range: '%v'", literal)
+	}
+
+	return value, nil
+}
+
+// splitSuffixed splits a suffixed numeric literal into its decimal and the multiplier of its suffix
+func splitSuffixed(literal string) (string, uint64) {
+	split := strings.IndexFunc(literal, func(char rune) bool { return !isDecChar(char) && char != '.' && !isSignChar(char) })
+	if split < 0 {
+		return literal, 1
+	}
+
+	decimal, suffix := literal[:split], strings.TrimSuffix(literal[split:], "B")
+
+	multiplier := uint64(1)
+	for _, entry := range numericSuffixes {
+		if entry.suffix == suffix {
+			multiplier = entry.multiplier
+		}
+	}
+
+	return decimal, multiplier
+}
+
+// decimalDigits formats a rational number with a terminating decimal expansion (such as
+// the value of a decimal literal) as its exact decimal digits, without trailing zeros.
+func decimalDigits(number *big.Rat) string {
+	// Determine the number of fractional digits, the denominator of a
+	// decimal literal only has factors of 2 and 5 so the loop terminates
+	scaled, precision := new(big.Rat).Set(number), 0
+	for !scaled.IsInt() && precision < 1000 {
+		scaled.Mul(scaled, big.NewRat(10, 1))
+		precision++
+	}
+
+	return number.FloatString(precision)
+}
+
+// matchTimestamp returns the length of the RFC3339 timestamp literal (such as 2022-11-04T10:15:30Z) at the
+// start of the given input or 0 if the input does not begin with a valid timestamp. Fractional seconds
+// and numeric zone offsets (such as 2022-11-04T10:15:30.250+05:30) are supported.
+func matchTimestamp(input []byte) int {
+	// Match the fixed layout of the date and time
+	layout := "dddd-dd-ddTdd:dd:dd"
+	if len(input) < len(layout) {
+		return 0
+	}
+
+	for idx := 0; idx < len(layout); idx++ {
+		switch char := input[idx]; layout[idx] {
+		case 'd':
+			if !isDecChar(rune(char)) {
+				return 0
+			}
+		case 'T':
+			if char != 'T' && char != 't' {
+				return 0
+			}
+		default:
+			if char != layout[idx] {
+				return 0
+			}
+		}
+	}
+
+	cursor := len(layout)
+
+	// Match the fractional seconds
+	if cursor < len(input) && input[cursor] == '.' {
+		cursor++
+		for cursor < len(input) && isDecChar(rune(input[cursor])) {
+			cursor++
+		}
+	}
+
+	// Match the zone offset
+	switch {
+	case cursor < len(input) && (input[cursor] == 'Z' || input[cursor] == 'z'):
+		cursor++
+
+	case cursor+6 <= len(input) && (input[cursor] == '+' || input[cursor] == '-') && input[cursor+3] == ':':
+		cursor += 6
+
+	default:
+		return 0
+	}
+
+	// Confirm that the literal is a valid timestamp
+	if _, err := time.Parse(time.RFC3339Nano, string(input[:cursor])); err != nil {
+		return 0
+	}
+
+	return cursor
+}
+
+// scanRawString scans for a raw String lexeme enclosed in backticks. Raw strings may contain
+// quotes and newlines without escaping. If the input is exhausted before the closing backtick,
+// a malformed lexeme spanning the rest of the input is returned.
+func (lexer *lexer) scanRawString() Lexeme {
+	start := lexer.cursor
+
+	// Find the closing backtick
+	end := bytes.IndexByte(lexer.input[start+1:], '`')
+	if end < 0 {
+		lexer.cursor = len(lexer.input)
+		return Lexeme{TokenMalformed, start, lexer.cursor}
+	}
+
+	lexer.cursor = start + 1 + end + 1
+	return Lexeme{TokenString, start, lexer.cursor}
+}
+
+// scanHeredoc scans for a heredoc String lexeme such as `<<END` followed by a newline, the content lines
+// and a line with only the END marker. Returns false if the symbols at the cursor do not begin a heredoc
+// (a marker identifier followed by a newline). If the input is exhausted before the terminating marker
+// line, a malformed lexeme spanning the rest of the input is returned.
+func (lexer *lexer) scanHeredoc() (Lexeme, bool) {
+	start := lexer.cursor
+	if !bytes.HasPrefix(lexer.input[start:], []byte("<<")) {
+		return Lexeme{}, false
+	}
+
+	// Collect the marker identifier which must be followed by a newline
+	cursor := start + 2
+	for cursor < len(lexer.input) && isIdentChar(rune(lexer.input[cursor])) && lexer.input[cursor] < utf8.RuneSelf {
+		cursor++
+	}
+
+	marker := lexer.input[start+2 : cursor]
+	if len(marker) == 0 || cursor >= len(lexer.input) || lexer.input[cursor] != '\n' {
+		return Lexeme{}, false
+	}
+
+	// Find the line that only contains the marker
+	for line := cursor + 1; line <= len(lexer.input); {
+		end := bytes.IndexByte(lexer.input[line:], '\n')
+		if end < 0 {
+			end = len(lexer.input) - line
+		}
+
+		if bytes.Equal(lexer.input[line:line+end], marker) {
+			lexer.cursor = line + end
+			return Lexeme{TokenString, start, lexer.cursor}, true
+		}
+
+		line += end + 1
+	}
+
+	lexer.cursor = len(lexer.input)
+	return Lexeme{TokenMalformed, start, lexer.cursor}, true
+}
+
+// heredocContent returns the content of a heredoc literal, which is
+// the data between the marker line and the terminating marker line.
+func heredocContent(literal string) string {
+	first, last := strings.IndexByte(literal, '\n'), strings.LastIndexByte(literal, '\n')
+	if first == last {
+		return ""
+	}
+
+	return literal[first+1 : last]
+}
+
+// Semver is the value of a semantic version literal (such as v1.2.3-beta.1+build.5)
+type Semver struct {
+	Major, Minor, Patch uint64
+	// Prerelease is the pre-release label after the '-' (such as 'beta.1'), if any
+	Prerelease string
+	// Build is the build metadata label after the '+' (such as 'build.5'), if any
+	Build string
+}
+
+// String returns the Semver formatted as a semantic version without a 'v' prefix
+func (version Semver) String() string {
+	formatted := fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+	if version.Prerelease != "" {
+		formatted += "-" + version.Prerelease
+	}
+
+	if version.Build != "" {
+		formatted += "+" + version.Build
+	}
+
+	return formatted
+}
+
+// matchSemver returns the length of the semantic version literal (such as 1.2.3, 1.2.3-beta.1 or 1.2.3+build.5)
+// at the start of the given input or 0 if the input does not begin with one. The major, minor and patch versions
+// must not have leading zeros and the literal must not be followed by an identifier character or a '.'.
+func matchSemver(input []byte) int {
+	cursor := 0
+
+	// Match the major, minor and patch versions separated by '.'
+	for component := 0; component < 3; component++ {
+		if component > 0 {
+			if cursor >= len(input) || input[cursor] != '.' {
+				return 0
+			}
+
+			cursor++
+		}
+
+		digits := cursor
+		for cursor < len(input) && isDecChar(rune(input[cursor])) {
+			cursor++
+		}
+
+		if cursor == digits || (cursor-digits > 1 && input[digits] == '0') {
+			return 0
+		}
+	}
+
+	// Match the pre-release and build labels
+	for _, marker := range []byte{'-', '+'} {
+		if cursor < len(input) && input[cursor] == marker {
+			length := matchSemverLabel(input[cursor+1:])
+			if length == 0 {
+				return 0
+			}
+
+			cursor += 1 + length
+		}
+	}
+
+	if cursor < len(input) && (isIdentChar(rune(input[cursor])) || input[cursor] == '.') {
+		return 0
+	}
+
+	return cursor
+}
+
+// matchSemverLabel returns the length of the dot separated label of ASCII alphanumerics and hyphens
+// at the start of the input or 0 if there is none. Each identifier of the label must not be empty.
+func matchSemverLabel(input []byte) int {
+	cursor := 0
+
+	for {
+		identifier := cursor
+		for cursor < len(input) && isSemverChar(input[cursor]) {
+			cursor++
+		}
+
+		if cursor == identifier {
+			return 0
+		}
+
+		if cursor+1 >= len(input) || input[cursor] != '.' || !isSemverChar(input[cursor+1]) {
+			return cursor
+		}
+
+		cursor++
+	}
+}
+
+// isSemverChar returns true if ch can be part of a semantic version label
+func isSemverChar(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || isDecChar(rune(ch)) || ch == '-'
+}
+
+// semverValue returns the Semver value of a semantic version literal with an optional 'v' prefix
+func semverValue(literal string) (any, error) {
+	var version Semver
+
+	core := strings.TrimPrefix(literal, "v")
+	if split := strings.IndexByte(core, '+'); split >= 0 {
+		core, version.Build = core[:split], core[split+1:]
+	}
+
+	if split := strings.IndexByte(core, '-'); split >= 0 {
+		core, version.Prerelease = core[:split], core[split+1:]
+	}
+
+	components := strings.Split(core, ".")
+	if len(components) != 3 {
+		return nil, valueErrorf(ErrInvalidValue, "invalid semver token: '%v'", literal)
+	}
+
+	for idx, target := range []*uint64{&version.Major, &version.Minor, &version.Patch} {
+		number, err := strconv.ParseUint(components[idx], 10, 64)
+		if err != nil {
+			return nil, valueErrorf(numericSentinel(err), "invalid semver token: %v", err)
+		}
+
+		*target = number
+	}
+
+	return version, nil
+}
+
+// Amount is the value of a currency amount literal (such as $1,299.99 or 42 USD)
+type Amount struct {
+	// Currency is the currency sigil or code of the amount (such as '$' or 'USD')
+	Currency string
+	// Value is the decimal value of the amount. It is a *big.Rat unless constructed with the DecimalConstructor
+	// of the Decimals option, which only applies to the value APIs of the Parser (and not to Token.Value).
+	Value any
+}
+
+// matchAmountDecimal returns the length of the decimal at the start of the input which may be preceded by a sign
+// (if signed is true), have ',' thousands separators between groups of three digits and a fractional part.
+func matchAmountDecimal(input []byte, signed bool) int {
+	cursor := 0
+	if signed && cursor < len(input) && isSignChar(rune(input[cursor])) {
+		cursor++
+	}
+
+	digits := cursor
+	for cursor < len(input) && isDecChar(rune(input[cursor])) {
+		cursor++
+	}
+
+	if cursor == digits {
+		return 0
+	}
+
+	// Match the thousands groups, only if the leading group has at most three digits
+	for grouped := cursor-digits <= 3; grouped && cursor < len(input) && input[cursor] == ','; {
+		group := cursor + 1
+		end := group
+		for end < len(input) && isDecChar(rune(input[end])) {
+			end++
+		}
+
+		if end-group != 3 {
+			break
+		}
+
+		cursor = end
+	}
+
+	// Match the fractional part, which must have at least one digit
+	if cursor+1 < len(input) && input[cursor] == '.' && isDecChar(rune(input[cursor+1])) {
+		cursor++
+		for cursor < len(input) && isDecChar(rune(input[cursor])) {
+			cursor++
+		}
+	}
+
+	return cursor
+}
+
+// matchCodedAmount returns the length of the amount literal followed by whitespace and one of the given currency
+// codes (such as 42 USD or -1,299.99 EUR) at the start of the input or 0 if the input does not begin with one.
+// The currency code must not be followed by an identifier character.
+func matchCodedAmount(input []byte, codes []string) int {
+	cursor := matchAmountDecimal(input, true)
+	if cursor == 0 || len(codes) == 0 {
+		return 0
+	}
+
+	// The decimal must be followed by whitespace
+	spaces := cursor
+	for cursor < len(input) && (input[cursor] == ' ' || input[cursor] == '\t') {
+		cursor++
+	}
+
+	if cursor == spaces {
+		return 0
+	}
+
+	for _, code := range codes {
+		end := cursor + len(code)
+		if bytes.HasPrefix(input[cursor:], []byte(code)) && (end >= len(input) || !isIdentChar(rune(input[end]))) {
+			return end
+		}
+	}
+
+	return 0
+}
+
+// scanAmount scans for an Amount lexeme that begins with the currency sigil under the cursor (such as $1,299.99).
+// Returns false if the sigil is not immediately followed by a decimal.
+func (lexer *lexer) scanAmount() (Lexeme, bool) {
+	start := lexer.cursor
+	_, width := lexer.decode(start)
+
+	length := matchAmountDecimal(lexer.input[start+width:], false)
+	if length == 0 || (start+width+length < len(lexer.input) && isIdentChar(rune(lexer.input[start+width+length]))) {
+		return Lexeme{}, false
+	}
+
+	lexer.cursor = start + width + length
+	return Lexeme{TokenAmount, start, lexer.cursor}, true
+}
+
+// amountValue returns the Amount value of a currency amount literal. The decimal is constructed with the
+// given DecimalConstructor from its digits (without thousands separators) or as a *big.Rat if it is nil.
+func amountValue(literal string, constructor DecimalConstructor) (any, error) {
+	var currency, digits string
+
+	// Split the literal into the currency (either a leading sigil or a trailing code) and its digits
+	if sigil, width := utf8.DecodeRuneInString(literal); !isDecChar(sigil) && !isSignChar(sigil) {
+		currency, digits = literal[:width], literal[width:]
+	} else {
+		fields := strings.Fields(literal)
+		if len(fields) != 2 {
+			return nil, valueErrorf(ErrInvalidValue, "invalid amount token: '%v'", literal)
+		}
+
+		digits, currency = fields[0], fields[1]
+	}
+
+	digits = strings.ReplaceAll(digits, ",", "")
+
+	if constructor != nil {
+		value, err := constructor(digits)
+		if err != nil {
+			return nil, valueErrorf(ErrInvalidValue, "invalid amount token: %v", err)
+		}
+
+		return Amount{currency, value}, nil
+	}
+
+	value, ok := new(big.Rat).SetString(digits)
+	if !ok {
+		return nil, valueErrorf(ErrInvalidValue, "invalid amount token: '%v'", literal)
+	}
+
+	return Amount{currency, value}, nil
+}
+
+// scanRegex scans for a Regex lexeme that begins with the '/' under the cursor (such as /^v[0-9]+$/i), with
+// any ASCII letters immediately after the closing '/' as its flags. Returns false if the pattern is empty
+// or if it is not closed by an unescaped '/' on the same line.
+func (lexer *lexer) scanRegex() (Lexeme, bool) {
+	start := lexer.cursor
+
+	cursor := start + 1
+	for cursor < len(lexer.input) && lexer.input[cursor] != '/' {
+		switch lexer.input[cursor] {
+		case '\n':
+			return Lexeme{}, false
+		case '\\':
+			cursor++
+		}
+
+		cursor++
+	}
+
+	if cursor >= len(lexer.input) || cursor == start+1 {
+		return Lexeme{}, false
+	}
+
+	// Move past the closing '/' and the flags
+	for cursor++; cursor < len(lexer.input) && isASCIILetter(lexer.input[cursor]); cursor++ {
+	}
+
+	lexer.cursor = cursor
+	return Lexeme{TokenRegex, start, lexer.cursor}, true
+}
+
+// isASCIILetter returns whether the byte is an ASCII letter
+func isASCIILetter(char byte) bool {
+	return 'a' <= char && char <= 'z' || 'A' <= char && char <= 'Z'
+}
+
+// regexPattern returns the raw pattern of a regular expression literal, with
+// the escaped '\/' unescaped and its flags applied inline (/a+/i -> '(?i)a+')
+func regexPattern(literal string) (string, error) {
+	end := strings.LastIndexByte(literal, '/')
+	if !strings.HasPrefix(literal, "/") || end < 1 {
+		return "", valueErrorf(ErrInvalidValue, "invalid regex token: '%v'", literal)
+	}
+
+	pattern, flags := strings.ReplaceAll(literal[1:end], `\/`, "/"), literal[end+1:]
+	if strings.Trim(flags, "imsU") != "" {
+		return "", valueErrorf(ErrInvalidValue, "invalid regex token: unknown flags '%v'", flags)
+	}
+
+	if flags != "" {
+		pattern = "(?" + flags + ")" + pattern
+	}
+
+	return pattern, nil
+}
+
+// regexValue compiles a regular expression literal into a *regexp.Regexp
+func regexValue(literal string) (any, error) {
+	pattern, err := regexPattern(literal)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, valueErrorf(ErrInvalidValue, "invalid regex token: %v", err)
+	}
+
+	return compiled, nil
+}
@@ -0,0 +1,448 @@
+package symbolizer
+
+import (
+	"math"
+	"math/big"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLexer_DurationAndTimestampLiterals(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			"timeout=5m30s", []ParserOption{DurationLiterals()},
+			[]Token{{TokenIdent, "timeout", 0, 7, nil}, {'=', "=", 7, 8, nil}, {TokenDuration, "5m30s", 8, 13, nil}, EOFToken(13)},
+		},
+		{
+			"100ms,-1.5h,2µs", []ParserOption{DurationLiterals()},
+			[]Token{{TokenDuration, "100ms", 0, 5, nil}, {',', ",", 5, 6, nil}, {TokenDuration, "-1.5h", 6, 11, nil}, {',', ",", 11, 12, nil}, {TokenDuration, "2µs", 12, 16, nil}, EOFToken(16)},
+		},
+		{
+			"5min 10", []ParserOption{DurationLiterals(), IgnoreWhitespaces()},
+			[]Token{{TokenNumber, "5", 0, 1, nil}, {TokenIdent, "min", 1, 4, nil}, {TokenNumber, "10", 5, 7, nil}, EOFToken(7)},
+		},
+		{
+			"5m30s", nil,
+			[]Token{{TokenNumber, "5", 0, 1, nil}, {TokenIdent, "m30s", 1, 5, nil}, EOFToken(5)},
+		},
+		{
+			"at 2022-11-04T10:15:30Z", []ParserOption{TimestampLiterals(), IgnoreWhitespaces()},
+			[]Token{{TokenIdent, "at", 0, 2, nil}, {TokenTimestamp, "2022-11-04T10:15:30Z", 3, 23, nil}, EOFToken(23)},
+		},
+		{
+			"[2022-11-04T10:15:30.250+05:30]", []ParserOption{TimestampLiterals(), DurationLiterals()},
+			[]Token{{'[', "[", 0, 1, nil}, {TokenTimestamp, "2022-11-04T10:15:30.250+05:30", 1, 30, nil}, {']', "]", 30, 31, nil}, EOFToken(31)},
+		},
+		{
+			"2022-13-04T10:15:30Z", []ParserOption{TimestampLiterals()},
+			[]Token{
+				{TokenNumber, "2022", 0, 4, nil}, {TokenNumber, "-13", 4, 7, nil}, {TokenNumber, "-04", 7, 10, nil}, {TokenIdent, "T10", 10, 13, nil},
+				{':', ":", 13, 14, nil}, {TokenNumber, "15", 14, 16, nil}, {':', ":", 16, 17, nil}, {TokenNumber, "30", 17, 19, nil}, {TokenIdent, "Z", 19, 20, nil}, EOFToken(20),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		lex := newLexer([]byte(test.input), newParseConfig(test.options...))
+		assert.Equal(t, test.output, lex.tokens(), test.input)
+	}
+}
+
+func TestToken_DurationAndTimestampValues(t *testing.T) {
+	tests := []struct {
+		token Token
+		value any
+		err   string
+	}{
+		{Token{Kind: TokenDuration, Literal: "5m30s"}, 5*time.Minute + 30*time.Second, ""},
+		{Token{Kind: TokenDuration, Literal: "-1.5h"}, -90 * time.Minute, ""},
+		{Token{Kind: TokenDuration, Literal: "5x"}, nil, "invalid duration token: time: unknown unit \"x\" in duration \"5x\""},
+		{Token{Kind: TokenTimestamp, Literal: "2022-11-04T10:15:30Z"}, time.Date(2022, 11, 4, 10, 15, 30, 0, time.UTC), ""},
+		{Token{Kind: TokenTimestamp, Literal: "yesterday"}, nil, "invalid timestamp token: parsing time \"yesterday\" as \"2006-01-02T15:04:05.999999999Z07:00\": cannot parse \"yesterday\" as \"2006\""},
+	}
+
+	for _, test := range tests {
+		value, err := test.token.Value()
+
+		if test.err == "" {
+			assert.NoError(t, err)
+			assert.Equal(t, test.value, value)
+		} else {
+			assert.Nil(t, value)
+			assert.EqualError(t, err, test.err)
+		}
+	}
+}
+
+func TestLexer_Base64Literals(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			`data=b64"aGVsbG8="`, []ParserOption{Base64Literals("b64")},
+			[]Token{{TokenIdent, "data", 0, 4, nil}, {'=', "=", 4, 5, nil}, {TokenBase64, `b64"aGVsbG8="`, 5, 18, nil}, EOFToken(18)},
+		},
+		{
+			`b64 "x", b64x"y", raw"z"`, []ParserOption{Base64Literals("raw"), IgnoreWhitespaces()},
+			[]Token{{TokenIdent, "b64", 0, 3, nil}, {TokenString, `"x"`, 4, 7, nil}, {',', ",", 7, 8, nil}, {TokenIdent, "b64x", 9, 13, nil}, {TokenString, `"y"`, 13, 16, nil}, {',', ",", 16, 17, nil}, {TokenBase64, `raw"z"`, 18, 24, nil}, EOFToken(24)},
+		},
+		{
+			`b64"aGVs`, []ParserOption{Base64Literals("b64")},
+			[]Token{{TokenMalformed, `b64"aGVs`, 0, 8, nil}, EOFToken(8)},
+		},
+		{
+			`b64"aGVsbG8="`, nil,
+			[]Token{{TokenIdent, "b64", 0, 3, nil}, {TokenString, `"aGVsbG8="`, 3, 13, nil}, EOFToken(13)},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, Tokenize(test.input, test.options...), test.input)
+	}
+}
+
+func TestToken_Base64Value(t *testing.T) {
+	tests := []struct {
+		token Token
+		value any
+		err   string
+	}{
+		{Token{Kind: TokenBase64, Literal: `b64"aGVsbG8="`}, []byte("hello"), ""},
+		{Token{Kind: TokenBase64, Literal: `b64"aGVsbG8"`}, []byte("hello"), ""},
+		{Token{Kind: TokenBase64, Literal: `b64"-_8="`}, []byte{0xfb, 0xff}, ""},
+		{Token{Kind: TokenBase64, Literal: `b64""`}, []byte{}, ""},
+		{Token{Kind: TokenBase64, Literal: `b64"a$=="`}, nil, "invalid base64 token: illegal base64 data at input byte 1"},
+		{Token{Kind: TokenBase64, Literal: "abc"}, nil, "invalid base64 token: missing quoted data: 'abc'"},
+	}
+
+	for _, test := range tests {
+		value, err := test.token.Value()
+
+		if test.err == "" {
+			assert.NoError(t, err)
+			assert.Equal(t, test.value, value)
+		} else {
+			assert.Nil(t, value)
+			assert.EqualError(t, err, test.err)
+		}
+	}
+}
+
+func TestLexer_RawStringsAndHeredocs(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+		values  []string
+	}{
+		{
+			"x=`say \"hi\"\nbye`", []ParserOption{RawStrings()},
+			[]Token{{TokenIdent, "x", 0, 1, nil}, {'=', "=", 1, 2, nil}, {TokenString, "`say \"hi\"\nbye`", 2, 16, nil}, EOFToken(16)},
+			[]string{"say \"hi\"\nbye"},
+		},
+		{
+			"`open", []ParserOption{RawStrings()},
+			[]Token{{TokenMalformed, "`open", 0, 5, nil}, EOFToken(5)},
+			nil,
+		},
+		{
+			"doc=<<END\nline \"one\"\nEND\nline two\nEND\n;", []ParserOption{Heredocs()},
+			[]Token{{TokenIdent, "doc", 0, 3, nil}, {'=', "=", 3, 4, nil}, {TokenString, "<<END\nline \"one\"\nEND", 4, 24, nil}, UnicodeToken('\n', 24), {TokenIdent, "line", 25, 29, nil}, UnicodeToken(' ', 29), {TokenIdent, "two", 30, 33, nil}, UnicodeToken('\n', 33), {TokenIdent, "END", 34, 37, nil}, UnicodeToken('\n', 37), {';', ";", 38, 39, nil}, EOFToken(39)},
+			[]string{"line \"one\""},
+		},
+		{
+			"<<EOF\nEOF", []ParserOption{Heredocs()},
+			[]Token{{TokenString, "<<EOF\nEOF", 0, 9, nil}, EOFToken(9)},
+			[]string{""},
+		},
+		{
+			"a<<b", []ParserOption{Heredocs()},
+			[]Token{{TokenIdent, "a", 0, 1, nil}, UnicodeToken('<', 1), UnicodeToken('<', 2), {TokenIdent, "b", 3, 4, nil}, EOFToken(4)},
+			nil,
+		},
+		{
+			"<<END\ndata", []ParserOption{Heredocs()},
+			[]Token{{TokenMalformed, "<<END\ndata", 0, 10, nil}, EOFToken(10)},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		tokens := Tokenize(test.input, test.options...)
+		assert.Equal(t, test.output, tokens, test.input)
+
+		var values []string
+		for _, token := range tokens {
+			if token.Kind == TokenString {
+				value, err := token.Value()
+				assert.NoError(t, err)
+				values = append(values, value.(string))
+			}
+		}
+
+		assert.Equal(t, test.values, values)
+	}
+}
+
+func TestLexer_ScientificAndSuffixedNumbers(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+		values  []any
+	}{
+		{
+			"1.5e10 -2E-3 +0.25 7", []ParserOption{ScientificNumbers(), IgnoreWhitespaces()},
+			[]Token{{TokenFloat, "1.5e10", 0, 6, nil}, {TokenFloat, "-2E-3", 7, 12, nil}, {TokenFloat, "+0.25", 13, 18, nil}, {TokenNumber, "7", 19, 20, nil}, EOFToken(20)},
+			[]any{1.5e10, -0.002, 0.25, uint64(7)},
+		},
+		{
+			"1.x 2e 3ex", []ParserOption{ScientificNumbers(), IgnoreWhitespaces()},
+			[]Token{{TokenNumber, "1", 0, 1, nil}, {'.', ".", 1, 2, nil}, {TokenIdent, "x", 2, 3, nil}, {TokenNumber, "2", 4, 5, nil}, {TokenIdent, "e", 5, 6, nil}, {TokenNumber, "3", 7, 8, nil}, {TokenIdent, "ex", 8, 10, nil}, EOFToken(10)},
+			[]any{uint64(1), uint64(2), uint64(3)},
+		},
+		{
+			"2k 3MiB 1.5M -4Ki 10B 5", []ParserOption{SuffixedNumbers(), IgnoreWhitespaces()},
+			[]Token{{TokenSuffixed, "2k", 0, 2, nil}, {TokenSuffixed, "3MiB", 3, 7, nil}, {TokenSuffixed, "1.5M", 8, 12, nil}, {TokenSuffixed, "-4Ki", 13, 17, nil}, {TokenSuffixed, "10B", 18, 21, nil}, {TokenNumber, "5", 22, 23, nil}, EOFToken(23)},
+			[]any{uint64(2000), uint64(3 << 20), 1.5e6, int64(-4096), uint64(10), uint64(5)},
+		},
+		{
+			"2km 2E3 2E", []ParserOption{SuffixedNumbers(), ScientificNumbers(), IgnoreWhitespaces()},
+			[]Token{{TokenNumber, "2", 0, 1, nil}, {TokenIdent, "km", 1, 3, nil}, {TokenFloat, "2E3", 4, 7, nil}, {TokenSuffixed, "2E", 8, 10, nil}, EOFToken(10)},
+			[]any{uint64(2), 2e3, uint64(2e18)},
+		},
+		{
+			"2k", nil,
+			[]Token{{TokenNumber, "2", 0, 1, nil}, {TokenIdent, "k", 1, 2, nil}, EOFToken(2)},
+			[]any{uint64(2)},
+		},
+	}
+
+	for _, test := range tests {
+		tokens := Tokenize(test.input, test.options...)
+		assert.Equal(t, test.output, tokens, test.input)
+
+		var values []any
+		for _, token := range tokens {
+			if token.Kind.CanValue() {
+				value, err := token.Value()
+				assert.NoError(t, err, token.Literal)
+				values = append(values, value)
+			}
+		}
+
+		assert.Equal(t, test.values, values, test.input)
+	}
+
+	// Suffixed numerics must be range checked
+	_, err := Token{TokenSuffixed, "20E", 0, 3, nil}.Value()
+	assert.EqualError(t, err, "invalid suffixed numeric token: value out of range: '20E'")
+
+	value, err := Token{TokenSuffixed, "-8Ei", 0, 4, nil}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(math.MinInt64), value)
+}
+
+func TestLexer_SemverLiterals(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			"pkg@v1.2.3-beta.1", []ParserOption{SemverLiterals()},
+			[]Token{{TokenIdent, "pkg", 0, 3, nil}, {'@', "@", 3, 4, nil}, {TokenSemver, "v1.2.3-beta.1", 4, 17, nil}, EOFToken(17)},
+		},
+		{
+			"1.2.3+build.5 >=2.0.0", []ParserOption{SemverLiterals(), ScientificNumbers(), IgnoreWhitespaces()},
+			[]Token{{TokenSemver, "1.2.3+build.5", 0, 13, nil}, {'>', ">", 14, 15, nil}, {'=', "=", 15, 16, nil}, {TokenSemver, "2.0.0", 16, 21, nil}, EOFToken(21)},
+		},
+		{
+			"1.2 01.2.3 version", []ParserOption{SemverLiterals(), IgnoreWhitespaces()},
+			[]Token{
+				{TokenNumber, "1", 0, 1, nil}, {'.', ".", 1, 2, nil}, {TokenNumber, "2", 2, 3, nil}, {TokenNumber, "01", 4, 6, nil}, {'.', ".", 6, 7, nil},
+				{TokenNumber, "2", 7, 8, nil}, {'.', ".", 8, 9, nil}, {TokenNumber, "3", 9, 10, nil}, {TokenIdent, "version", 11, 18, nil}, EOFToken(18),
+			},
+		},
+		{
+			"v1.2.3", nil,
+			[]Token{{TokenIdent, "v1", 0, 2, nil}, {'.', ".", 2, 3, nil}, {TokenNumber, "2", 3, 4, nil}, {'.', ".", 4, 5, nil}, {TokenNumber, "3", 5, 6, nil}, EOFToken(6)},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, Tokenize(test.input, test.options...), test.input)
+	}
+}
+
+func TestToken_SemverValue(t *testing.T) {
+	value, err := Token{Kind: TokenSemver, Literal: "v1.2.3-beta.1+build.5"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, Semver{Major: 1, Minor: 2, Patch: 3, Prerelease: "beta.1", Build: "build.5"}, value)
+	assert.Equal(t, "1.2.3-beta.1+build.5", value.(Semver).String())
+
+	value, err = Token{Kind: TokenSemver, Literal: "10.0.1"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, Semver{Major: 10, Patch: 1}, value)
+
+	_, err = Token{Kind: TokenSemver, Literal: "1.2"}.Value()
+	assert.EqualError(t, err, "invalid semver token: '1.2'")
+}
+
+func TestLexer_AmountLiterals(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			"price=$1,299.99", []ParserOption{AmountLiterals()},
+			[]Token{{TokenIdent, "price", 0, 5, nil}, {'=', "=", 5, 6, nil}, {TokenAmount, "$1,299.99", 6, 15, nil}, EOFToken(15)},
+		},
+		{
+			"42 USD, -1,000,000 EUR, 7 GBP", []ParserOption{AmountLiterals("USD", "EUR")},
+			[]Token{
+				{TokenAmount, "42 USD", 0, 6, nil}, {',', ",", 6, 7, nil}, {' ', " ", 7, 8, nil}, {TokenAmount, "-1,000,000 EUR", 8, 22, nil}, {',', ",", 22, 23, nil},
+				{' ', " ", 23, 24, nil}, {TokenNumber, "7", 24, 25, nil}, {' ', " ", 25, 26, nil}, {TokenIdent, "GBP", 26, 29, nil}, EOFToken(29),
+			},
+		},
+		{
+			"€5,12 $x", []ParserOption{AmountLiterals(), IgnoreWhitespaces()},
+			[]Token{{TokenAmount, "€5", 0, 4, nil}, {',', ",", 4, 5, nil}, {TokenNumber, "12", 5, 7, nil}, {'$', "$", 8, 9, nil}, {TokenIdent, "x", 9, 10, nil}, EOFToken(10)},
+		},
+		{
+			"$5", nil,
+			[]Token{{'$', "$", 0, 1, nil}, {TokenNumber, "5", 1, 2, nil}, EOFToken(2)},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, Tokenize(test.input, test.options...), test.input)
+	}
+}
+
+func TestToken_AmountValue(t *testing.T) {
+	value, err := Token{Kind: TokenAmount, Literal: "$1,299.99"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, Amount{"$", big.NewRat(129999, 100)}, value)
+
+	value, err = Token{Kind: TokenAmount, Literal: "-42 USD"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, Amount{"USD", big.NewRat(-42, 1)}, value)
+
+	// Decimals are constructed with the DecimalConstructor when parsing values
+	parser := NewParser(`{price: $1,299.99}`, IgnoreWhitespaces(), AmountLiterals(), Decimals(func(digits string) (any, error) {
+		return "decimal:" + digits, nil
+	}))
+
+	group, err := parser.KeyedGroup(EnclosureCurly(), ':', ',')
+	assert.NoError(t, err)
+	assert.Equal(t, map[any]any{"price": Amount{"$", "decimal:1299.99"}}, group)
+}
+
+func TestLexer_NullLiterals(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			"null nil none", []ParserOption{NullLiterals(), IgnoreWhitespaces()},
+			[]Token{{TokenNull, "null", 0, 4, nil}, {TokenNull, "nil", 5, 8, nil}, {TokenNull, "none", 9, 13, nil}, EOFToken(13)},
+		},
+		{
+			"NULL nil", []ParserOption{NullLiterals("null"), CaseInsensitiveKeywords(), IgnoreWhitespaces()},
+			[]Token{{TokenNull, "NULL", 0, 4, nil}, {TokenIdent, "nil", 5, 8, nil}, EOFToken(8)},
+		},
+		{
+			"null", nil,
+			[]Token{{TokenIdent, "null", 0, 4, nil}, EOFToken(4)},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, Tokenize(test.input, test.options...), test.input)
+	}
+
+	// Null values are distinct from absent values and zero values
+	group, err := NewParser(`{a: null, b:, c: 0}`, IgnoreWhitespaces(), NullLiterals()).KeyedGroup(EnclosureCurly(), ':', ',')
+	assert.NoError(t, err)
+	assert.Equal(t, map[any]any{"a": Null{}, "b": nil, "c": uint64(0)}, group)
+	assert.Equal(t, "null", Null{}.String())
+}
+
+func TestLexer_RegexLiterals(t *testing.T) {
+	tests := []struct {
+		input  string
+		tokens []Token
+	}{
+		{
+			`match(/^v[0-9]+$/i)`,
+			[]Token{
+				{TokenIdent, "match", 0, 5, nil},
+				{TokenKind('('), "(", 5, 6, nil},
+				{TokenRegex, "/^v[0-9]+$/i", 6, 18, nil},
+				{TokenKind(')'), ")", 18, 19, nil},
+			},
+		},
+		{
+			`/a\/b/ c`,
+			[]Token{
+				{TokenRegex, `/a\/b/`, 0, 6, nil},
+				{TokenKind(' '), " ", 6, 7, nil},
+				{TokenIdent, "c", 7, 8, nil},
+			},
+		},
+		{
+			"a / b",
+			[]Token{
+				{TokenIdent, "a", 0, 1, nil},
+				{TokenKind(' '), " ", 1, 2, nil},
+				{TokenKind('/'), "/", 2, 3, nil},
+				{TokenKind(' '), " ", 3, 4, nil},
+				{TokenIdent, "b", 4, 5, nil},
+			},
+		},
+		{
+			"//",
+			[]Token{
+				{TokenKind('/'), "/", 0, 1, nil},
+				{TokenKind('/'), "/", 1, 2, nil},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tokens := Tokenize(test.input, RegexLiterals())
+		assert.Equal(t, test.tokens, tokens[:len(tokens)-1], test.input)
+	}
+}
+
+func TestRegexValue(t *testing.T) {
+	value, err := Token{Kind: TokenRegex, Literal: `/^a\/b+$/i`}.Value()
+	require.NoError(t, err)
+	require.IsType(t, &regexp.Regexp{}, value)
+	assert.Equal(t, "(?i)^a/b+$", value.(*regexp.Regexp).String())
+	assert.True(t, value.(*regexp.Regexp).MatchString("A/BBB"))
+
+	_, err = Token{Kind: TokenRegex, Literal: `/a/g`}.Value()
+	assert.EqualError(t, err, "invalid regex token: unknown flags 'g'")
+
+	_, err = Token{Kind: TokenRegex, Literal: `/a(/`}.Value()
+	assert.EqualError(t, err, "invalid regex token: error parsing regexp: missing closing ): `a(`")
+
+	// Raw patterns are returned while parsing values with RawRegex
+	group, err := NewParser(`{a: /x+/s}`, IgnoreWhitespaces(), RegexLiterals(), RawRegex()).KeyedGroup(EnclosureCurly(), ':', ',')
+	require.NoError(t, err)
+	assert.Equal(t, map[any]any{"a": "(?s)x+"}, group)
+}
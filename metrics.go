@@ -0,0 +1,69 @@
+package symbolizer
+
+import "time"
+
+// Metrics is an interface for instrumenting parsing workloads, such as to export Prometheus metrics from a
+// service that embeds the parser. It is configured with the WithMetrics option and observes the input of each
+// Parser, Lexer and call to Tokenize (but not the lexemes of a Scanner). Metrics that are shared by Parsers
+// across goroutines (such as with TokenizeAll or a compiled Config) must be safe for concurrent use.
+//
+// Tokens that are rescanned from the same input (by sub-parsers for nested content, clones of a
+// Parser and Parser.RemainingTokens) are not observed again, so that they are not counted twice.
+// Likewise, when the Token after the cursor of a Parser is rescanned because its settings changed
+// (such as with SetIgnoreWhitespaces or PushKeywords), Tokens that begin within the input that
+// has already been observed are not observed again.
+type Metrics interface {
+	// ObserveToken is called for each Token emitted, after any token filters and
+	// middleware have been applied to it. It is not called for the EoF Token.
+	ObserveToken(token Token)
+	// ObserveScan is called once the end of the input is reached (or the lexer is terminated, such as
+	// by a limit) with the number of bytes consumed and the duration since the first Token was scanned.
+	// For a Parser, the duration includes the time spent by the caller between advancing the parser.
+	ObserveScan(bytes int, duration time.Duration)
+	// ObserveError is called for each Error recorded by a Parser and
+	// for the Error that terminates the lexer (such as an exceeded limit).
+	ObserveError(err *Error)
+}
+
+// WithMetrics returns a ParserOption that instruments the parser with the given Metrics
+func WithMetrics(metrics Metrics) ParserOption {
+	return func(config *parseConfig) {
+		config.metrics = metrics
+	}
+}
+
+// observe reports the Token emitted by the lexer to its Metrics, unless it begins before the end of the last
+// reported Token (when it is rescanned). If the Token is the first EoF Token, the scan and the terminal error
+// of the lexer (if any) are reported.
+func (lexer *lexer) observe(token Token) Token {
+	if token.Kind != TokenEoF {
+		if token.Position >= lexer.observedEnd {
+			lexer.observedEnd = token.End
+			lexer.metrics.ObserveToken(token)
+		}
+
+		return token
+	}
+
+	if lexer.observed {
+		return token
+	}
+
+	lexer.observed = true
+	lexer.metrics.ObserveScan(lexer.cursor, time.Since(lexer.started))
+
+	if lexer.err != nil {
+		lexer.metrics.ObserveError(lexer.err)
+	}
+
+	return token
+}
+
+// record appends the Error to the list of errors accumulated by the parser and reports it to the Metrics, if any
+func (parser *Parser) record(err *Error) {
+	*parser.errors = append(*parser.errors, err)
+
+	if metrics := parser.scanner.config.metrics; metrics != nil {
+		metrics.ObserveError(err)
+	}
+}
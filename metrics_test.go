@@ -0,0 +1,105 @@
+package symbolizer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testMetrics is a Metrics that records its observations
+type testMetrics struct {
+	tokens []string
+	scans  []int
+	errors []*Error
+}
+
+func (metrics *testMetrics) ObserveToken(token Token) {
+	metrics.tokens = append(metrics.tokens, token.Literal)
+}
+
+func (metrics *testMetrics) ObserveScan(bytes int, duration time.Duration) {
+	metrics.scans = append(metrics.scans, bytes)
+}
+
+func (metrics *testMetrics) ObserveError(err *Error) {
+	metrics.errors = append(metrics.errors, err)
+}
+
+func TestWithMetrics(t *testing.T) {
+	metrics := new(testMetrics)
+	Tokenize("a, b", IgnoreWhitespaces(), WithMetrics(metrics))
+
+	assert.Equal(t, []string{"a", ",", "b"}, metrics.tokens)
+	assert.Equal(t, []int{4}, metrics.scans)
+	assert.Empty(t, metrics.errors)
+
+	// Nested content, clones and remaining tokens are not observed again
+	metrics = new(testMetrics)
+	parser := NewParser("f(a, b) c", IgnoreWhitespaces(), WithMetrics(metrics))
+	parser.Advance()
+
+	inner, err := parser.Unwrap(EnclosureParens())
+	assert.NoError(t, err)
+	assert.Equal(t, "a, b", inner)
+
+	parser.Clone().Advance()
+	assert.Equal(t, []Token{{TokenIdent, "c", 8, 9, nil}}, parser.RemainingTokens())
+
+	for !parser.Exhausted() {
+		parser.Advance()
+	}
+
+	assert.Equal(t, []string{"f", "(", "a", ",", "b", ")", "c"}, metrics.tokens)
+	assert.Equal(t, []int{9}, metrics.scans)
+
+	// The scan is reported again after the parser is reset
+	parser.ResetInput("d")
+	assert.Equal(t, []int{9, 1}, metrics.scans)
+}
+
+func TestWithMetrics_SetIgnoreWhitespaces(t *testing.T) {
+	metrics := new(testMetrics)
+	parser := NewParser("a b  c d", WithMetrics(metrics))
+
+	// The rescanned Tokens after the cursor are not observed again, such
+	// that the input is observed once with the Tokens first scanned from it
+	parser.SetIgnoreWhitespaces(true)
+	parser.SetIgnoreWhitespaces(false)
+	parser.SetIgnoreWhitespaces(true)
+	parser.Advance()
+	parser.SetIgnoreWhitespaces(false)
+	parser.Advance()
+	parser.SetIgnoreWhitespaces(true)
+
+	for !parser.Exhausted() {
+		parser.Advance()
+	}
+
+	assert.Equal(t, []string{"a", " ", "b", "c", "d"}, metrics.tokens)
+	assert.Equal(t, []int{8}, metrics.scans)
+}
+
+func TestWithMetrics_Errors(t *testing.T) {
+	metrics := new(testMetrics)
+	parser := NewParser("a b c", IgnoreWhitespaces(), MaxTokens(2), WithMetrics(metrics))
+
+	for !parser.Exhausted() {
+		parser.Advance()
+	}
+
+	assert.Equal(t, []string{"a", "b"}, metrics.tokens)
+	assert.Equal(t, []int{4}, metrics.scans)
+	assert.Len(t, metrics.errors, 1)
+	assert.True(t, errors.Is(metrics.errors[0], ErrLimitExceeded))
+	assert.Len(t, parser.Errors(), 1)
+
+	// Errors recorded by a Parser are observed
+	metrics = new(testMetrics)
+	parser = NewParser("(a", WithMetrics(metrics))
+
+	_, err := parser.Unwrap(EnclosureParens())
+	assert.Error(t, err)
+	assert.Equal(t, []*Error(parser.Errors()), metrics.errors)
+}
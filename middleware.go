@@ -0,0 +1,51 @@
+package symbolizer
+
+// TokenSource is a source of Tokens, such as the lexer of a Parser or a Middleware that wraps one
+type TokenSource interface {
+	// Next returns the next Token from the source.
+	// Once the source is exhausted, it must return an EoF Token for every call.
+	Next() Token
+}
+
+// TokenSourceFunc is an adapter to use a function as a TokenSource
+type TokenSourceFunc func() Token
+
+// Next implements the TokenSource interface for TokenSourceFunc
+func (fn TokenSourceFunc) Next() Token { return fn() }
+
+// Middleware wraps the TokenSource of a Parser with another TokenSource, for layering cross-cutting concerns
+// (such as metrics, tracing, token rewriting or rate-limiting) onto the Parser. The returned TokenSource may
+// drop, modify or insert Tokens, but must continue to return the EoF Token once the wrapped source does.
+type Middleware func(next TokenSource) TokenSource
+
+// Use returns a ParserOption that wraps the TokenSource of the Parser with the given Middleware. The first
+// Middleware is the outermost, such that it receives the Tokens from the Middleware after it, while the
+// innermost Middleware receives the Tokens of the lexer after any token filters are applied. Multiple
+// uses of the option append to the chain of Middleware.
+//
+// The chain of Middleware is built for each Parser (including any sub-parsers for nested content and
+// clones), Lexer and call to Tokenize when it scans its first Token, and is rebuilt by Parser.ResetInput.
+// Middleware that is shared by Parsers across goroutines (such as with TokenizeAll) must be safe for
+// concurrent use. The lexemes of a Scanner are not passed through the Middleware.
+func Use(middleware ...Middleware) ParserOption {
+	return func(config *parseConfig) {
+		config.middleware = append(config.middleware, middleware...)
+	}
+}
+
+// chain wraps the TokenSource with the configured Middleware
+func (config *parseConfig) chain(source TokenSource) TokenSource {
+	for idx := len(config.middleware) - 1; idx >= 0; idx-- {
+		source = config.middleware[idx](source)
+	}
+
+	return source
+}
+
+// lexerSource is the TokenSource of a lexer, which is the innermost source of a chain of Middleware
+type lexerSource struct {
+	lexer *lexer
+}
+
+// Next implements the TokenSource interface for lexerSource
+func (source lexerSource) Next() Token { return source.lexer.filteredToken() }
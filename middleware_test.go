@@ -0,0 +1,88 @@
+package symbolizer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// upperIdents is a Middleware that rewrites the literals of identifiers to upper case
+func upperIdents(next TokenSource) TokenSource {
+	return TokenSourceFunc(func() Token {
+		token := next.Next()
+		if token.Kind == TokenIdent {
+			token.Literal = strings.ToUpper(token.Literal)
+		}
+
+		return token
+	})
+}
+
+// countTokens returns a Middleware that counts the Tokens (excluding EoF) that pass through it
+func countTokens(count *int) Middleware {
+	return func(next TokenSource) TokenSource {
+		return TokenSourceFunc(func() Token {
+			token := next.Next()
+			if token.Kind != TokenEoF {
+				*count++
+			}
+
+			return token
+		})
+	}
+}
+
+// dropKind returns a Middleware that drops the Tokens of the given TokenKind
+func dropKind(kind TokenKind) Middleware {
+	return func(next TokenSource) TokenSource {
+		return TokenSourceFunc(func() Token {
+			for {
+				if token := next.Next(); token.Kind != kind {
+					return token
+				}
+			}
+		})
+	}
+}
+
+func TestUse(t *testing.T) {
+	count := 0
+	tokens := Tokenize("a, b", IgnoreWhitespaces(), Use(upperIdents, countTokens(&count)))
+
+	assert.Equal(t, []Token{
+		{TokenIdent, "A", 0, 1, nil},
+		{TokenKind(','), ",", 1, 2, nil},
+		{TokenIdent, "B", 3, 4, nil},
+		{TokenEoF, "", 4, 4, nil},
+	}, tokens)
+	assert.Equal(t, 3, count)
+
+	// The outermost Middleware only counts the Tokens that are not dropped by the inner Middleware
+	count = 0
+	parser := NewParser("a, b, c", IgnoreWhitespaces(), Use(countTokens(&count)), Use(dropKind(TokenKind(','))))
+
+	for !parser.IsCursor(TokenEoF) {
+		parser.Advance()
+	}
+
+	assert.Equal(t, 3, count)
+}
+
+func TestUse_Clone(t *testing.T) {
+	count := 0
+	parser := NewParser("a b c", IgnoreWhitespaces(), Use(countTokens(&count), upperIdents))
+	assert.Equal(t, "A", parser.Cursor().Literal)
+
+	clone := parser.Clone()
+	clone.Advance()
+	clone.Advance()
+
+	assert.Equal(t, "C", clone.Cursor().Literal)
+	assert.Equal(t, "A", parser.Cursor().Literal)
+	assert.Equal(t, "B", parser.Peek().Literal)
+
+	parser.ResetInput("d")
+	assert.Equal(t, "D", parser.Cursor().Literal)
+	assert.True(t, parser.IsPeek(TokenEoF))
+}
@@ -1,30 +1,207 @@
 package symbolizer
 
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
 // parseConfig is an internal configuration object for the
 // lexer/parser that are modified using ParserOption functions
 type parseConfig struct {
-	eatSpaces bool
-	keywords  map[string]TokenKind
+	eatSpaces    bool
+	recover      bool
+	durations    bool
+	timestamps   bool
+	floats       bool
+	suffixes     bool
+	semvers      bool
+	amounts      bool
+	placeholders bool
+	wildcards    bool
+	regexes      bool
+	rawRegex     bool
+	noHex        bool
+	strict       bool
+	failFast     bool
+	nfc          bool
+	exact        bool
+	rawStrings   bool
+	heredocs     bool
+	bigNumbers   bool
+	padHex       bool
+
+	maxDepth      int
+	maxTokens     int
+	maxInputBytes int
+
+	base64Prefix string
+
+	currencyCodes []string
+	decimal       DecimalConstructor
+
+	keywords     map[string]TokenKind
+	keywordData  map[string]*KeywordData
+	noDefaults   bool
+	numBooleans  map[string]*KeywordData
+	foldKeywords bool
+	trie         *keywordTrie
+	reserved     map[TokenKind]bool
+
+	scanners []customScanner
+	filters  []func(Token) (Token, bool)
+
+	middleware []Middleware
+	metrics    Metrics
+	trace      Logger
+
+	escape rune
+
+	identClasses  []*unicode.RangeTable
+	symbolClasses []symbolClass
+	runs          []runClass
+}
+
+// runClass is a class of unicode symbols whose consecutive runs generate Tokens of a specific TokenKind
+type runClass struct {
+	kind  TokenKind
+	class func(rune) bool
+}
+
+// symbolClass is a set of unicode symbols that generate Tokens of a specific TokenKind
+type symbolClass struct {
+	kind   TokenKind
+	tables []*unicode.RangeTable
+}
+
+// identClass returns whether the symbol belongs to any of the configured identifier classes
+func (config *parseConfig) identClass(symbol rune) bool {
+	return len(config.identClasses) != 0 && unicode.IsOneOf(config.identClasses, symbol)
+}
+
+// symbolClass returns the TokenKind for the symbol if it belongs to any of the configured symbol classes
+func (config *parseConfig) symbolClass(symbol rune) (TokenKind, bool) {
+	for _, class := range config.symbolClasses {
+		if unicode.IsOneOf(class.tables, symbol) {
+			return class.kind, true
+		}
+	}
+
+	return 0, false
+}
+
+// defaultKeywords are the keywords recognized by the Parser unless disabled with NoDefaultKeywords
+var defaultKeywords = map[string]TokenKind{
+	"true":  TokenBoolean,
+	"false": TokenBoolean,
 }
 
 // newParseConfig generate a new parseConfig with all default params
 // and then applies any options provided to modify the config
 func newParseConfig(opts ...ParserOption) *parseConfig {
 	// Create a new parseConfig and apply all the given options on it
-	config := new(parseConfig)
-	// Set the default keywords
-	config.keywords = map[string]TokenKind{
-		"true":  TokenBoolean,
-		"false": TokenBoolean,
-	}
-
+	config := &parseConfig{keywords: make(map[string]TokenKind)}
 	for _, option := range opts {
 		option(config)
 	}
 
+	// Set the default keywords, unless disabled or overwritten by custom keywords
+	if !config.noDefaults {
+		for keyword, kind := range defaultKeywords {
+			if _, exists := config.keywords[keyword]; !exists {
+				config.keywords[keyword] = kind
+			}
+		}
+	}
+
+	if config.nfc {
+		config.normalizeKeywords()
+	}
+
+	config.compileKeywords()
+	config.compileKeywordData()
+
 	return config
 }
 
+// normalizeKeywords normalizes the keywords (and the keys of the keyword data) into Unicode NFC
+func (config *parseConfig) normalizeKeywords() {
+	keywords := make(map[string]TokenKind, len(config.keywords))
+	for keyword, kind := range config.keywords {
+		keywords[norm.NFC.String(keyword)] = kind
+	}
+
+	config.keywords = keywords
+
+	if config.keywordData != nil {
+		data := make(map[string]*KeywordData, len(config.keywordData))
+		for keyword, payload := range config.keywordData {
+			data[norm.NFC.String(keyword)] = payload
+		}
+
+		config.keywordData = data
+	}
+}
+
+// compileKeywords compiles the keywords into a trie if any of them span multiple words or if keywords
+// are matched case-insensitively. Otherwise, the keywords are looked up directly from the map.
+func (config *parseConfig) compileKeywords() {
+	phrases := false
+	for keyword := range config.keywords {
+		if strings.IndexFunc(keyword, unicode.IsSpace) >= 0 {
+			phrases = true
+			break
+		}
+	}
+
+	if phrases || config.foldKeywords {
+		config.trie = newKeywordTrie(config.keywords, config.foldKeywords)
+	}
+}
+
+// compileKeywordData normalizes the keys of the keyword data to match the literals of their Tokens, with
+// their words separated by a single space and folded to lowercase if keywords are matched case-insensitively.
+func (config *parseConfig) compileKeywordData() {
+	if config.keywordData == nil || config.trie == nil {
+		return
+	}
+
+	normalized := make(map[string]*KeywordData, len(config.keywordData))
+	for keyword, data := range config.keywordData {
+		normalized[config.normalizeKeyword(keyword)] = data
+	}
+
+	config.keywordData = normalized
+}
+
+// normalizeKeyword normalizes a keyword or the literal of a keyword Token for keyword data lookups.
+// Keywords are only normalized if they are matched with the keyword trie.
+func (config *parseConfig) normalizeKeyword(keyword string) string {
+	if config.trie == nil {
+		return keyword
+	}
+
+	keyword = strings.Join(strings.FieldsFunc(keyword, unicode.IsSpace), " ")
+	if config.foldKeywords {
+		keyword = strings.ToLower(keyword)
+	}
+
+	return keyword
+}
+
+// Config is a compiled set of ParserOptions. It can be shared by any number of Parsers (including
+// concurrently) to avoid applying the options and rebuilding the keyword table for each of them.
+type Config struct {
+	config *parseConfig
+}
+
+// CompileConfig applies the given options and returns the compiled Config.
+// Any token filters or custom scanners in the options are shared by all Parsers using the Config.
+func CompileConfig(opts ...ParserOption) *Config {
+	return &Config{config: newParseConfig(opts...)}
+}
+
 // ParserOption represents an option to modify the Parser behaviour.
 // It must be provided with the constructor for Parser.
 type ParserOption func(config *parseConfig)
@@ -35,13 +212,136 @@ type ParserOption func(config *parseConfig)
 // it returns a Token with the given kind and the actual literal encountered.
 // Any default keywords are overwritten if specified in the custom set.
 //
-// Note: Use TokenKind values less than -10 for custom Token classes.
-// -10 to -1 are reserved for standard token classes while 0 and above correspond the unicode code points.
+// Keywords may span multiple words separated by whitespace (such as 'ORDER BY'), which match
+// identifiers separated by any whitespace in the input and generate a single Token for them.
+// The longest keyword is matched when a multi-word keyword begins with another keyword.
+//
+// Note: Use TokenKind values of -10 or below for custom Token classes.
+// -9 to -1 are reserved for standard token classes while 0 and above correspond the unicode code points.
 func Keywords(keywords map[string]TokenKind) ParserOption {
 	return func(config *parseConfig) {
-		// Add each keyword to the config
+		// Add each keyword to the config, overwriting any keyword data
 		for keyword, kind := range keywords {
 			config.keywords[keyword] = kind
+			delete(config.keywordData, keyword)
+		}
+	}
+}
+
+// KeywordSpec describes a keyword registered with KeywordsWithData,
+// with the TokenKind of its Tokens and the payload carried by them.
+type KeywordSpec struct {
+	Kind TokenKind
+	Data any
+}
+
+// KeywordsWithData returns a ParserOption that provides the Parser with a set of special keywords like Keywords,
+// where each keyword also carries a payload (such as a handler or an enum value). Tokens generated for the keywords
+// have the TokenKind of their KeywordSpec and a handle to its Data in their Data field (see KeywordData), which
+// allows applications to act on keywords without a second lookup table. Keywords provided with Keywords
+// overwrite the keyword and its data.
+func KeywordsWithData(keywords map[string]KeywordSpec) ParserOption {
+	return func(config *parseConfig) {
+		if config.keywordData == nil {
+			config.keywordData = make(map[string]*KeywordData)
+		}
+
+		for keyword, spec := range keywords {
+			config.keywords[keyword] = spec.Kind
+			config.keywordData[keyword] = &KeywordData{spec.Data}
+		}
+	}
+}
+
+// NoDefaultKeywords returns a ParserOption that specifies the Parser to not recognize the default keywords
+// ('true' and 'false' as TokenBoolean), which are then treated as regular identifiers. Custom keywords
+// provided with the Keywords option are unaffected, regardless of the order of the options.
+func NoDefaultKeywords() ParserOption {
+	return func(config *parseConfig) {
+		config.noDefaults = true
+	}
+}
+
+// DisableDefaultBooleans returns a ParserOption that specifies the Parser to not recognize the default boolean
+// keywords ('true' and 'false'), which are then treated as regular identifiers. It is an alias of NoDefaultKeywords,
+// since the default keywords are only the booleans, and it pairs with DisableHexLiterals for grammars that
+// disable the standard literals. Boolean keywords provided with the Keywords option are unaffected.
+func DisableDefaultBooleans() ParserOption {
+	return NoDefaultKeywords()
+}
+
+// BooleanKeywords returns a ParserOption that provides the Parser with additional spellings of booleans (such as
+// 'yes' and 'no' or 'on' and 'off') mapped to their boolean values. Tokens of the spellings are TokenBoolean and
+// carry their boolean value as their Data, which Token.Value returns instead of parsing the literal. Spellings that
+// begin with a digit (such as '1' and '0') are matched against numeric literals, which are otherwise TokenNumber.
+// Other spellings are keywords, which are matched like any other keyword (see Keywords and KeywordsWithData).
+func BooleanKeywords(spellings map[string]bool) ParserOption {
+	return func(config *parseConfig) {
+		if config.keywordData == nil {
+			config.keywordData = make(map[string]*KeywordData)
+		}
+
+		for spelling, value := range spellings {
+			if spelling != "" && unicode.IsDigit([]rune(spelling)[0]) {
+				if config.numBooleans == nil {
+					config.numBooleans = make(map[string]*KeywordData)
+				}
+
+				config.numBooleans[spelling] = &KeywordData{value}
+				continue
+			}
+
+			config.keywords[spelling] = TokenBoolean
+			config.keywordData[spelling] = &KeywordData{value}
+		}
+	}
+}
+
+// NullLiterals returns a ParserOption that specifies the Parser to generate TokenNull Tokens for the given spellings
+// of null values, whose Token.Value is the Null marker. If no spellings are given, 'null', 'nil' and 'none' are used.
+// The spellings are keywords, which are matched like any other keyword (see Keywords and CaseInsensitiveKeywords).
+func NullLiterals(spellings ...string) ParserOption {
+	return func(config *parseConfig) {
+		if len(spellings) == 0 {
+			spellings = []string{"null", "nil", "none"}
+		}
+
+		for _, spelling := range spellings {
+			config.keywords[spelling] = TokenNull
+			delete(config.keywordData, spelling)
+		}
+	}
+}
+
+// DisableHexLiterals returns a ParserOption that specifies the Parser to not recognize hex literals (such as 0x18),
+// which are then scanned like any other symbols (0x18 -> '0' as a TokenNumber and 'x18' as a TokenIdent). This is
+// useful for grammars in which such symbols are not numerics and can be reassembled or handled by a CustomScanner.
+func DisableHexLiterals() ParserOption {
+	return func(config *parseConfig) {
+		config.noHex = true
+	}
+}
+
+// CaseInsensitiveKeywords returns a ParserOption that specifies the Parser to match keywords regardless
+// of their case, such that the 'select' keyword also matches 'SELECT' and 'Select' in the input.
+// The generated Tokens retain the literal as encountered in the input.
+func CaseInsensitiveKeywords() ParserOption {
+	return func(config *parseConfig) {
+		config.foldKeywords = true
+	}
+}
+
+// ReservedKeywords returns a ParserOption that declares the keywords of the given custom TokenKinds as
+// reserved, such that they cannot be used as identifiers. Keywords of other custom TokenKinds are accepted
+// as identifiers by Parser.ExpectIdent and ParseCall, while reserved keywords result in a descriptive error.
+func ReservedKeywords(kinds ...TokenKind) ParserOption {
+	return func(config *parseConfig) {
+		if config.reserved == nil {
+			config.reserved = make(map[TokenKind]bool)
+		}
+
+		for _, kind := range kinds {
+			config.reserved[kind] = true
 		}
 	}
 }
@@ -53,3 +353,485 @@ func IgnoreWhitespaces() ParserOption {
 		config.eatSpaces = true
 	}
 }
+
+// RecoverMalformed returns a ParserOption that specifies the Parser to recover from malformed constructs
+// while parsing structured content (such as a key without a separator in ParsePairs) by skipping the
+// malformed construct and producing a TokenMalformed Token for it instead of halting with an error.
+// This allows batch tools to report every malformed construct in an input rather than just the first.
+func RecoverMalformed() ParserOption {
+	return func(config *parseConfig) {
+		config.recover = true
+	}
+}
+
+// NFCNormalize returns a ParserOption that specifies the Parser to normalize the input into Unicode NFC before
+// tokenizing it, such that visually identical identifiers with different code point sequences (such as 'é' as a
+// single code point and as 'e' with a combining accent) generate the same literals and match the same keywords,
+// which are also normalized. Inputs that are not already normalized are copied, and the positions of their Tokens
+// are byte offsets within the normalized input (which is available from a Scanner with Scanner.Input).
+func NFCNormalize() ParserOption {
+	return func(config *parseConfig) {
+		config.nfc = true
+	}
+}
+
+// StrictASCII returns a ParserOption that specifies the Parser to reject non-ASCII symbols, for protocols that only
+// permit ASCII symbol strings. Each non-ASCII symbol generates a TokenMalformed Token spanning the symbol (and any
+// literal containing one, such as a string, generates a TokenMalformed Token spanning the literal). Identifiers end
+// at non-ASCII symbols. The Parser also reports an Error classified as ErrNonASCII for each of them in its Errors.
+func StrictASCII() ParserOption {
+	return func(config *parseConfig) {
+		config.strict = true
+	}
+}
+
+// StrictMode returns a ParserOption that specifies the Parser to fail fast on lexical problems instead of generating
+// TokenMalformed Tokens for them (such as unterminated strings, hex prefixes without digits or invalid UTF-8). The
+// lexer is terminated at the first malformed symbol with an Error classified as ErrMalformed (or as ErrNonASCII
+// with StrictASCII) that is reported by Parser.Next, Lexer.Err and in the Errors of the Parser.
+func StrictMode() ParserOption {
+	return func(config *parseConfig) {
+		config.failFast = true
+	}
+}
+
+// RawStrings returns a ParserOption that specifies the Parser to recognize raw string literals enclosed in
+// backticks (such as `say "hi"`), which may contain quotes and newlines without escaping. They generate
+// TokenString Tokens whose value is the data between the backticks.
+func RawStrings() ParserOption {
+	return func(config *parseConfig) {
+		config.rawStrings = true
+	}
+}
+
+// Heredocs returns a ParserOption that specifies the Parser to recognize heredoc literals, which begin with
+// '<<' and a marker identifier on its own line (such as <<END) and continue until a line that only contains
+// the marker. They generate TokenString Tokens whose value is the data between the marker lines.
+func Heredocs() ParserOption {
+	return func(config *parseConfig) {
+		config.heredocs = true
+	}
+}
+
+// Base64Literals returns a ParserOption that specifies the Parser to recognize base64 literals that are written
+// as a string immediately preceded by the given prefix (such as b64"aGVsbG8=" for the prefix "b64") and generate
+// TokenBase64 Tokens for them. The value of such Tokens is the decoded []byte, with either the standard or the
+// URL-safe alphabet and with optional padding. An unterminated base64 literal generates a TokenMalformed Token.
+func Base64Literals(prefix string) ParserOption {
+	return func(config *parseConfig) {
+		config.base64Prefix = prefix
+	}
+}
+
+// MaxDepth returns a ParserOption that limits the nesting depth of enclosures resolved by Unwrap and of the
+// structures parsed by recursive routines such as KeyedGroup, ParseCall or ParseTypeSignature. An error is
+// returned when the depth is exceeded, protecting services that parse untrusted input from pathological inputs.
+// A depth of zero or less removes the limit (which is the default).
+func MaxDepth(depth int) ParserOption {
+	return func(config *parseConfig) {
+		config.maxDepth = depth
+	}
+}
+
+// MaxTokens returns a ParserOption that limits the number of Tokens (excluding EoF) that are generated
+// for an input. If the limit is exceeded, tokenization stops with an EoF Token at the position of the
+// first Token beyond the limit and an Error classified as ErrLimitExceeded is reported in the Errors of
+// the Parser (or by the Err method of a Lexer or Scanner). A limit of zero or less removes the limit.
+func MaxTokens(limit int) ParserOption {
+	return func(config *parseConfig) {
+		config.maxTokens = limit
+	}
+}
+
+// MaxInputBytes returns a ParserOption that limits the size of the input in bytes. If the input exceeds
+// the limit, it is not tokenized at all and an Error classified as ErrLimitExceeded is reported in the
+// Errors of the Parser (or by the Err method of a Lexer or Scanner). A limit of zero or less removes the limit.
+func MaxInputBytes(limit int) ParserOption {
+	return func(config *parseConfig) {
+		config.maxInputBytes = limit
+	}
+}
+
+// AllowBigNumbers returns a ParserOption that specifies the Parser to convert numeric literals that overflow
+// 64-bit integers into a big.Int (see Token.BigValue) while parsing values, such as with KeyedGroup or
+// ParseCall, instead of failing with an out of range error. This matters for 256-bit blockchain values.
+func AllowBigNumbers() ParserOption {
+	return func(config *parseConfig) {
+		config.bigNumbers = true
+	}
+}
+
+// PadOddHex returns a ParserOption that specifies the Parser to left-pad hex literals that have an odd number of
+// digits with a zero (0x1A2 -> 0x01A2) before decoding them while parsing values, such as with KeyedGroup or
+// ParseCall. This allows addresses and identifiers written without leading zeros to be decoded correctly.
+func PadOddHex() ParserOption {
+	return func(config *parseConfig) {
+		config.padHex = true
+	}
+}
+
+// DurationLiterals returns a ParserOption that specifies the Parser to recognize duration literals such as
+// 5m30s, 100ms or -1.5h and generate TokenDuration Tokens for them. The value of such Tokens is a time.Duration.
+func DurationLiterals() ParserOption {
+	return func(config *parseConfig) {
+		config.durations = true
+	}
+}
+
+// TimestampLiterals returns a ParserOption that specifies the Parser to recognize RFC3339 timestamp literals
+// such as 2022-11-04T10:15:30Z and generate TokenTimestamp Tokens for them. The value of such Tokens is a time.Time.
+func TimestampLiterals() ParserOption {
+	return func(config *parseConfig) {
+		config.timestamps = true
+	}
+}
+
+// ScientificNumbers returns a ParserOption that specifies the Parser to recognize floating point literals with a
+// fractional part or an exponent (such as 1.5, 2e10 or -1.5E-3). They generate TokenFloat Tokens whose value is
+// a float64. Numerics without a fractional part or exponent continue to generate TokenNumber Tokens.
+func ScientificNumbers() ParserOption {
+	return func(config *parseConfig) {
+		config.floats = true
+	}
+}
+
+// SuffixedNumbers returns a ParserOption that specifies the Parser to recognize numerics with an SI multiplier
+// suffix (k, K, M, G, T, P, E) or an IEC multiplier suffix (Ki, Mi, Gi, Ti, Pi, Ei), optionally followed by 'B',
+// such as 2k, 1.5M or 3MiB. They generate TokenSuffixed Tokens whose value has the multiplier applied.
+// If ScientificNumbers is also enabled, exponents take precedence such that 2E3 is a TokenFloat.
+func SuffixedNumbers() ParserOption {
+	return func(config *parseConfig) {
+		config.suffixes = true
+	}
+}
+
+// SemverLiterals returns a ParserOption that specifies the Parser to recognize semantic version literals with an
+// optional 'v' prefix and optional pre-release and build labels (such as 1.2.3, v1.2.3-beta.1 or 2.0.0+build.5)
+// and generate TokenSemver Tokens for them. The value of such Tokens is a Semver. Semantic versions take
+// precedence over floating point literals, such that 1.2.3 is never split into a TokenFloat and a '.'.
+func SemverLiterals() ParserOption {
+	return func(config *parseConfig) {
+		config.semvers = true
+	}
+}
+
+// AmountLiterals returns a ParserOption that specifies the Parser to recognize currency amounts and generate
+// TokenAmount Tokens for them. Amounts are decimals (with optional ',' thousands separators and a fractional part)
+// that are either preceded by a currency sigil from the unicode.Sc category (such as $1,299.99 or €5) or followed
+// by whitespace and one of the given currency codes (such as 42 USD). The value of such Tokens is an Amount.
+func AmountLiterals(codes ...string) ParserOption {
+	return func(config *parseConfig) {
+		config.amounts = true
+		config.currencyCodes = append(config.currencyCodes, codes...)
+	}
+}
+
+// Placeholders returns a ParserOption that specifies the Parser to recognize the placeholders of prepared symbols
+// and generate TokenPlaceholder Tokens for them. Placeholders are either positional ('?') or named with a ':'
+// immediately followed by an identifier (such as :owner). A ':' that follows an identifier, keyword or value
+// (such as in `key:value`) is never a placeholder. Values are bound to them with Prepare and Bind.
+func Placeholders() ParserOption {
+	return func(config *parseConfig) {
+		config.placeholders = true
+	}
+}
+
+// Wildcards returns a ParserOption that specifies the Parser to recognize the wildcards of pattern symbols (such as
+// metrics.*.latency) and generate TokenWildcard Tokens for '*', '**' and '?'. Patterns can be matched against
+// symbols with MatchPattern. If Placeholders is also enabled, '?' is a wildcard instead of a placeholder.
+func Wildcards() ParserOption {
+	return func(config *parseConfig) {
+		config.wildcards = true
+	}
+}
+
+// RegexLiterals returns a ParserOption that specifies the Parser to recognize regular expression literals of the
+// form /pattern/flags (such as /^v[0-9]+$/i) and generate TokenRegex Tokens for them. A '/' within the pattern
+// must be escaped as '\/'. The flags are any of 'i', 'm', 's' and 'U' (see regexp/syntax). A '/' that is not
+// closed by another '/' on the same line is not a regular expression and generates a unicode Token.
+// The value of such Tokens is a compiled *regexp.Regexp, unless RawRegex is enabled.
+func RegexLiterals() ParserOption {
+	return func(config *parseConfig) {
+		config.regexes = true
+	}
+}
+
+// RawRegex returns a ParserOption that specifies the Parser to convert TokenRegex Tokens into their raw pattern
+// while parsing values, such as with KeyedGroup or ParseCall, instead of compiling them. The raw pattern is a
+// string with the escaped '\/' unescaped and the flags applied inline, such as '(?i)^v[0-9]+$' for /^v[0-9]+$/i.
+func RawRegex() ParserOption {
+	return func(config *parseConfig) {
+		config.rawRegex = true
+	}
+}
+
+// DecimalConstructor constructs a decimal value from the digits of a decimal literal (such as '-1299.99'),
+// which allows consumers to use their own exact decimal representation instead of a *big.Rat.
+type DecimalConstructor func(digits string) (any, error)
+
+// Decimals returns a ParserOption that specifies the Parser to construct the decimal values of amounts (and of
+// fractional numerics if ExactDecimals is enabled) with the given DecimalConstructor while parsing values, such as
+// with KeyedGroup or ParseCall. If the constructor returns an error, it is reported at the position of the Token.
+// Token.Value and Token.Rat do not observe the option and always convert amounts into a *big.Rat.
+func Decimals(constructor DecimalConstructor) ParserOption {
+	return func(config *parseConfig) {
+		config.decimal = constructor
+	}
+}
+
+// ExactDecimals returns a ParserOption that specifies the Parser to convert numerics with fractional parts (TokenFloat
+// and fractional TokenSuffixed Tokens) into their exact value while parsing values, such as with KeyedGroup or
+// ParseCall, instead of a float64. The value is a *big.Rat (see Token.Rat) or the value constructed by the
+// DecimalConstructor of the Decimals option, which receives the exact decimal digits (1.5e3 -> '1500').
+// Token.Value does not observe the option, since a Token carries no parser configuration, and it continues to
+// convert such Tokens into a float64. Their exact value is available from any Token with Token.Rat.
+func ExactDecimals() ParserOption {
+	return func(config *parseConfig) {
+		config.exact = true
+	}
+}
+
+// CustomScanner returns a ParserOption that registers a user defined scanner with the Parser. When the lexer
+// encounters a symbol for which trigger returns true, scan is invoked with a LexerCursor positioned at that
+// symbol. The scanner must advance the cursor past the symbols it consumes and return a Token for them, the
+// Token spans the input from the trigger symbol to the final cursor position. If the scanner does not consume
+// any symbols, the lexer falls back to its standard scanners. Custom scanners are attempted before the
+// standard scanners and in the order they were registered.
+func CustomScanner(trigger func(rune) bool, scan func(*LexerCursor) Token) ParserOption {
+	return func(config *parseConfig) {
+		config.scanners = append(config.scanners, customScanner{trigger, scan})
+	}
+}
+
+// IdentifierClass returns a ParserOption that specifies the Parser to treat unicode symbols from the given
+// range tables (such as unicode.Sc for currency symbols or EmojiTable) as identifier characters. Such symbols
+// can then begin and be part of identifiers, similar to letters, digits and underscores.
+func IdentifierClass(tables ...*unicode.RangeTable) ParserOption {
+	return func(config *parseConfig) {
+		config.identClasses = append(config.identClasses, tables...)
+	}
+}
+
+// SymbolClass returns a ParserOption that specifies the Parser to generate Tokens of the given kind for
+// unicode symbols from the given range tables (such as unicode.Sm for math symbols), instead of Tokens
+// with their code point as the kind. Each symbol generates its own Token with the symbol as its literal.
+//
+// Note: Use TokenKind values of -10 or below for custom Token classes.
+func SymbolClass(kind TokenKind, tables ...*unicode.RangeTable) ParserOption {
+	return func(config *parseConfig) {
+		config.symbolClasses = append(config.symbolClasses, symbolClass{kind, tables})
+	}
+}
+
+// RunsOf returns a ParserOption that specifies the Parser to merge consecutive unicode symbols that match
+// the given class into a single Token of the given kind, such as runs of punctuation or phone numbers with
+// digits separated by dashes. Runs take precedence over all other Tokens except those of custom scanners.
+// If multiple runs are specified, the first one whose class matches a symbol generates its Token.
+//
+// Note: Use TokenKind values of -10 or below for custom Token classes.
+func RunsOf(class func(rune) bool, kind TokenKind) ParserOption {
+	return func(config *parseConfig) {
+		config.runs = append(config.runs, runClass{kind, class})
+	}
+}
+
+// EmojiTable is a unicode range table that covers the common emoji and pictograph blocks.
+// It can be used with IdentifierClass or SymbolClass to classify emoji symbols.
+var EmojiTable = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x2600, Hi: 0x27bf, Stride: 1}, // Miscellaneous Symbols and Dingbats
+		{Lo: 0x2b00, Hi: 0x2bff, Stride: 1}, // Miscellaneous Symbols and Arrows
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x1f000, Hi: 0x1f2ff, Stride: 1}, // Mahjong, Domino, Playing Cards and Enclosed Supplements
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1}, // Miscellaneous Symbols and Pictographs, Emoticons
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1}, // Transport and Map Symbols
+		{Lo: 0x1f900, Hi: 0x1faff, Stride: 1}, // Supplemental Symbols and Pictographs, Extended-A
+	},
+}
+
+// TokenFilter returns a ParserOption that registers a filter which is applied to every Token before the
+// Parser sees it. The filter may return a modified Token (for case folding or renaming) and returns false
+// to drop the Token entirely. Multiple filters are applied in the order they were registered, with each
+// receiving the output of the previous. The EoF Token is never passed to filters and cannot be dropped.
+//
+// Note: Filters do not apply to the Lexemes generated by a Scanner.
+func TokenFilter(filter func(Token) (Token, bool)) ParserOption {
+	return func(config *parseConfig) {
+		config.filters = append(config.filters, filter)
+	}
+}
+
+// EscapeRune returns a ParserOption that specifies an escape character (such as '\\') for the extracting
+// operations of the Parser (such as Split and Unwrap). A unicode character Token that immediately follows the
+// escape character is escaped, such that delimiters do not terminate segments and enclosure characters do not
+// open or close enclosures. The escape character is stripped from the extracted data, while an escape character
+// that does not immediately precede a unicode character Token (such as one followed by an identifier) is retained.
+// An escape character can be escaped by itself.
+func EscapeRune(escape rune) ParserOption {
+	return func(config *parseConfig) {
+		config.escape = escape
+	}
+}
+
+// escapes returns whether the Token is an escape character that escapes the next Token (see EscapeRune)
+func (config *parseConfig) escapes(token, next Token) bool {
+	return config.escape != 0 && token.Kind == TokenKind(config.escape) && next.Kind > 0 && next.Position == token.End
+}
+
+// whitespaceMode describes how whitespaces are handled when extracting data from the input
+type whitespaceMode int
+
+const (
+	// whitespaceDefault uses the default behaviour of each operation
+	whitespaceDefault whitespaceMode = iota
+	// whitespacePreserve retains all whitespaces as they appear in the input
+	whitespacePreserve
+	// whitespaceDrop removes all whitespaces outside string literals
+	whitespaceDrop
+)
+
+// spacesMode describes whether whitespaces are ignored by the Parser during a data extracting operation
+type spacesMode int
+
+const (
+	// spacesDefault uses the setting of the Parser
+	spacesDefault spacesMode = iota
+	// spacesScan generates Tokens for whitespaces
+	spacesScan
+	// spacesIgnore consumes whitespaces without generating Tokens for them
+	spacesIgnore
+)
+
+// extractConfig is an internal configuration object for the data extracting
+// operations of the Parser that are modified using ExtractOption functions
+type extractConfig struct {
+	whitespace whitespaceMode
+	spaces     spacesMode
+
+	trim      bool
+	dropEmpty bool
+	limit     int
+}
+
+// newExtractConfig generates a new extractConfig and applies any options provided to modify it
+func newExtractConfig(opts ...ExtractOption) *extractConfig {
+	config := new(extractConfig)
+	for _, option := range opts {
+		option(config)
+	}
+
+	return config
+}
+
+// ExtractOption represents an option to modify the behaviour of a single data extracting
+// operation of the Parser such as Split or Unwrap, independent of the ParserOptions.
+type ExtractOption func(config *extractConfig)
+
+// PreserveWhitespace returns an ExtractOption that specifies whether whitespaces are retained in the extracted
+// data. If preserve is true, the data is returned exactly as it appears in the input, including any whitespaces
+// ignored by the Parser. If preserve is false, all whitespaces outside of string literals are removed from
+// the data, even if they were not ignored by the Parser.
+func PreserveWhitespace(preserve bool) ExtractOption {
+	return func(config *extractConfig) {
+		if preserve {
+			config.whitespace = whitespacePreserve
+		} else {
+			config.whitespace = whitespaceDrop
+		}
+	}
+}
+
+// WithSpaces returns an ExtractOption that specifies the Parser to generate Tokens for whitespaces during the
+// operation, even if it ignores whitespaces (see IgnoreWhitespaces), such that they can be used as delimiters
+// and are retained in the extracted data. The setting of the Parser is restored once the operation completes.
+// It applies to operations that scan Tokens to extract data (such as Split), rather than slicing the input.
+func WithSpaces() ExtractOption {
+	return func(config *extractConfig) {
+		config.spaces = spacesScan
+	}
+}
+
+// WithoutSpaces returns an ExtractOption that specifies the Parser to ignore whitespaces during the operation,
+// even if it does not ignore whitespaces otherwise. The setting of the Parser is restored once the operation
+// completes. Unlike PreserveWhitespace(false), whitespaces are never scanned as Tokens during the operation.
+func WithoutSpaces() ExtractOption {
+	return func(config *extractConfig) {
+		config.spaces = spacesIgnore
+	}
+}
+
+// TrimSpace returns an ExtractOption that specifies the splitting operations of the Parser (such as Split) to
+// trim the leading and trailing whitespaces of each segment, which are otherwise retained in the segments.
+func TrimSpace() ExtractOption {
+	return func(config *extractConfig) {
+		config.trim = true
+	}
+}
+
+// DropEmpty returns an ExtractOption that specifies the splitting operations of the Parser (such as Split)
+// to omit empty segments (after trimming with TrimSpace, if enabled), such as those between adjacent delimiters.
+func DropEmpty() ExtractOption {
+	return func(config *extractConfig) {
+		config.dropEmpty = true
+	}
+}
+
+// MaxSegments returns an ExtractOption that specifies the splitting operations of the Parser (such as Split) to
+// generate at most n segments, like strings.SplitN. The last segment is the remainder of the input, in which
+// delimiters are retained. Segments omitted by DropEmpty do not count towards the limit. Values of n below 1 are ignored.
+func MaxSegments(n int) ExtractOption {
+	return func(config *extractConfig) {
+		config.limit = n
+	}
+}
+
+// splits returns whether a delimiter splits a segment, given the number of segments that have been generated.
+// A delimiter also splits a segment that is omitted by DropEmpty (see drops) once the limit is reached, since
+// omitted segments do not count towards the limit of MaxSegments.
+func (config *extractConfig) splits(segments int) bool {
+	return config.limit <= 0 || segments < config.limit-1
+}
+
+// drops returns whether the segment is omitted by DropEmpty, after trimming it with TrimSpace (if enabled)
+func (config *extractConfig) drops(segment string) bool {
+	if !config.dropEmpty {
+		return false
+	}
+
+	if config.trim {
+		segment = strings.TrimFunc(segment, unicode.IsSpace)
+	}
+
+	return segment == ""
+}
+
+// valueConfig is an internal configuration object for the value parsing
+// operations of the Parser that are modified using ValueOption functions
+type valueConfig struct {
+	rejectTrailing bool
+}
+
+// newValueConfig generates a new valueConfig and applies any options provided to modify it
+func newValueConfig(opts ...ValueOption) *valueConfig {
+	config := new(valueConfig)
+	for _, option := range opts {
+		option(config)
+	}
+
+	return config
+}
+
+// ValueOption represents an option to modify the behaviour of a single value parsing
+// operation of the Parser such as Value or KeyedGroup, independent of the ParserOptions.
+type ValueOption func(config *valueConfig)
+
+// RejectTrailing returns a ValueOption that specifies the value parsing operations of the Parser to fail with an
+// Error classified as ErrTrailingInput (at the position of the first extra Token) if any Tokens other than whitespaces
+// remain after the value is parsed. Without it, the parser is left at the remainder of the input, which can be
+// retrieved with Unparsed or RemainingTokens.
+func RejectTrailing() ValueOption {
+	return func(config *valueConfig) {
+		config.rejectTrailing = true
+	}
+}
@@ -0,0 +1,106 @@
+package symbolizer
+
+// ParsePairs walks the remaining contents of the parser as a sequence of key-value pairs such as
+// `key=value, key=value`, where sep is the TokenKind between a key and its value and delim is the
+// TokenKind between two pairs. For each pair, yield is called with the key Token and the run of
+// Tokens that make up its value (which may be empty). Walking stops early if yield returns false.
+//
+// Delimiters nested within parenthesis, square or curly brackets in a value do not end the value.
+// Returns an error if a key is not followed by the separator. Empty pairs are skipped. If the parser
+// was created with the RecoverMalformed option, the malformed pair is instead skipped until the next
+// delimiter and yielded as a key Token of kind TokenMalformed (spanning the pair) with no value,
+// while the error is accumulated into the Errors of the parser.
+func (parser *Parser) ParsePairs(sep, delim TokenKind, yield func(key Token, value []Token) bool) error {
+	return parser.parsePairs(sep, delim, nil, yield)
+}
+
+// parsePairs walks the remaining contents of the parser as a sequence of key-value pairs like ParsePairs,
+// but delimiters nested within any of the given Enclosures in a value also do not end the value
+func (parser *Parser) parsePairs(sep, delim TokenKind, nested []Enclosure, yield func(key Token, value []Token) bool) error {
+	for !parser.Exhausted() {
+		// Skip empty pairs
+		if parser.IsCursor(delim) {
+			parser.Advance()
+			continue
+		}
+
+		// Collect the key and require the separator after it
+		key := parser.curr
+		if !parser.ExpectPeek(sep) {
+			err := parser.errorf(parser.next.Position, "missing pair separator %v after key: '%v'", sep, key.Literal)
+			if !parser.scanner.config.recover {
+				return err
+			}
+
+			// Resynchronize at the next pair delimiter and yield the malformed pair
+			if !yield(parser.SkipUntil(delim), nil) {
+				return nil
+			}
+
+			continue
+		}
+
+		// Advance into the value and collect it
+		parser.Advance()
+		value := parser.collectValue(delim, nested...)
+
+		if !yield(key, value) {
+			return nil
+		}
+
+		// Move past the pair delimiter
+		if parser.IsCursor(delim) {
+			parser.Advance()
+		}
+	}
+
+	return nil
+}
+
+// collectValue collects all Tokens from the cursor until the given delimiter is
+// encountered at the top nesting level or the parser is exhausted. The nesting
+// level is tracked with the parenthesis, square bracket and curly bracket
+// enclosures along with the given Enclosures (such as the Enclosure of a group).
+// The parser is left with the delimiter (or EoF) under its cursor.
+func (parser *Parser) collectValue(delim TokenKind, nested ...Enclosure) (tokens []Token) {
+	nesting := 0
+
+	for !parser.Exhausted() {
+		if nesting == 0 && parser.IsCursor(delim) {
+			break
+		}
+
+		// Resolve the change in nesting level and the end of the Tokens that cause it,
+		// which spans multiple Tokens for the character sequences of an Enclosure
+		delta, end := nestingDelta(parser.curr.Kind), parser.curr.End
+		for _, enc := range nested {
+			switch {
+			case parser.opens(enc):
+				delta, end = 1, parser.curr.Position+len(enc.Open())
+			case parser.closes(enc):
+				delta, end = -1, parser.curr.Position+len(enc.Close())
+			}
+		}
+
+		nesting += delta
+		for !parser.Exhausted() && parser.curr.Position < end {
+			tokens = append(tokens, parser.curr)
+			parser.Advance()
+		}
+	}
+
+	return tokens
+}
+
+// nestingDelta returns the change in nesting level caused by a token of the given kind,
+// considering the parenthesis, square bracket and curly bracket enclosures.
+func nestingDelta(kind TokenKind) int {
+	switch kind {
+	case '(', '[', '{':
+		return 1
+	case ')', ']', '}':
+		return -1
+	default:
+		return 0
+	}
+}
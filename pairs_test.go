@@ -0,0 +1,96 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_ParsePairs(t *testing.T) {
+	type pair struct {
+		key   string
+		value []string
+	}
+
+	tests := []struct {
+		input   string
+		options []ParserOption
+		sep     TokenKind
+		delim   TokenKind
+		pairs   []pair
+		error   string
+	}{
+		{
+			"name=alice, age=32", []ParserOption{IgnoreWhitespaces()}, '=', ',',
+			[]pair{{"name", []string{"alice"}}, {"age", []string{"32"}}}, "",
+		},
+		{
+			"a: [1, 2]; b: f(x;y); c:", []ParserOption{IgnoreWhitespaces()}, ':', ';',
+			[]pair{{"a", []string{"[", "1", ",", "2", "]"}}, {"b", []string{"f", "(", "x", ";", "y", ")"}}, {"c", nil}}, "",
+		},
+		{
+			"0x10=\"sixteen\",,-1=true,", nil, '=', ',',
+			[]pair{{"0x10", []string{`"sixteen"`}}, {"-1", []string{"true"}}}, "",
+		},
+		{
+			"a=1,b", nil, '=', ',',
+			[]pair{{"a", []string{"1"}}}, "missing pair separator <unicode:'='> after key: 'b'",
+		},
+	}
+
+	for _, test := range tests {
+		var pairs []pair
+
+		parser := NewParser(test.input, test.options...)
+		err := parser.ParsePairs(test.sep, test.delim, func(key Token, value []Token) bool {
+			var literals []string
+			for _, token := range value {
+				literals = append(literals, token.Literal)
+			}
+
+			pairs = append(pairs, pair{key.Literal, literals})
+			return true
+		})
+
+		assert.Equal(t, test.pairs, pairs)
+
+		if test.error != "" {
+			assert.EqualError(t, err, test.error)
+		} else {
+			assert.NoError(t, err)
+			assert.True(t, parser.Exhausted())
+		}
+	}
+
+	t.Run("Recover Malformed", func(t *testing.T) {
+		var keys []Token
+
+		parser := NewParser("a=1, b c, d=4, e", IgnoreWhitespaces(), RecoverMalformed())
+		err := parser.ParsePairs('=', ',', func(key Token, _ []Token) bool {
+			keys = append(keys, key)
+			return true
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []Token{
+			{TokenIdent, "a", 0, 1, nil},
+			{TokenMalformed, "b c", 5, 8, nil},
+			{TokenIdent, "d", 10, 11, nil},
+			{TokenMalformed, "e", 15, 16, nil},
+		}, keys)
+	})
+
+	t.Run("Early Stop", func(t *testing.T) {
+		var keys []string
+
+		parser := NewParser("a=1,b=2,c=3")
+		err := parser.ParsePairs('=', ',', func(key Token, _ []Token) bool {
+			keys = append(keys, key.Literal)
+			return key.Literal != "b"
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, keys)
+		assert.Equal(t, ",c=3", parser.Unparsed())
+	})
+}
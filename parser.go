@@ -1,6 +1,12 @@
 package symbolizer
 
-import "fmt"
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
 
 // Parser is a symbol parser that parse a given string input and handle
 // operations like unwrapping enclosed data or splitting by a given delimiter
@@ -9,18 +15,30 @@ type Parser struct {
 	scanner *lexer
 	// curr and next represent the current and next Token values
 	curr, next Token
+	// errors represents the errors accumulated by the parser
+	errors *ErrorList
+	// depth represents the nesting depth of the parser's content
+	depth int
+	// scopes are the configurations of the lexer that were replaced by PushKeywords, in the order they were pushed
+	scopes []*parseConfig
 }
 
 // NewParser generates a new Parser for a given input string and some options that
 // modify the parser behaviour such as ignoring whitespaces or using custom keywords
 func NewParser(input string, opts ...ParserOption) *Parser {
 	// Create a parser instance with a token scanning lexer
-	parser := &Parser{
-		scanner: &lexer{
-			config:  newParseConfig(opts...),
-			symbols: []rune(input),
-		},
-	}
+	return newParser(newLexer([]byte(input), newParseConfig(opts...)))
+}
+
+// NewParserWithConfig generates a new Parser for a given input string with a compiled Config.
+// It is equivalent to calling NewParser with the options used to compile the Config.
+func NewParserWithConfig(input string, config *Config) *Parser {
+	return newParser(newLexer([]byte(input), config.config))
+}
+
+// newParser generates a new Parser for the given lexer and initializes its tokens
+func newParser(scanner *lexer) *Parser {
+	parser := &Parser{scanner: scanner, errors: new(ErrorList)}
 
 	// Advance the parser twice to initialize
 	// the curr and next Tokens of the parser
@@ -30,6 +48,47 @@ func NewParser(input string, opts ...ParserOption) *Parser {
 	return parser
 }
 
+// ResetInput resets the Parser to the start of a new input string, reusing its configuration and
+// input buffer. It clears any accumulated errors, which allows Parsers to be pooled (with sync.Pool)
+// by high-throughput callers. Since the input buffer is reused, any Parsers cloned from the Parser
+// must not be used after it is reset. Tokens generated before the reset remain valid.
+func (parser *Parser) ResetInput(input string) {
+	// Reslice to the full buffer, in case the lexer truncated it when terminated
+	buffer := parser.scanner.input[:0:cap(parser.scanner.input)]
+	parser.reset(append(buffer, input...))
+}
+
+// reset resets the Parser to the start of the given input buffer
+func (parser *Parser) reset(input []byte) {
+	// Restore the keywords of the parser, if any were pushed
+	if len(parser.scopes) != 0 {
+		parser.scanner.config, parser.scopes = parser.scopes[0], nil
+	}
+
+	parser.scanner.reset(input)
+
+	parser.curr, parser.next = Token{}, Token{}
+	parser.errors, parser.depth = new(ErrorList), 0
+
+	// Advance the parser twice to initialize
+	// the curr and next Tokens of the parser
+	parser.Advance()
+	parser.Advance()
+}
+
+// Clone returns an independent copy of the Parser that shares the underlying input.
+// The clone can be advanced to speculatively parse an alternative interpretation of the
+// input and simply discarded on failure, without affecting the state or errors of the Parser.
+func (parser *Parser) Clone() *Parser {
+	scanner := parser.scanner.fork()
+	errors := append(ErrorList(nil), *parser.errors...)
+
+	return &Parser{
+		scanner: scanner, curr: parser.curr, next: parser.next, errors: &errors, depth: parser.depth,
+		scopes: append([]*parseConfig(nil), parser.scopes...),
+	}
+}
+
 // Peek looks ahead and returns the next Token without advancing the parser
 func (parser *Parser) Peek() Token { return parser.next }
 
@@ -38,13 +97,105 @@ func (parser *Parser) Cursor() Token { return parser.curr }
 
 // Unparsed returns the remaining unparsed data in the parser as a string
 func (parser *Parser) Unparsed() string {
-	return string(parser.scanner.symbols)[parser.curr.Position:]
+	return parser.scanner.collectBetween(parser.curr.Position, len(parser.scanner.input))
+}
+
+// Exhausted returns whether the parser has consumed all of its input i.e, the cursor is at EoF
+func (parser *Parser) Exhausted() bool {
+	return parser.curr.Kind == TokenEoF
+}
+
+// RemainingTokens returns all the Tokens from the cursor until the end of the input, excluding
+// the EoF Token. The Tokens are scanned ahead without advancing the parser, so it can be used to
+// report trailing data after parsing has completed. Returns nil if the parser is exhausted.
+func (parser *Parser) RemainingTokens() (tokens []Token) {
+	if parser.Exhausted() {
+		return nil
+	}
+
+	tokens = append(tokens, parser.curr)
+	if parser.next.Kind == TokenEoF {
+		return tokens
+	}
+
+	// Scan the rest of the input from a copy of the lexer
+	remaining := parser.scanner.fork().tokens()
+
+	tokens = append(tokens, parser.next)
+	return append(tokens, remaining[:len(remaining)-1]...)
+}
+
+// SetIgnoreWhitespaces sets whether whitespaces are ignored by the parser from the cursor onwards, overriding the
+// IgnoreWhitespaces option for the rest of the input (until the parser is reset), since some segments of an input
+// may be whitespace-significant while others are not. The Token after the cursor is rescanned with the new
+// setting. If whitespaces are ignored and the cursor is a whitespace Token, the parser is advanced past it.
+func (parser *Parser) SetIgnoreWhitespaces(ignore bool) {
+	if parser.scanner.eatSpaces == ignore {
+		return
+	}
+
+	parser.scanner.eatSpaces = ignore
+	parser.rescanPeek()
+
+	if ignore && isSpaceToken(parser.curr) {
+		parser.Advance()
+	}
+}
+
+// rescanPeek rescans the Token after the cursor, after the settings of the lexer have changed.
+// The rescanned Token is not counted twice towards the token limit or observed twice by the metrics.
+func (parser *Parser) rescanPeek() {
+	if parser.Exhausted() {
+		return
+	}
+
+	if parser.next.Kind != TokenEoF {
+		parser.scanner.count--
+	}
+
+	parser.scanner.cursor = parser.curr.End
+	parser.next = parser.scanner.nextToken()
+	parser.recordStrictError(parser.next)
+	parser.recordScanError()
+}
+
+// withSpaces applies the whitespace setting of the extractConfig (if any) to the
+// parser and returns a function that restores the previous setting of the parser
+func (parser *Parser) withSpaces(config *extractConfig) (restore func()) {
+	previous := parser.scanner.eatSpaces
+
+	switch config.spaces {
+	case spacesScan:
+		parser.SetIgnoreWhitespaces(false)
+	case spacesIgnore:
+		parser.SetIgnoreWhitespaces(true)
+	}
+
+	return func() { parser.SetIgnoreWhitespaces(previous) }
+}
+
+// Next returns the Token at the parser's cursor and advances the parser, for consumers that iterate over Tokens
+// and fail fast. If the Token is the EoF Token at which the lexer was terminated (such as at a malformed symbol
+// with StrictMode or at an exceeded limit), the terminal Error of the lexer is returned along with it.
+func (parser *Parser) Next() (Token, error) {
+	token := parser.curr
+	parser.Advance()
+
+	if token.Kind == TokenEoF && parser.scanner.err != nil {
+		return token, parser.scanner.err
+	}
+
+	return token, nil
 }
 
 // Advance moves the parser's cursor and peek tokens
 func (parser *Parser) Advance() {
+	parser.traceConsume(parser.curr)
+
 	parser.curr = parser.next
-	parser.next = parser.scanner.next()
+	parser.next = parser.scanner.nextToken()
+	parser.recordStrictError(parser.next)
+	parser.recordScanError()
 }
 
 // IsPeek checks if the next token is of the specified TokenKind.
@@ -58,10 +209,65 @@ func (parser *Parser) IsCursor(t TokenKind) bool {
 	return parser.curr.Kind == t
 }
 
+// IsPeekAny checks if the next token is of any of the specified TokenKinds.
+// This look ahead is performed without moving the parser's cursor
+func (parser *Parser) IsPeekAny(kinds ...TokenKind) bool {
+	return matchKind(parser.next.Kind, kinds)
+}
+
+// IsCursorAny checks if the current token is of any of the specified TokenKinds.
+func (parser *Parser) IsCursorAny(kinds ...TokenKind) bool {
+	return matchKind(parser.curr.Kind, kinds)
+}
+
+// HasPrefixTokens checks if the Tokens from the cursor onwards are of the specified TokenKinds, in order.
+// Tokens after the peek Token are scanned ahead from a copy of the lexer without advancing the parser.
+func (parser *Parser) HasPrefixTokens(kinds ...TokenKind) bool {
+	var scanner *lexer
+
+	for idx, kind := range kinds {
+		var token Token
+
+		switch idx {
+		case 0:
+			token = parser.curr
+		case 1:
+			token = parser.next
+		default:
+			if scanner == nil {
+				scanner = parser.scanner.fork()
+			}
+
+			token = scanner.nextToken()
+		}
+
+		if token.Kind != kind {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ConsumePrefix advances the parser past the Tokens at the cursor if they match the given literal, such as
+// ConsumePrefix("::") for the Tokens ':' and ':'. The literal must span whole Tokens that are adjacent to each
+// other in the input. If they do not match, the parser does not advance. The returned boolean indicates if the
+// parser was advanced. It is useful to dispatch between multiple symbol formats that share a parser.
+func (parser *Parser) ConsumePrefix(literal string) bool {
+	if literal == "" || !parser.atSequence(literal) {
+		return false
+	}
+
+	parser.skipSequence(literal)
+	return true
+}
+
 // ExpectPeek advances the cursor if the next token is of the specified TokenKind.
 // If it is not the same type, the parser does not advance.
 // The returned boolean indicates if the parser was advanced.
 func (parser *Parser) ExpectPeek(t TokenKind) bool {
+	parser.trace("ExpectPeek", t)
+
 	// Check if peek token matches
 	if !parser.IsPeek(t) {
 		return false
@@ -73,26 +279,317 @@ func (parser *Parser) ExpectPeek(t TokenKind) bool {
 	return true
 }
 
+// ExpectPeekAny advances the cursor if the next token is of any of the specified TokenKinds.
+// If it does not match any of them, the parser does not advance. The returned boolean indicates
+// if the parser was advanced and the returned Token is the matched token (now under the cursor).
+func (parser *Parser) ExpectPeekAny(kinds ...TokenKind) (Token, bool) {
+	parser.trace("ExpectPeekAny", kinds)
+
+	// Check if peek token matches any kind
+	if !parser.IsPeekAny(kinds...) {
+		return Token{}, false
+	}
+
+	// Advance the parse cursor
+	parser.Advance()
+
+	return parser.curr, true
+}
+
+// Require returns the Token at the cursor and advances the parser if it is of the specified TokenKind.
+// Otherwise, the parser does not advance and an Error classified as ErrUnexpectedToken is returned at the
+// position of the cursor, which describes the expected TokenKind and the Token that was found instead.
+func (parser *Parser) Require(kind TokenKind) (Token, error) {
+	return parser.RequireAny(kind)
+}
+
+// RequireAny returns the Token at the cursor and advances the parser if it is of any of the specified
+// TokenKinds. Otherwise, the parser does not advance and an Error classified as ErrUnexpectedToken
+// is returned at the position of the cursor, like Require.
+func (parser *Parser) RequireAny(kinds ...TokenKind) (Token, error) {
+	parser.trace("RequireAny", kinds)
+
+	if !parser.IsCursorAny(kinds...) {
+		expected := make([]string, 0, len(kinds))
+		for _, kind := range kinds {
+			expected = append(expected, describeKind(kind))
+		}
+
+		return Token{}, parser.sentinelf(parser.curr.Position, ErrUnexpectedToken,
+			"expected %v, found %v", strings.Join(expected, " or "), describeToken(parser.curr))
+	}
+
+	token := parser.curr
+	parser.Advance()
+
+	return token, nil
+}
+
+// SkipUntil advances the parser until the cursor is a token of any of the specified TokenKinds
+// or the parser is exhausted. It is used to resynchronize the parser after encountering a
+// malformed construct. The returned Token is of kind TokenMalformed and spans the skipped
+// input, which is empty if the cursor was already at one of the specified TokenKinds.
+func (parser *Parser) SkipUntil(kinds ...TokenKind) Token {
+	parser.trace("SkipUntil", kinds)
+
+	start, end := parser.curr.Position, parser.curr.Position
+
+	for !parser.Exhausted() && !parser.IsCursorAny(kinds...) {
+		end = parser.curr.End
+		parser.Advance()
+	}
+
+	return Token{TokenMalformed, parser.scanner.collectBetween(start, end), start, end, nil}
+}
+
+// TakeUntil advances the parser until the cursor is a token of any of the specified TokenKinds or the parser
+// is exhausted, and returns the consumed tokens. The tokens retain their positions and any whitespace tokens
+// (unless whitespaces are ignored), so the spacing between them can be reconstructed. Returns nil if the
+// cursor is already of one of the specified TokenKinds.
+func (parser *Parser) TakeUntil(kinds ...TokenKind) (tokens []Token) {
+	parser.trace("TakeUntil", kinds)
+
+	for !parser.Exhausted() && !parser.IsCursorAny(kinds...) {
+		tokens = append(tokens, parser.curr)
+		parser.Advance()
+	}
+
+	return tokens
+}
+
+// SeekTo advances the parser until the cursor is a token of the specified TokenKind or the parser is exhausted.
+// The parser does not advance if the cursor is already of the specified TokenKind. The returned boolean
+// indicates if a token of the TokenKind was found, in which case it is under the cursor.
+func (parser *Parser) SeekTo(kind TokenKind) bool {
+	parser.trace("SeekTo", kind)
+
+	parser.SkipUntil(kind)
+	return parser.IsCursor(kind)
+}
+
 // Split attempts to split the remaining contents of the parser
 // into a set of strings separated by the given delimiting TokenKind.
 // This process exhausts the parser consuming all the tokens within it.
-func (parser *Parser) Split(delimiter TokenKind) (splits []string) {
+//
+// By default, the segments are the literals of the tokens between the delimiters, which excludes
+// any whitespaces ignored by the parser. Use the PreserveWhitespace option to control this explicitly.
+// Use the WithSpaces or WithoutSpaces options to control whether whitespaces are ignored while splitting,
+// such as to split by whitespaces with a parser that otherwise ignores them.
+func (parser *Parser) Split(delimiter TokenKind, opts ...ExtractOption) (splits []string) {
+	parser.trace("Split", delimiter)
+
+	config := newExtractConfig(opts...)
+	defer parser.withSpaces(config)()
+
+	for _, segment := range parser.splitAny([]TokenKind{delimiter}, config) {
+		splits = append(splits, segment.Data)
+	}
+
+	return splits
+}
+
+// SplitSpans splits the remaining contents of the parser like Split, but returns the start and end byte offsets
+// of each segment in the input instead of its data, for read-only consumers that slice the input themselves.
+// The spans include any whitespaces within the segments, such that slicing the input with each of them is
+// equivalent to Split with PreserveWhitespace(true) (except that escape characters are not stripped, see EscapeRune).
+// The TrimSpace, DropEmpty and MaxSegments options are supported, with TrimSpace narrowing the spans.
+// This process exhausts the parser consuming all the tokens within it.
+func (parser *Parser) SplitSpans(delimiter TokenKind, opts ...ExtractOption) (spans [][2]int) {
+	parser.trace("SplitSpans", delimiter)
+
+	config := newExtractConfig(opts...)
+
+	// appendSpan appends the span of the segment that ends at the cursor
+	start := parser.curr.Position
+	appendSpan := func() {
+		span := [2]int{start, parser.curr.Position}
+		if config.trim {
+			segment := parser.scanner.input[span[0]:span[1]]
+			span[0] += len(segment) - len(bytes.TrimLeftFunc(segment, unicode.IsSpace))
+			span[1] -= len(segment) - len(bytes.TrimRightFunc(segment, unicode.IsSpace))
+
+			if span[1] < span[0] {
+				span[1] = span[0]
+			}
+		}
+
+		if !config.dropEmpty || span[0] != span[1] {
+			spans = append(spans, span)
+		}
+	}
+
+	for {
+		switch {
+		case parser.curr.Kind == TokenEoF:
+			appendSpan()
+			return spans
+
+		case parser.curr.Kind == delimiter && (config.splits(len(spans)) || config.drops(string(parser.scanner.input[start:parser.curr.Position]))):
+			appendSpan()
+			start = parser.curr.End
+
+		// Skip over the escaped Token, if any
+		case parser.escaped():
+			parser.Advance()
+		}
+
+		parser.Advance()
+	}
+}
+
+// SplitLast splits the remaining contents of the parser at the last occurrence of the delimiter, such as to
+// separate a package path from its last element. Delimiters within strings or nested within parenthesis, square
+// or curly brackets are not considered, nor are escaped delimiters (see EscapeRune). The data before and after the
+// delimiter is returned as it appears in the input, like strings.Cut. If the delimiter is not found, the remaining
+// contents are returned as the head with ok set to false. This process exhausts the parser consuming all the tokens.
+func (parser *Parser) SplitLast(delimiter TokenKind) (head, tail string, ok bool) {
+	parser.trace("SplitLast", delimiter)
+
+	start, depth := parser.curr.Position, 0
+
+	var last Token
+	for ; !parser.Exhausted(); parser.Advance() {
+		switch kind := parser.curr.Kind; {
+		case parser.escaped():
+			parser.Advance()
+		case depth == 0 && kind == delimiter:
+			last, ok = parser.curr, true
+		case kind == '(' || kind == '[' || kind == '{':
+			depth++
+		case (kind == ')' || kind == ']' || kind == '}') && depth > 0:
+			depth--
+		}
+	}
+
+	stop := parser.curr.Position
+	if !ok {
+		return parser.scanner.collectUnescaped(start, stop, parser.escapesBetween(start, stop)), "", false
+	}
+
+	head = parser.scanner.collectUnescaped(start, last.Position, parser.escapesBetween(start, last.Position))
+	tail = parser.scanner.collectUnescaped(last.End, stop, parser.escapesBetween(last.End, stop))
+
+	return head, tail, true
+}
+
+// SplitQuoted attempts to split the remaining contents of the parser into a set of strings separated by the
+// given delimiting TokenKind, like a row of CSV fields. Delimiters within quoted strings do not split fields and
+// fields that are quoted strings are unquoted, such that `"a,b",c` is split into 'a,b' and 'c'. Adjacent quoted
+// strings within a field (such as `"say ""hi"""`) are joined with a quote, as quotes are escaped in CSV.
+// This process exhausts the parser consuming all the tokens within it.
+func (parser *Parser) SplitQuoted(delimiter TokenKind) (fields []string) {
+	parser.trace("SplitQuoted", delimiter)
+
+	for {
+		fields = append(fields, quotedField(parser.TakeUntil(delimiter)))
+		if parser.Exhausted() {
+			return fields
+		}
+
+		parser.Advance()
+	}
+}
+
+// quotedField returns the data of a field with the given Tokens. If the field only consists of adjacent
+// strings, they are unquoted and joined with a quote, otherwise the literals of the Tokens are joined.
+func quotedField(tokens []Token) string {
+	quoted := len(tokens) != 0
+	for idx, token := range tokens {
+		if token.Kind != TokenString || (idx > 0 && tokens[idx-1].End != token.Position) {
+			quoted = false
+			break
+		}
+	}
+
+	var field strings.Builder
+	for idx, token := range tokens {
+		if !quoted {
+			field.WriteString(token.Literal)
+			continue
+		}
+
+		if idx > 0 {
+			field.WriteByte('"')
+		}
+
+		value, _ := token.Value()
+		field.WriteString(value.(string))
+	}
+
+	return field.String()
+}
+
+// Segment represents a portion of the input produced by SplitAny along with the delimiting
+// TokenKind that terminated it. The Delimiter of the final segment is always TokenEoF.
+type Segment struct {
+	Data      string
+	Delimiter TokenKind
+}
+
+// SplitAny attempts to split the remaining contents of the parser into a set of Segments separated
+// by any of the given delimiting TokenKinds. Each Segment records the delimiter that terminated it,
+// allowing different delimiters to carry different semantics. This process exhausts the parser.
+func (parser *Parser) SplitAny(delimiters ...TokenKind) (segments []Segment) {
+	parser.trace("SplitAny", delimiters)
+
+	return parser.splitAny(delimiters, newExtractConfig())
+}
+
+// appendSegment appends the Segment to the segments, after trimming it with TrimSpace and omitting it
+// with DropEmpty, if enabled. The Delimiter of the final segment may not be TokenEoF with DropEmpty.
+func (config *extractConfig) appendSegment(segments []Segment, segment Segment) []Segment {
+	if config.drops(segment.Data) {
+		return segments
+	}
+
+	if config.trim {
+		segment.Data = strings.TrimFunc(segment.Data, unicode.IsSpace)
+	}
+
+	return append(segments, segment)
+}
+
+// splitAny splits the remaining contents of the parser into a set of Segments separated by
+// any of the given delimiting TokenKinds, with the whitespace behaviour of the extractConfig.
+func (parser *Parser) splitAny(delimiters []TokenKind, config *extractConfig) (segments []Segment) {
 	var accumulator string
+	// start is the byte offset of the start of the current segment
+	start := parser.curr.Position
+	// escapes are the byte offsets of the escape characters in the current segment
+	var escapes []int
+
+	// segment returns the data of the current segment that ends at the cursor
+	segment := func() string {
+		if config.whitespace == whitespacePreserve {
+			return parser.scanner.collectUnescaped(start, parser.curr.Position, escapes)
+		}
+
+		return accumulator
+	}
 
 Loop:
 	for {
-		switch parser.Cursor().Kind {
-		case delimiter:
-			// Append the accumulated characters and reset the accumulator
-			splits = append(splits, accumulator)
-			accumulator = ""
-
-		case TokenEoF:
+		switch kind := parser.Cursor().Kind; {
+		case kind == TokenEoF:
 			// Append accumulated characters
-			splits = append(splits, accumulator)
+			segments = config.appendSegment(segments, Segment{segment(), TokenEoF})
 			// Break from loop (end of symbol)
 			break Loop
 
+		case matchKind(kind, delimiters) && (config.splits(len(segments)) || config.drops(segment())):
+			// Append the accumulated characters and reset the accumulator
+			segments = config.appendSegment(segments, Segment{segment(), kind})
+			accumulator, start, escapes = "", parser.curr.End, nil
+
+		case parser.escaped():
+			// Accumulate the escaped character without the escape character
+			escapes = append(escapes, parser.curr.Position)
+			parser.Advance()
+			accumulator += parser.curr.Literal
+
+		case config.whitespace == whitespaceDrop && isSpaceToken(parser.curr):
+			// Skip whitespace characters
+
 		default:
 			// Accumulate character
 			accumulator += parser.curr.Literal
@@ -101,50 +598,391 @@ Loop:
 		parser.Advance()
 	}
 
-	return splits
+	return segments
 }
 
 // Unwrap attempts to unravel a substring enclosed between to characters described with an Enclosure.
-// When calling Unwrap, the parse cursor must be the opening character of the given Enclosure. Returns
-// an error if the opening character is not found or if the symbol terminates before the closing character.
+// When calling Unwrap, the parse cursor must be the opening character of the given Enclosure. Returns an
+// Error classified as ErrMissingEnclosureStart if the opening character is not found or as
+// ErrUnterminatedEnclosure if the symbol terminates before the closing character.
+//
+// By default, the enclosed data is returned as it appears in the input, which includes any whitespaces
+// ignored by the parser. Use the PreserveWhitespace option to control this explicitly.
 //
 // Note: Unwrap will resolve nested enclosures attempting to match one
 // opening character with one closing character until it fully resolves.
-func (parser *Parser) Unwrap(enc Enclosure) (string, error) {
+func (parser *Parser) Unwrap(enc Enclosure, opts ...ExtractOption) (string, error) {
+	parser.trace("Unwrap", enc)
+
+	start, stop, err := parser.enclosed(enc)
+	if err != nil {
+		return "", err
+	}
+
+	if newExtractConfig(opts...).whitespace == whitespaceDrop {
+		return parser.collectWithoutSpaces(start, stop), nil
+	}
+
+	return parser.scanner.collectUnescaped(start, stop, parser.escapesBetween(start, stop)), nil
+}
+
+// UnwrapTo unwraps the Enclosure at the cursor like Unwrap, but streams the enclosed data into the writer
+// instead of building a string, for very large enclosed payloads. The enclosure must be resolved before any
+// data is written, so nothing is written if Unwrap would return an error. Any error from the writer is returned.
+func (parser *Parser) UnwrapTo(w io.Writer, enc Enclosure, opts ...ExtractOption) error {
+	parser.trace("UnwrapTo", enc)
+
+	start, stop, err := parser.enclosed(enc)
+	if err != nil {
+		return err
+	}
+
+	if newExtractConfig(opts...).whitespace == whitespaceDrop {
+		return parser.writeWithoutSpaces(w, start, stop)
+	}
+
+	return parser.scanner.writeUnescaped(w, start, stop, parser.escapesBetween(start, stop))
+}
+
+// UnwrapAuto unwraps the Enclosure that opens at the cursor, choosing it from the given Enclosures by their
+// opening character, for grammars where multiple bracket styles are legal. If no Enclosures are given, the
+// predefined Enclosures (parenthesis, square, curly and angle brackets) are used. Returns the chosen Enclosure
+// along with the enclosed data. If the cursor does not open any of the Enclosures, an Error classified as
+// ErrMissingEnclosureStart is returned. Otherwise, it behaves like Unwrap with the chosen Enclosure.
+func (parser *Parser) UnwrapAuto(encs ...Enclosure) (string, Enclosure, error) {
+	if len(encs) == 0 {
+		encs = []Enclosure{EnclosureParens(), EnclosureSquare(), EnclosureCurly(), EnclosureAngle()}
+	}
+
+	for _, enc := range encs {
+		if parser.opens(enc) {
+			data, err := parser.Unwrap(enc)
+			return data, enc, err
+		}
+	}
+
+	starts := make([]string, 0, len(encs))
+	for _, enc := range encs {
+		starts = append(starts, enc.Open())
+	}
+
+	return "", Enclosure{}, parser.sentinelf(parser.curr.Position, ErrMissingEnclosureStart,
+		"missing start of enclosure: '%v'", strings.Join(starts, "' or '"))
+}
+
+// UnwrapAll unwraps every top-level Enclosure in the remaining contents of the parser (such as each of
+// the groups in 'f(a)(b)(c)') and returns the enclosed data of each of them in order. Tokens outside
+// the Enclosures are skipped. This process exhausts the parser consuming all the tokens within it.
+// If an Enclosure is not closed, the data of the preceding Enclosures is returned with the error.
+func (parser *Parser) UnwrapAll(enc Enclosure, opts ...ExtractOption) ([]string, error) {
+	parser.trace("UnwrapAll", enc)
+
+	var regions []string
+
+	for !parser.Exhausted() {
+		if !parser.opens(enc) {
+			parser.Advance()
+			continue
+		}
+
+		region, err := parser.Unwrap(enc, opts...)
+		if err != nil {
+			return regions, err
+		}
+
+		regions = append(regions, region)
+	}
+
+	return regions, nil
+}
+
+// enclosed resolves the Enclosure that opens at the cursor and returns the start and stop byte
+// offsets of the data enclosed within it. The parser is advanced past the closing character.
+func (parser *Parser) enclosed(enc Enclosure) (int, int, error) {
 	// Require the current token of the parser to be the enclosure opening token
-	if !parser.IsCursor(TokenKind(enc.start)) {
-		return "", fmt.Errorf("missing start of enclosure: '%v'", string(enc.start))
+	if !parser.opens(enc) {
+		return 0, 0, parser.sentinelf(parser.curr.Position, ErrMissingEnclosureStart, "missing start of enclosure: '%v'", enc.Open())
 	}
 
-	// Record the start of the enclosed data (1 position after enclose opener)
-	start := parser.curr.Position + 1
+	// Record the position of the enclose opener
+	opener := parser.curr.Position
 	// First enclose opener sets the nesting level to 1.
 	// This nesting level needs to be resolved for the enclosure to "end"
 	nesting := 1
+	if err := parser.checkDepth(nesting, opener); err != nil {
+		return 0, 0, err
+	}
 
-	// Advance the cursor into the enclosed data.
-	parser.Advance()
+	// Advance the cursor into the enclosed data, which starts at the end of the enclose opener
+	start := parser.skipSequence(enc.Open())
 
 	for {
-		switch parser.Cursor().Kind {
-		case TokenKind(enc.start):
-			// Increase nesting level, if new enclosure start is encountered
-			nesting++
-		case TokenKind(enc.stop):
+		switch {
+		case parser.Exhausted():
+			// premature end of symbol
+			return 0, 0, parser.sentinelf(opener, ErrUnterminatedEnclosure, "missing end of enclosure: '%v'", enc.Close())
+
+		case parser.escaped():
+			// Skip over the escape character and the escaped Token
+			parser.Advance()
+			parser.Advance()
+
+		case parser.closes(enc):
 			// Reduce nesting level, if new enclosure end is encountered
 			nesting--
 
-		case TokenEoF:
-			// premature end of symbol
-			return "", fmt.Errorf("missing end of enclosure: '%v'", string(enc.stop))
+			// If nesting is resolved, the stop point is the start of the enclose closer
+			if nesting == 0 {
+				stop := parser.curr.Position
+				parser.skipSequence(enc.Close())
+
+				return start, stop, nil
+			}
+
+			parser.skipSequence(enc.Close())
+
+		case parser.opens(enc):
+			// Increase nesting level, if new enclosure start is encountered
+			nesting++
+			if err := parser.checkDepth(nesting, parser.curr.Position); err != nil {
+				return 0, 0, err
+			}
+
+			parser.skipSequence(enc.Open())
+
+		default:
+			parser.Advance()
 		}
+	}
+}
 
+// opens returns whether the Enclosure opens at the cursor
+func (parser *Parser) opens(enc Enclosure) bool {
+	if enc.open == "" {
+		return parser.IsCursor(TokenKind(enc.start))
+	}
+
+	return parser.atSequence(enc.open)
+}
+
+// closes returns whether the Enclosure closes at the cursor
+func (parser *Parser) closes(enc Enclosure) bool {
+	if enc.close == "" {
+		return parser.IsCursor(TokenKind(enc.stop))
+	}
+
+	return parser.atSequence(enc.close)
+}
+
+// wraps returns whether the Tokens begin with the opening and end with the closing of the Enclosure
+func (parser *Parser) wraps(tokens []Token, enc Enclosure) bool {
+	if enc.open == "" {
+		return tokens[0].Kind == TokenKind(enc.start) && tokens[len(tokens)-1].Kind == TokenKind(enc.stop)
+	}
+
+	source := parser.scanner.collectBetween(tokens[0].Position, tokens[len(tokens)-1].End)
+	return len(source) >= len(enc.open)+len(enc.close) && strings.HasPrefix(source, enc.open) && strings.HasSuffix(source, enc.close)
+}
+
+// atSequence returns whether the input at the cursor begins with the character sequence and the
+// sequence spans whole Tokens that are adjacent to each other. Tokens after the peek Token are
+// scanned ahead from a copy of the lexer without advancing the parser.
+func (parser *Parser) atSequence(sequence string) bool {
+	position := parser.curr.Position
+	if parser.Exhausted() || !bytes.HasPrefix(parser.scanner.input[position:], []byte(sequence)) {
+		return false
+	}
+
+	end := position + len(sequence)
+	if parser.curr.End >= end {
+		return parser.curr.End == end
+	}
+
+	last := parser.next
+	if last.Position != parser.curr.End {
+		return false
+	}
+
+	var scanner *lexer
+	for last.End < end {
+		if scanner == nil {
+			scanner = parser.scanner.fork()
+		}
+
+		token := scanner.nextToken()
+		if token.Kind == TokenEoF || token.Position != last.End {
+			return false
+		}
+
+		last = token
+	}
+
+	return last.End == end
+}
+
+// skipSequence advances the parser past the Tokens of the character sequence at the
+// cursor (see atSequence) and returns the byte offset of the end of the sequence
+func (parser *Parser) skipSequence(sequence string) int {
+	end := parser.curr.Position + len(sequence)
+	for !parser.Exhausted() && parser.curr.Position < end {
 		parser.Advance()
+	}
+
+	return end
+}
+
+// enclosedParser resolves the Enclosure that opens at the cursor and returns a sub-parser for the data
+// enclosed within it, which is one nesting level deeper than the parser. The parser is advanced past
+// the closing character.
+func (parser *Parser) enclosedParser(enc Enclosure) (*Parser, error) {
+	start, stop, err := parser.enclosed(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	inner := parser.subParser(start, stop)
+	inner.depth++
+
+	return inner, nil
+}
+
+// descend increases the nesting depth of the parser for recursive parsing routines and returns an
+// error if the maximum depth is exceeded. Every call must be paired with a deferred call to ascend.
+func (parser *Parser) descend() error {
+	parser.depth++
+	return parser.checkDepth(0, parser.curr.Position)
+}
+
+// ascend decreases the nesting depth of the parser after a recursive parsing routine
+func (parser *Parser) ascend() { parser.depth-- }
+
+// checkDepth returns an error if the nesting depth of the parser with the
+// additional levels exceeds the maximum depth specified with MaxDepth.
+func (parser *Parser) checkDepth(levels, pos int) error {
+	if limit := parser.scanner.config.maxDepth; limit > 0 && parser.depth+levels > limit {
+		return parser.sentinelf(pos, ErrLimitExceeded, "maximum nesting depth exceeded: %d", limit)
+	}
+
+	return nil
+}
+
+// subParser generates a new Parser that shares the input and configuration of the parser
+// but only parses the input between the given byte offsets. Token positions produced by
+// the sub-parser remain relative to the complete input and it accumulates errors into the
+// same list as the parser at the same nesting depth.
+func (parser *Parser) subParser(start, stop int) *Parser {
+	sub := newParser(parser.rescan(start, stop))
+	sub.errors, sub.depth = parser.errors, parser.depth
 
-		if nesting == 0 {
-			// If nesting is resolved, slice input and return
-			// The stop point is 1 position before enclose closer
-			return parser.scanner.collectBetween(start, parser.curr.Position-1), nil
+	return sub
+}
+
+// rescan generates a new lexer that shares the configuration of the parser and rescans its input between the
+// given byte offsets. The Tokens of the input have already been observed, so they are not reported to the metrics.
+func (parser *Parser) rescan(start, stop int) *lexer {
+	scanner := newLexer(parser.scanner.input[:stop], parser.scanner.config)
+	scanner.cursor, scanner.metrics = start, nil
+	scanner.eatSpaces = parser.scanner.eatSpaces
+
+	return scanner
+}
+
+// collectWithoutSpaces collects the literals of all the tokens between the
+// specified byte offsets of the input, excluding any whitespace tokens.
+func (parser *Parser) collectWithoutSpaces(start, stop int) string {
+	var collected strings.Builder
+	_ = parser.writeWithoutSpaces(&collected, start, stop)
+
+	return collected.String()
+}
+
+// writeWithoutSpaces writes the literals of all the tokens between the specified
+// byte offsets of the input into the writer, excluding any whitespace tokens.
+func (parser *Parser) writeWithoutSpaces(w io.Writer, start, stop int) error {
+	scanner := parser.rescan(start, stop)
+
+	for token := scanner.nextToken(); token.Kind != TokenEoF; {
+		next := scanner.nextToken()
+
+		switch {
+		case parser.scanner.config.escapes(token, next):
+			// Write the escaped Token without the escape character
+			token, next = next, scanner.nextToken()
+		case isSpaceToken(token):
+			token = next
+			continue
 		}
+
+		if _, err := io.WriteString(w, token.Literal); err != nil {
+			return err
+		}
+
+		token = next
+	}
+
+	return nil
+}
+
+// escaped returns whether the cursor is an escape character that escapes the peek Token (see EscapeRune)
+func (parser *Parser) escaped() bool {
+	return parser.scanner.config.escapes(parser.curr, parser.next)
+}
+
+// escapesBetween returns the byte offsets of the escape characters between the specified byte offsets of the input
+func (parser *Parser) escapesBetween(start, stop int) (escapes []int) {
+	if parser.scanner.config.escape == 0 {
+		return nil
 	}
+
+	scanner := parser.rescan(start, stop)
+
+	for token := scanner.nextToken(); token.Kind != TokenEoF; {
+		next := scanner.nextToken()
+
+		// Skip over the escaped Token
+		if parser.scanner.config.escapes(token, next) {
+			escapes = append(escapes, token.Position)
+			next = scanner.nextToken()
+		}
+
+		token = next
+	}
+
+	return escapes
+}
+
+// isSpaceToken returns whether the Token is a unicode whitespace character
+func isSpaceToken(token Token) bool {
+	return token.Kind > 0 && unicode.IsSpace(rune(token.Kind))
+}
+
+// describeKind returns a description of the TokenKind for error messages,
+// which is the quoted character for unicode TokenKinds (such as '(')
+func describeKind(kind TokenKind) string {
+	if kind > 0 {
+		return fmt.Sprintf("'%v'", string(kind))
+	}
+
+	return kind.String()
+}
+
+// describeToken returns a description of the Token for error messages, which is
+// its TokenKind (see describeKind) followed by its literal for non-unicode Tokens
+func describeToken(token Token) string {
+	if token.Kind > 0 || token.Kind == TokenEoF {
+		return describeKind(token.Kind)
+	}
+
+	return fmt.Sprintf("%v '%v'", token.Kind, token.Literal)
+}
+
+// matchKind returns whether the given TokenKind is present in the set of kinds
+func matchKind(kind TokenKind, kinds []TokenKind) bool {
+	for _, k := range kinds {
+		if kind == k {
+			return true
+		}
+	}
+
+	return false
 }
@@ -1,6 +1,9 @@
 package symbolizer
 
 import (
+	"bytes"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,6 +41,61 @@ func TestParser_Split(t *testing.T) {
 	}
 }
 
+func TestParser_SplitQuoted(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		fields  []string
+	}{
+		{`"a,b",c`, nil, []string{"a,b", "c"}},
+		{`"say ""hi""",2,,"x"`, nil, []string{`say "hi"`, "2", "", "x"}},
+		{`"a" , "b"`, []ParserOption{IgnoreWhitespaces()}, []string{"a", "b"}},
+		{`"a" b,"c`, nil, []string{`"a" b`, `"c`}},
+		{`""`, nil, []string{""}},
+		{``, nil, []string{""}},
+		{`a,`, nil, []string{"a", ""}},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+		assert.Equal(t, test.fields, parser.SplitQuoted(','), test.input)
+		assert.True(t, parser.Exhausted())
+	}
+}
+
+func TestParser_SplitAny(t *testing.T) {
+	tests := []struct {
+		inputs   string
+		options  []ParserOption
+		delims   []TokenKind
+		segments []Segment
+	}{
+		{
+			"a,b;c", nil,
+			[]TokenKind{',', ';'}, []Segment{{"a", ','}, {"b", ';'}, {"c", TokenEoF}},
+		},
+		{
+			"1, 2; 3;", []ParserOption{IgnoreWhitespaces()},
+			[]TokenKind{',', ';'}, []Segment{{"1", ','}, {"2", ';'}, {"3", ';'}, {"", TokenEoF}},
+		},
+		{
+			"hello-world", nil,
+			[]TokenKind{',', ';'}, []Segment{{"hello-world", TokenEoF}},
+		},
+		{
+			"", nil,
+			nil, []Segment{{"", TokenEoF}},
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.inputs, test.options...)
+		segments := parser.SplitAny(test.delims...)
+		assert.Equal(t, test.segments, segments)
+		assert.True(t, parser.Exhausted())
+	}
+}
+
 func TestParser_Unwrap(t *testing.T) {
 	// mustEnclose is a helper function
 	mustEnclose := func(enclosure Enclosure, err error) Enclosure {
@@ -72,6 +130,10 @@ func TestParser_Unwrap(t *testing.T) {
 			"@sarah[chapman&", nil, mustEnclose(NewEnclosure('@', '&')),
 			"sarah[chapman", "", "",
 		},
+		{
+			"«ünï«code»»€", nil, mustEnclose(NewEnclosure('«', '»')),
+			"ünï«code»", "", "€",
+		},
 		{
 			"( 12345(555))hello123", []ParserOption{IgnoreWhitespaces()}, EnclosureParens(),
 			" 12345(555)", "", "hello123",
@@ -99,6 +161,447 @@ func TestParser_Unwrap(t *testing.T) {
 	}
 }
 
+func TestParser_PreserveWhitespace(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		extract []ExtractOption
+		splits  []string
+		unwrap  string
+	}{
+		{
+			"( a, \"b c\" ,d )", nil, nil,
+			[]string{"( a", " \"b c\" ", "d )"}, " a, \"b c\" ,d ",
+		},
+		{
+			"( a, \"b c\" ,d )", []ParserOption{IgnoreWhitespaces()}, nil,
+			[]string{"(a", "\"b c\"", "d)"}, " a, \"b c\" ,d ",
+		},
+		{
+			"( a, \"b c\" ,d )", []ParserOption{IgnoreWhitespaces()}, []ExtractOption{PreserveWhitespace(true)},
+			[]string{"( a", " \"b c\" ", "d )"}, " a, \"b c\" ,d ",
+		},
+		{
+			"( a, \"b c\" ,d )", nil, []ExtractOption{PreserveWhitespace(false)},
+			[]string{"(a", "\"b c\"", "d)"}, "a,\"b c\",d",
+		},
+		{
+			"( a, \"b c\" ,d )", []ParserOption{IgnoreWhitespaces()}, []ExtractOption{PreserveWhitespace(false)},
+			[]string{"(a", "\"b c\"", "d)"}, "a,\"b c\",d",
+		},
+	}
+
+	for _, test := range tests {
+		splits := NewParser(test.input, test.options...).Split(',', test.extract...)
+		assert.Equal(t, test.splits, splits, test.input)
+
+		unwrapped, err := NewParser(test.input, test.options...).Unwrap(EnclosureParens(), test.extract...)
+		assert.NoError(t, err)
+		assert.Equal(t, test.unwrap, unwrapped, test.input)
+
+		var buffer bytes.Buffer
+		assert.NoError(t, NewParser(test.input, test.options...).UnwrapTo(&buffer, EnclosureParens(), test.extract...))
+		assert.Equal(t, test.unwrap, buffer.String(), test.input)
+	}
+}
+
+func TestParser_WithSpaces(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		delim   TokenKind
+		extract []ExtractOption
+		splits  []string
+	}{
+		{"a b  c", []ParserOption{IgnoreWhitespaces()}, ' ', nil, []string{"abc"}},
+		{"a b  c", []ParserOption{IgnoreWhitespaces()}, ' ', []ExtractOption{WithSpaces()}, []string{"a", "b", "", "c"}},
+		{"a, b c", []ParserOption{IgnoreWhitespaces()}, ',', []ExtractOption{WithSpaces()}, []string{"a", " b c"}},
+		{"a , b c", nil, ',', []ExtractOption{WithoutSpaces()}, []string{"a", "bc"}},
+		{"a , b c", nil, ',', []ExtractOption{WithoutSpaces(), PreserveWhitespace(true)}, []string{"a ", " b c"}},
+	}
+
+	for _, test := range tests {
+		splits := NewParser(test.input, test.options...).Split(test.delim, test.extract...)
+		assert.Equal(t, test.splits, splits, test.input)
+	}
+}
+
+func TestParser_SetIgnoreWhitespaces(t *testing.T) {
+	// The command is whitespace-significant while its arguments are not
+	parser := NewParser("run a b , c d", IgnoreWhitespaces())
+	parser.SetIgnoreWhitespaces(false)
+	assert.Equal(t, Token{TokenKind(' '), " ", 3, 4, nil}, parser.Peek())
+
+	command, err := parser.Require(TokenIdent)
+	assert.NoError(t, err)
+	assert.Equal(t, "run", command.Literal)
+
+	// The whitespace at the cursor is skipped once whitespaces are ignored
+	parser.SetIgnoreWhitespaces(true)
+	assert.Equal(t, Token{TokenIdent, "a", 4, 5, nil}, parser.Cursor())
+	assert.Equal(t, Token{TokenIdent, "b", 6, 7, nil}, parser.Peek())
+
+	// The setting of the parser is restored after splitting with whitespaces
+	clone := parser.Clone()
+	assert.Equal(t, []string{"a", "b", ",", "c", "d"}, clone.Split(' ', WithSpaces()))
+	assert.Equal(t, []string{"ab", "cd"}, parser.Split(','))
+
+	// The token limit is not exceeded by rescanning the Token after the cursor
+	parser = NewParser("a b", MaxTokens(3))
+	parser.SetIgnoreWhitespaces(true)
+	parser.SetIgnoreWhitespaces(false)
+	assert.Equal(t, []Token{{TokenIdent, "a", 0, 1, nil}, {TokenKind(' '), " ", 1, 2, nil}, {TokenIdent, "b", 2, 3, nil}}, parser.RemainingTokens())
+	assert.Empty(t, parser.Errors())
+}
+
+func TestParser_EscapeRune(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		splits  []string
+		unwrap  string
+	}{
+		{`(a\, b), c`, nil, []string{`(a, b)`, ` c`}, `a, b`},
+		{`(a\) b), c`, nil, []string{`(a) b)`, ` c`}, `a) b`},
+		{`(a\\, b), c`, nil, []string{`(a\`, ` b)`, ` c`}, `a\, b`},
+		{`(a\n, b), c`, nil, []string{`(a\n`, ` b)`, ` c`}, `a\n, b`},
+		{`(a\,  "b\,") c`, []ParserOption{IgnoreWhitespaces()}, []string{`(a,"b\,")c`}, `a,  "b\,"`},
+		{`(a\, b), c`, []ParserOption{EscapeRune(0)}, []string{`(a\`, ` b)`, ` c`}, `a\, b`},
+	}
+
+	for _, test := range tests {
+		options := append([]ParserOption{EscapeRune('\\')}, test.options...)
+
+		splits := NewParser(test.input, options...).Split(',')
+		assert.Equal(t, test.splits, splits, test.input)
+
+		unwrapped, err := NewParser(test.input, options...).Unwrap(EnclosureParens())
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.unwrap, unwrapped, test.input)
+
+		var buffer bytes.Buffer
+		assert.NoError(t, NewParser(test.input, options...).UnwrapTo(&buffer, EnclosureParens()), test.input)
+		assert.Equal(t, test.unwrap, buffer.String(), test.input)
+	}
+
+	// Escape characters are stripped with whitespaces preserved or dropped
+	parser := NewParser(`a\,b , c\ d`, EscapeRune('\\'))
+	assert.Equal(t, []string{"a,b ", " c d"}, parser.Split(',', PreserveWhitespace(true)))
+
+	parser = NewParser(`a\,b , c\ d`, EscapeRune('\\'))
+	assert.Equal(t, []string{"a,b", "c d"}, parser.Split(',', PreserveWhitespace(false)))
+
+	unwrapped, err := NewParser(`(a \) , b\ c)`, EscapeRune('\\')).Unwrap(EnclosureParens(), PreserveWhitespace(false))
+	assert.NoError(t, err)
+	assert.Equal(t, "a),b c", unwrapped)
+
+	assert.Equal(t, [][2]int{{0, 4}, {5, 6}}, NewParser(`a\,b,c`, EscapeRune('\\')).SplitSpans(','))
+}
+
+func TestParser_SplitSpans(t *testing.T) {
+	tests := []struct {
+		input   string
+		options []ParserOption
+		spans   [][2]int
+	}{
+		{"a, b c,d", nil, [][2]int{{0, 1}, {2, 6}, {7, 8}}},
+		{"a, b c,d", []ParserOption{IgnoreWhitespaces()}, [][2]int{{0, 1}, {2, 6}, {7, 8}}},
+		{",a,", nil, [][2]int{{0, 0}, {1, 2}, {3, 3}}},
+		{"", nil, [][2]int{{0, 0}}},
+		{"ü,\"x,y\"", nil, [][2]int{{0, 2}, {3, 8}}},
+	}
+
+	for _, test := range tests {
+		spans := NewParser(test.input, test.options...).SplitSpans(',')
+		assert.Equal(t, test.spans, spans, test.input)
+
+		// Slicing the input with the spans is equivalent to splitting it with whitespace preserved
+		var segments []string
+		for _, span := range spans {
+			segments = append(segments, test.input[span[0]:span[1]])
+		}
+
+		assert.Equal(t, NewParser(test.input, test.options...).Split(',', PreserveWhitespace(true)), segments, test.input)
+	}
+}
+
+func TestParser_SplitPolicies(t *testing.T) {
+	tests := []struct {
+		input    string
+		opts     []ExtractOption
+		segments []string
+		spans    [][2]int
+	}{
+		{" a , b ,c ", []ExtractOption{TrimSpace()}, []string{"a", "b", "c"}, [][2]int{{1, 2}, {5, 6}, {8, 9}}},
+		{"a,,b,", []ExtractOption{DropEmpty()}, []string{"a", "b"}, [][2]int{{0, 1}, {3, 4}}},
+		{"a, ,b", []ExtractOption{DropEmpty()}, []string{"a", " ", "b"}, [][2]int{{0, 1}, {2, 3}, {4, 5}}},
+		{"a, ,b", []ExtractOption{TrimSpace(), DropEmpty()}, []string{"a", "b"}, [][2]int{{0, 1}, {4, 5}}},
+		{"a,b,c,d", []ExtractOption{MaxSegments(2)}, []string{"a", "b,c,d"}, [][2]int{{0, 1}, {2, 7}}},
+		{"a,b,c", []ExtractOption{MaxSegments(1)}, []string{"a,b,c"}, [][2]int{{0, 5}}},
+		{"a,b", []ExtractOption{MaxSegments(5)}, []string{"a", "b"}, [][2]int{{0, 1}, {2, 3}}},
+		{",,a,b,c", []ExtractOption{DropEmpty(), MaxSegments(2)}, []string{"a", "b,c"}, [][2]int{{2, 3}, {4, 7}}},
+		{"a,,b,c", []ExtractOption{DropEmpty(), MaxSegments(2)}, []string{"a", "b,c"}, [][2]int{{0, 1}, {3, 6}}},
+		{"a, ,b,c", []ExtractOption{TrimSpace(), DropEmpty(), MaxSegments(2)}, []string{"a", "b,c"}, [][2]int{{0, 1}, {4, 7}}},
+	}
+
+	for _, test := range tests {
+		opts := append([]ExtractOption{PreserveWhitespace(true)}, test.opts...)
+		assert.Equal(t, test.segments, NewParser(test.input).Split(',', opts...), test.input)
+		assert.Equal(t, test.spans, NewParser(test.input).SplitSpans(',', test.opts...), test.input)
+	}
+}
+
+func TestParser_SplitLast(t *testing.T) {
+	tests := []struct {
+		input      string
+		options    []ParserOption
+		head, tail string
+		ok         bool
+	}{
+		{"github.com/a/b/c", nil, "github.com/a/b", "c", true},
+		{"a/b", []ParserOption{IgnoreWhitespaces()}, "a", "b", true},
+		{"a / b", []ParserOption{IgnoreWhitespaces()}, "a ", " b", true},
+		{`a/"b/c"`, nil, "a", `"b/c"`, true},
+		{"a/f(b/c)", nil, "a", "f(b/c)", true},
+		{"a/[b/{c/d}]", nil, "a", "[b/{c/d}]", true},
+		{"a/", nil, "a", "", true},
+		{"abc", nil, "abc", "", false},
+		{"", nil, "", "", false},
+		{`a/b\/c`, []ParserOption{EscapeRune('\\')}, "a", "b/c", true},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+
+		head, tail, ok := parser.SplitLast('/')
+		assert.Equal(t, test.ok, ok, test.input)
+		assert.Equal(t, test.head, head, test.input)
+		assert.Equal(t, test.tail, tail, test.input)
+		assert.True(t, parser.Exhausted(), test.input)
+	}
+}
+
+func TestParser_HasPrefixTokens(t *testing.T) {
+	parser := NewParser("a::b(c)", IgnoreWhitespaces())
+
+	assert.True(t, parser.HasPrefixTokens())
+	assert.True(t, parser.HasPrefixTokens(TokenIdent))
+	assert.True(t, parser.HasPrefixTokens(TokenIdent, ':', ':', TokenIdent, '('))
+	assert.False(t, parser.HasPrefixTokens(TokenIdent, ':', '.'))
+	assert.False(t, parser.HasPrefixTokens(TokenIdent, ':', ':', TokenIdent, '(', TokenIdent, ')', TokenIdent))
+
+	// The parser is not advanced
+	assert.Equal(t, "a", parser.Cursor().Literal)
+	assert.Equal(t, ":", parser.Peek().Literal)
+
+	parser.SeekTo(')')
+	assert.True(t, parser.HasPrefixTokens(')', TokenEoF))
+}
+
+func TestParser_ConsumePrefix(t *testing.T) {
+	tests := []struct {
+		input   string
+		prefix  string
+		options []ParserOption
+		matched bool
+		cursor  string
+	}{
+		{"::a", "::", nil, true, "a"},
+		{"::a", ":", nil, true, ":"},
+		{"::a", "::a", nil, true, ""},
+		{": :a", "::", nil, false, ":"},
+		{": :a", "::", []ParserOption{IgnoreWhitespaces()}, false, ":"},
+		{"abc.d", "ab", nil, false, "abc"},
+		{"abc.d", "abc.", nil, true, "d"},
+		{"abc", "", nil, false, "abc"},
+		{"", "a", nil, false, ""},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+
+		assert.Equal(t, test.matched, parser.ConsumePrefix(test.prefix), test.input)
+		assert.Equal(t, test.cursor, parser.Cursor().Literal, test.input)
+	}
+}
+
+// failingWriter is an io.Writer that fails after writing the given number of bytes
+type failingWriter struct {
+	remaining int
+}
+
+func (writer *failingWriter) Write(data []byte) (int, error) {
+	if len(data) > writer.remaining {
+		written := writer.remaining
+		writer.remaining = 0
+
+		return written, io.ErrShortWrite
+	}
+
+	writer.remaining -= len(data)
+	return len(data), nil
+}
+
+func TestParser_UnwrapTo(t *testing.T) {
+	payload := strings.Repeat("[a, 0x1f, \"b\"] ", 1<<16)
+	parser := NewParser("f("+payload+") g", IgnoreWhitespaces())
+	parser.Advance()
+
+	var buffer bytes.Buffer
+	assert.NoError(t, parser.UnwrapTo(&buffer, EnclosureParens()))
+	assert.Equal(t, payload, buffer.String())
+	assert.Equal(t, Token{TokenIdent, "g", len(payload) + 4, len(payload) + 5, nil}, parser.Cursor())
+
+	// Nothing is written if the enclosure is not terminated
+	buffer.Reset()
+	err := NewParser("(a, b").UnwrapTo(&buffer, EnclosureParens())
+	assert.ErrorIs(t, err, ErrUnterminatedEnclosure)
+	assert.Zero(t, buffer.Len())
+
+	// Errors from the writer are returned
+	err = NewParser("(a, b)").UnwrapTo(&failingWriter{remaining: 2}, EnclosureParens())
+	assert.Equal(t, io.ErrShortWrite, err)
+
+	err = NewParser("(a, b)").UnwrapTo(&failingWriter{remaining: 1}, EnclosureParens(), PreserveWhitespace(false))
+	assert.Equal(t, io.ErrShortWrite, err)
+}
+
+func TestNewSequenceEnclosure(t *testing.T) {
+	enc, err := NewSequenceEnclosure("<%", "%>")
+	assert.NoError(t, err)
+	assert.Equal(t, "<%", enc.Open())
+	assert.Equal(t, "%>", enc.Close())
+
+	// Single character sequences are equivalent to an Enclosure of code points
+	enc, err = NewSequenceEnclosure("«", "»")
+	assert.NoError(t, err)
+	assert.Equal(t, Enclosure{start: '«', stop: '»'}, enc)
+
+	_, err = NewSequenceEnclosure("[[", "")
+	assert.EqualError(t, err, "enclosure start and stop cannot be empty")
+
+	_, err = NewSequenceEnclosure("**", "**")
+	assert.EqualError(t, err, "enclosure start and stop cannot be the same")
+}
+
+func TestParser_UnwrapSequence(t *testing.T) {
+	template, _ := NewSequenceEnclosure("<%", "%>")
+	comment, _ := NewSequenceEnclosure("/*", "*/")
+	wiki, _ := NewSequenceEnclosure("[[", "]]")
+
+	tests := []struct {
+		input    string
+		options  []ParserOption
+		enclose  Enclosure
+		output   string
+		unparsed string
+		err      string
+	}{
+		{"<% a <% b %> % > %> c", nil, template, " a <% b %> % > ", " c", ""},
+		{"/* x * / y */z", nil, comment, " x * / y ", "z", ""},
+		{"[[a [b] [[c]]]] d", nil, wiki, "a [b] [[c]]", " d", ""},
+		{"[[a]] b", []ParserOption{IgnoreWhitespaces()}, wiki, "a", "b", ""},
+		{"[ [a]]", []ParserOption{IgnoreWhitespaces()}, wiki, "", "[ [a]]", "missing start of enclosure: '[['"},
+		{"[[a]", nil, wiki, "", "", "missing end of enclosure: ']]'"},
+		{"<%", nil, template, "", "", "missing end of enclosure: '%>'"},
+		{`<% "%>" %>`, nil, template, ` "%>" `, "", ""},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+		unwrapped, err := parser.Unwrap(test.enclose)
+
+		assert.Equal(t, test.output, unwrapped, test.input)
+		assert.Equal(t, test.unparsed, parser.Unparsed(), test.input)
+
+		if test.err == "" {
+			assert.NoError(t, err, test.input)
+		} else {
+			assert.EqualError(t, err, test.err, test.input)
+		}
+	}
+
+	comments, err := NewParser("/*a*/ x /*b*/").UnwrapAll(comment)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, comments)
+
+	data, enc, err := NewParser("[[a]]").UnwrapAuto(template, wiki)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", data)
+	assert.Equal(t, wiki, enc)
+
+	curly, _ := NewSequenceEnclosure("{{", "}}")
+	group, err := NewParser("{{a: 1, b: {{c: true}}}}", IgnoreWhitespaces()).KeyedGroup(curly, ':', ',')
+	assert.NoError(t, err)
+	assert.Equal(t, map[any]any{"a": uint64(1), "b": map[any]any{"c": true}}, group)
+}
+
+func TestParser_UnwrapAuto(t *testing.T) {
+	tests := []struct {
+		input    string
+		encs     []Enclosure
+		data     string
+		enc      Enclosure
+		err      string
+		unparsed string
+	}{
+		{"(a[b]) c", nil, "a[b]", EnclosureParens(), "", " c"},
+		{"[a(b)] c", nil, "a(b)", EnclosureSquare(), "", " c"},
+		{"{a} c", []Enclosure{EnclosureSquare(), EnclosureCurly()}, "a", EnclosureCurly(), "", " c"},
+		{"<a>", []Enclosure{EnclosureParens()}, "", Enclosure{}, "missing start of enclosure: '('", "<a>"},
+		{"a", nil, "", Enclosure{}, "missing start of enclosure: '(' or '[' or '{' or '<'", "a"},
+		{"[a", nil, "", EnclosureSquare(), "missing end of enclosure: ']'", ""},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input)
+		data, enc, err := parser.UnwrapAuto(test.encs...)
+
+		assert.Equal(t, test.data, data, test.input)
+		assert.Equal(t, test.enc, enc, test.input)
+		assert.Equal(t, test.unparsed, parser.Unparsed(), test.input)
+
+		if test.err == "" {
+			assert.NoError(t, err, test.input)
+		} else {
+			assert.EqualError(t, err, test.err, test.input)
+		}
+	}
+
+	_, _, err := NewParser("x").UnwrapAuto()
+	assert.ErrorIs(t, err, ErrMissingEnclosureStart)
+}
+
+func TestParser_UnwrapAll(t *testing.T) {
+	tests := []struct {
+		input   string
+		enclose Enclosure
+		output  []string
+		error   string
+	}{
+		{"f(a)(b)(c)", EnclosureParens(), []string{"a", "b", "c"}, ""},
+		{"x[1] + y[2][z[3]]", EnclosureSquare(), []string{"1", "2", "z[3]"}, ""},
+		{"a) (b) c", EnclosureParens(), []string{"b"}, ""},
+		{"()", EnclosureParens(), []string{""}, ""},
+		{"none", EnclosureParens(), nil, ""},
+		{"(a) (b", EnclosureParens(), []string{"a"}, "missing end of enclosure: ')'"},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input)
+		regions, err := parser.UnwrapAll(test.enclose)
+		assert.Equal(t, test.output, regions, test.input)
+
+		if test.error != "" {
+			assert.EqualError(t, err, test.error, test.input)
+		} else {
+			assert.NoError(t, err, test.input)
+		}
+
+		assert.True(t, parser.Exhausted())
+	}
+}
+
 func TestParser_Unparsed(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -155,3 +658,490 @@ func TestParser_Peeking(t *testing.T) {
 		}
 	}
 }
+
+func TestParser_RemainingTokens(t *testing.T) {
+	tests := []struct {
+		input     string
+		options   []ParserOption
+		advances  int
+		remaining []Token
+	}{
+		{
+			"[32]uint64", nil, 3,
+			[]Token{{TokenIdent, "uint64", 4, 10, nil}},
+		},
+		{
+			"map[string] string", []ParserOption{IgnoreWhitespaces()}, 1,
+			[]Token{{TokenKind('['), "[", 3, 4, nil}, {TokenIdent, "string", 4, 10, nil}, {TokenKind(']'), "]", 10, 11, nil}, {TokenIdent, "string", 12, 18, nil}},
+		},
+		{
+			"0x45, 32", nil, 1,
+			[]Token{{TokenKind(','), ",", 4, 5, nil}, UnicodeToken(' ', 5), {TokenNumber, "32", 6, 8, nil}},
+		},
+		{
+			"hello", nil, 1,
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+		for i := 0; i < test.advances; i++ {
+			parser.Advance()
+		}
+
+		cursor, peek := parser.Cursor(), parser.Peek()
+
+		assert.Equal(t, test.remaining, parser.RemainingTokens())
+		assert.Equal(t, test.remaining == nil, parser.Exhausted())
+
+		// Parser must not have been advanced
+		assert.Equal(t, cursor, parser.Cursor())
+		assert.Equal(t, peek, parser.Peek())
+	}
+}
+
+func TestNewParserWithConfig(t *testing.T) {
+	options := []ParserOption{IgnoreWhitespaces(), Keywords(map[string]TokenKind{"uint": -10})}
+	config := CompileConfig(options...)
+
+	for _, input := range []string{"map[string] uint", "(uint, true)", ""} {
+		compiled, parser := NewParserWithConfig(input, config), NewParser(input, options...)
+		assert.Equal(t, parser.RemainingTokens(), compiled.RemainingTokens(), input)
+	}
+}
+
+func TestParser_ResetInput(t *testing.T) {
+	parser := NewParser("(a, b) c", IgnoreWhitespaces(), MaxInputBytes(16))
+
+	_, err := parser.KeyedGroup(EnclosureParens(), '=', ',')
+	assert.Error(t, err)
+	assert.Equal(t, 1, parser.Errors().Len())
+
+	for _, input := range []string{"[x]", "map[string]uint64", "(key = value, count = 1) rest"} {
+		parser.ResetInput(input)
+
+		fresh := NewParser(input, IgnoreWhitespaces(), MaxInputBytes(16))
+		assert.Equal(t, fresh.Errors(), parser.Errors(), input)
+		assert.Equal(t, fresh.RemainingTokens(), parser.RemainingTokens(), input)
+	}
+
+	// Errors from the input limit of a previous input must not persist
+	parser.ResetInput("(a = 1)")
+	group, err := parser.KeyedGroup(EnclosureParens(), '=', ',')
+	assert.NoError(t, err)
+	assert.Equal(t, map[any]any{"a": uint64(1)}, group)
+	assert.Equal(t, 0, parser.Errors().Len())
+}
+
+func TestNewParser_DefaultKeywords(t *testing.T) {
+	tests := []struct {
+		options []ParserOption
+		kinds   []TokenKind
+	}{
+		{nil, []TokenKind{TokenBoolean, TokenBoolean, TokenIdent}},
+		{[]ParserOption{NoDefaultKeywords()}, []TokenKind{TokenIdent, TokenIdent, TokenIdent}},
+		{[]ParserOption{Keywords(map[string]TokenKind{"null": -10}), NoDefaultKeywords()}, []TokenKind{TokenIdent, TokenIdent, -10}},
+		{[]ParserOption{NoDefaultKeywords(), Keywords(map[string]TokenKind{"true": -11})}, []TokenKind{-11, TokenIdent, TokenIdent}},
+		{[]ParserOption{Keywords(map[string]TokenKind{"false": -11})}, []TokenKind{TokenBoolean, -11, TokenIdent}},
+		{[]ParserOption{DisableDefaultBooleans()}, []TokenKind{TokenIdent, TokenIdent, TokenIdent}},
+		{[]ParserOption{Keywords(map[string]TokenKind{"true": TokenBoolean}), DisableDefaultBooleans()}, []TokenKind{TokenBoolean, TokenIdent, TokenIdent}},
+	}
+
+	for _, test := range tests {
+		options := append([]ParserOption{IgnoreWhitespaces()}, test.options...)
+		parser := NewParser("true false null", options...)
+
+		var kinds []TokenKind
+		for _, token := range parser.RemainingTokens() {
+			kinds = append(kinds, token.Kind)
+		}
+
+		assert.Equal(t, test.kinds, kinds)
+	}
+}
+
+func TestParser_Clone(t *testing.T) {
+	parser := NewParser("(a, b) c", IgnoreWhitespaces())
+
+	// Speculatively parse the clone as a keyed group, which fails
+	clone := parser.Clone()
+	_, err := clone.KeyedGroup(EnclosureParens(), '=', ',')
+	assert.EqualError(t, err, "missing pair separator <unicode:'='> after key: 'a'")
+	assert.Equal(t, 1, clone.Errors().Len())
+
+	// Original parser must be unaffected
+	assert.Equal(t, 0, parser.Errors().Len())
+	assert.Equal(t, Token{TokenKind('('), "(", 0, 1, nil}, parser.Cursor())
+
+	// Parse the alternative interpretation on the original parser
+	elements, err := parser.Unwrap(EnclosureParens())
+	assert.NoError(t, err)
+	assert.Equal(t, "a, b", elements)
+	assert.Equal(t, Token{TokenIdent, "c", 7, 8, nil}, parser.Cursor())
+
+	// Clone must be unaffected by the original parser
+	parser.Advance()
+	assert.True(t, parser.Exhausted())
+	assert.Equal(t, Token{TokenIdent, "c", 7, 8, nil}, clone.Cursor())
+}
+
+func TestParser_PeekingAny(t *testing.T) {
+	tests := []struct {
+		input       string
+		advances    int
+		cursorKinds []TokenKind
+		cursorMatch bool
+		peekKinds   []TokenKind
+		peekMatch   bool
+	}{
+		{
+			"a,b;c", 1,
+			[]TokenKind{',', ';'}, true,
+			[]TokenKind{TokenIdent, TokenNumber}, true,
+		},
+		{
+			"a,b;c", 2,
+			[]TokenKind{TokenNumber, TokenString}, false,
+			[]TokenKind{',', ';'}, true,
+		},
+		{
+			"[32]uint64", 0,
+			[]TokenKind{'(', '{'}, false,
+			[]TokenKind{TokenHexNumber}, false,
+		},
+		{
+			"[32]uint64", 0,
+			nil, false,
+			nil, false,
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input)
+		for i := 0; i < test.advances; i++ {
+			parser.Advance()
+		}
+
+		peek := parser.Peek()
+
+		assert.Equal(t, test.cursorMatch, parser.IsCursorAny(test.cursorKinds...))
+		assert.Equal(t, test.peekMatch, parser.IsPeekAny(test.peekKinds...))
+
+		token, ok := parser.ExpectPeekAny(test.peekKinds...)
+		assert.Equal(t, test.peekMatch, ok)
+
+		if test.peekMatch {
+			assert.Equal(t, peek, token)
+			assert.Equal(t, peek, parser.Cursor())
+		} else {
+			assert.Equal(t, Token{}, token)
+			assert.Equal(t, peek, parser.Peek())
+		}
+	}
+}
+
+func TestParser_Require(t *testing.T) {
+	tests := []struct {
+		input string
+		kinds []TokenKind
+		token Token
+		err   *Error
+	}{
+		{"f(x)", []TokenKind{TokenIdent}, Token{TokenIdent, "f", 0, 1, nil}, nil},
+		{"(x)", []TokenKind{TokenIdent, '('}, Token{TokenKind('('), "(", 0, 1, nil}, nil},
+		{"f(x)", []TokenKind{'('}, Token{}, &Error{0, "expected '(', found <ident> 'f'", ErrUnexpectedToken}},
+		{"[x]", []TokenKind{'(', '{'}, Token{}, &Error{0, "expected '(' or '{', found '['", ErrUnexpectedToken}},
+		{"", []TokenKind{TokenNumber}, Token{}, &Error{0, "expected <num>, found <eof>", ErrUnexpectedToken}},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input)
+		cursor := parser.Cursor()
+
+		token, err := parser.RequireAny(test.kinds...)
+		assert.Equal(t, test.token, token, test.input)
+
+		if test.err == nil {
+			assert.NoError(t, err, test.input)
+			assert.NotEqual(t, cursor, parser.Cursor(), test.input)
+
+			continue
+		}
+
+		assert.Equal(t, test.err, err, test.input)
+		assert.Equal(t, ErrorList{test.err}, parser.Errors(), test.input)
+		assert.Equal(t, cursor, parser.Cursor(), test.input)
+	}
+
+	parser := NewParser("a = 1", IgnoreWhitespaces())
+
+	key, err := parser.Require(TokenIdent)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", key.Literal)
+
+	_, err = parser.Require(':')
+	assert.ErrorIs(t, err, ErrUnexpectedToken)
+	assert.EqualError(t, err, "expected ':', found '='")
+}
+
+func TestParser_SkipUntil(t *testing.T) {
+	tests := []struct {
+		input    string
+		options  []ParserOption
+		kinds    []TokenKind
+		skipped  Token
+		unparsed string
+	}{
+		{
+			"garbage here; next", nil, []TokenKind{';', ','},
+			Token{TokenMalformed, "garbage here", 0, 12, nil}, "; next",
+		},
+		{
+			",next", nil, []TokenKind{';', ','},
+			Token{TokenMalformed, "", 0, 0, nil}, ",next",
+		},
+		{
+			"a b  c ", []ParserOption{IgnoreWhitespaces()}, []TokenKind{';'},
+			Token{TokenMalformed, "a b  c", 0, 6, nil}, "",
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+		assert.Equal(t, test.skipped, parser.SkipUntil(test.kinds...))
+		assert.Equal(t, test.unparsed, parser.Unparsed())
+	}
+}
+
+func TestParser_TakeUntil(t *testing.T) {
+	tests := []struct {
+		input    string
+		options  []ParserOption
+		kinds    []TokenKind
+		taken    []Token
+		unparsed string
+	}{
+		{
+			"a = 1 2, b = 3", nil, []TokenKind{','},
+			[]Token{{TokenIdent, "a", 0, 1, nil}, UnicodeToken(' ', 1), {'=', "=", 2, 3, nil}, UnicodeToken(' ', 3), {TokenNumber, "1", 4, 5, nil}, UnicodeToken(' ', 5), {TokenNumber, "2", 6, 7, nil}},
+			", b = 3",
+		},
+		{
+			"1 2; 3", []ParserOption{IgnoreWhitespaces()}, []TokenKind{',', ';'},
+			[]Token{{TokenNumber, "1", 0, 1, nil}, {TokenNumber, "2", 2, 3, nil}},
+			"; 3",
+		},
+		{
+			",x", nil, []TokenKind{','},
+			nil,
+			",x",
+		},
+		{
+			"x y", nil, []TokenKind{','},
+			[]Token{{TokenIdent, "x", 0, 1, nil}, UnicodeToken(' ', 1), {TokenIdent, "y", 2, 3, nil}},
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+		assert.Equal(t, test.taken, parser.TakeUntil(test.kinds...), test.input)
+		assert.Equal(t, test.unparsed, parser.Unparsed(), test.input)
+	}
+}
+
+func TestParser_SeekTo(t *testing.T) {
+	tests := []struct {
+		input    string
+		kind     TokenKind
+		found    bool
+		unparsed string
+	}{
+		{"key = value", '=', true, "= value"},
+		{"=value", '=', true, "=value"},
+		{"key value", '=', false, ""},
+		{"", '=', false, ""},
+		{"a b", TokenEoF, true, ""},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input)
+		assert.Equal(t, test.found, parser.SeekTo(test.kind), test.input)
+		assert.Equal(t, test.unparsed, parser.Unparsed(), test.input)
+	}
+}
+
+func TestParser_TokenFilter(t *testing.T) {
+	// lowercase folds the case of identifiers
+	lowercase := TokenFilter(func(token Token) (Token, bool) {
+		if token.Kind == TokenIdent {
+			token.Literal = strings.ToLower(token.Literal)
+		}
+
+		return token, true
+	})
+
+	// dropComma drops all comma tokens
+	dropComma := TokenFilter(func(token Token) (Token, bool) {
+		return token, token.Kind != ','
+	})
+
+	// renameAll renames identifiers to a custom kind
+	renameAll := TokenFilter(func(token Token) (Token, bool) {
+		if token.Kind == TokenIdent && token.Literal == "all" {
+			token.Kind = -10
+		}
+
+		return token, true
+	})
+
+	tests := []struct {
+		input   string
+		options []ParserOption
+		output  []Token
+	}{
+		{
+			"Select ALL, Name", []ParserOption{IgnoreWhitespaces(), lowercase, dropComma, renameAll},
+			[]Token{{TokenIdent, "select", 0, 6, nil}, {-10, "all", 7, 10, nil}, {TokenIdent, "name", 12, 16, nil}},
+		},
+		{
+			"Select ALL, Name", []ParserOption{IgnoreWhitespaces(), renameAll, lowercase},
+			[]Token{{TokenIdent, "select", 0, 6, nil}, {TokenIdent, "all", 7, 10, nil}, {',', ",", 10, 11, nil}, {TokenIdent, "name", 12, 16, nil}},
+		},
+		{
+			",,,", []ParserOption{dropComma},
+			nil,
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+		assert.Equal(t, test.output, parser.RemainingTokens())
+
+		var tokens []Token
+		for !parser.Exhausted() {
+			tokens = append(tokens, parser.Cursor())
+			parser.Advance()
+		}
+
+		assert.Equal(t, test.output, tokens)
+	}
+}
+
+func TestParser_MaxDepth(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(depth int) error
+		depth int
+		error string
+	}{
+		{
+			"Unwrap Within Limit",
+			func(depth int) error {
+				_, err := NewParser("[[[x]]]", MaxDepth(depth)).Unwrap(EnclosureSquare())
+				return err
+			},
+			3, "",
+		},
+		{
+			"Unwrap Exceeds Limit",
+			func(depth int) error {
+				_, err := NewParser("[[[x]]]", MaxDepth(depth)).Unwrap(EnclosureSquare())
+				return err
+			},
+			2, "maximum nesting depth exceeded: 2",
+		},
+		{
+			"KeyedGroup Within Limit",
+			func(depth int) error {
+				_, err := NewParser("{a:{b:{c:1}}}", MaxDepth(depth)).KeyedGroup(EnclosureCurly(), ':', ',')
+				return err
+			},
+			3, "",
+		},
+		{
+			"KeyedGroup Exceeds Limit",
+			func(depth int) error {
+				_, err := NewParser("{a:{b:{c:1}}}", MaxDepth(depth)).KeyedGroup(EnclosureCurly(), ':', ',')
+				return err
+			},
+			2, "maximum nesting depth exceeded: 2",
+		},
+		{
+			"ParseCall Exceeds Limit",
+			func(depth int) error {
+				_, err := NewParser("f(g(h(1)))", MaxDepth(depth)).ParseCall()
+				return err
+			},
+			2, "maximum nesting depth exceeded: 2",
+		},
+		{
+			"ParseTypeSignature Within Limit",
+			func(depth int) error {
+				_, err := ParseTypeSignature("[]map[string]*int", MaxDepth(depth))
+				return err
+			},
+			4, "",
+		},
+		{
+			"ParseTypeSignature Exceeds Limit",
+			func(depth int) error {
+				_, err := ParseTypeSignature("[]map[string]*int", MaxDepth(depth))
+				return err
+			},
+			3, "maximum nesting depth exceeded: 3",
+		},
+		{
+			"ParseABISignature Exceeds Limit",
+			func(depth int) error {
+				_, err := ParseABISignature("f(((uint8)))", MaxDepth(depth))
+				return err
+			},
+			2, "maximum nesting depth exceeded: 2",
+		},
+		{
+			"Unlimited",
+			func(depth int) error {
+				_, err := NewParser(strings.Repeat("(", 1000)+strings.Repeat(")", 1000), MaxDepth(depth)).Unwrap(EnclosureParens())
+				return err
+			},
+			0, "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.parse(test.depth)
+
+			if test.error != "" {
+				assert.EqualError(t, err, test.error)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func FuzzParser(f *testing.F) {
+	for _, seed := range []string{
+		"{map[string]string}", "( 12345(555))hello123", "(map(sequence[map]", "a=1, b c, d=4",
+		"{a: {b: 1}, c: 0x12}", "f(g(1), x=2)", "map[[2]byte]*T", "transfer((uint8,bytes)[],address)",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		options := []ParserOption{IgnoreWhitespaces(), MaxDepth(32)}
+
+		// None of the parsing routines must panic on arbitrary input
+		_, _ = NewParser(input, options...).Unwrap(EnclosureCurly())
+		_, _ = NewParser(input, options...).KeyedGroup(EnclosureCurly(), ':', ',')
+		_, _ = NewParser(input, options...).ParseCall()
+		_, _ = ParseTypeSignature(input, MaxDepth(32))
+		_, _ = ParseABISignature(input, MaxDepth(32))
+
+		_ = NewParser(input, options...).Split(',')
+		_ = NewParser(input, append(options, RecoverMalformed())...).ParsePairs('=', ',', func(Token, []Token) bool { return true })
+	})
+}
@@ -0,0 +1,85 @@
+package symbolizer
+
+// scanWildcard scans for a Wildcard lexeme under the cursor, either '?', '*' or '**'
+func (lexer *lexer) scanWildcard() Lexeme {
+	start := lexer.cursor
+
+	if lexer.char() == '*' && lexer.peek() == '*' {
+		lexer.advanceCursor()
+	}
+
+	lexer.advanceCursor()
+	return Lexeme{TokenWildcard, start, lexer.cursor}
+}
+
+// MatchPattern returns whether a symbol matches a pattern, such as the Tokens of `metrics.*.latency` tokenized with
+// Wildcards. The symbol is tokenized with the given options and matched against the pattern Token by Token, where
+// Tokens match if they have the same TokenKind and literal. Whitespaces and EoF Tokens are ignored in both.
+// The wildcards of the pattern match the Tokens of the symbol as follows:
+//   - '?' matches exactly one Token
+//   - '*' matches any number of Tokens within a segment, where segments are separated by '.' or '/' Tokens
+//   - '**' matches any number of Tokens, including the separators of segments
+func MatchPattern(pattern []Token, input string, opts ...ParserOption) bool {
+	pattern = significantTokens(pattern)
+	symbol := significantTokens(Tokenize(input, opts...))
+
+	// memo records whether each suffix of the pattern failed to match a suffix of the symbol
+	memo := make(map[[2]int]bool)
+
+	var match func(p, s int) bool
+	match = func(p, s int) bool {
+		if p == len(pattern) {
+			return s == len(symbol)
+		}
+
+		key := [2]int{p, s}
+		if failed, ok := memo[key]; ok {
+			return !failed
+		}
+
+		var matched bool
+
+		switch token := pattern[p]; {
+		case token.Kind == TokenWildcard && token.Literal == "?":
+			matched = s < len(symbol) && match(p+1, s+1)
+
+		case token.Kind == TokenWildcard:
+			// Extend the wildcard one Token at a time until the rest of the pattern matches
+			for end := s; ; end++ {
+				if match(p+1, end) {
+					matched = true
+					break
+				}
+
+				if end == len(symbol) || (token.Literal == "*" && isSegmentSeparator(symbol[end])) {
+					break
+				}
+			}
+
+		default:
+			matched = s < len(symbol) && sameToken(token, symbol[s]) && match(p+1, s+1)
+		}
+
+		memo[key] = !matched
+		return matched
+	}
+
+	return match(0, 0)
+}
+
+// significantTokens returns the Tokens without any whitespace or EoF Tokens
+func significantTokens(tokens []Token) []Token {
+	significant := make([]Token, 0, len(tokens))
+	for _, token := range tokens {
+		if token.Kind != TokenEoF && !isSpaceToken(token) {
+			significant = append(significant, token)
+		}
+	}
+
+	return significant
+}
+
+// isSegmentSeparator returns whether the Token separates the segments of a symbol for '*' wildcards
+func isSegmentSeparator(token Token) bool {
+	return token.Kind == '.' || token.Kind == '/'
+}
@@ -0,0 +1,52 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexer_Wildcards(t *testing.T) {
+	assert.Equal(t, []Token{
+		{TokenIdent, "a", 0, 1, nil},
+		{TokenKind('.'), ".", 1, 2, nil},
+		{TokenWildcard, "**", 2, 4, nil},
+		{TokenKind('.'), ".", 4, 5, nil},
+		{TokenWildcard, "*", 5, 6, nil},
+		{TokenWildcard, "?", 6, 7, nil},
+		{TokenEoF, "", 7, 7, nil},
+	}, Tokenize("a.**.*?", Wildcards()))
+
+	// Wildcards take precedence over positional placeholders
+	assert.Equal(t, TokenWildcard, Tokenize("?", Wildcards(), Placeholders())[0].Kind)
+	assert.Equal(t, TokenKind('*'), Tokenize("*")[0].Kind)
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		matched bool
+	}{
+		{"metrics.*.latency", "metrics.api.latency", true},
+		{"metrics.*.latency", "metrics.api_v2.latency", true},
+		{"metrics.*.latency", "metrics.api.v2.latency", false},
+		{"metrics.*.latency", "metrics.api.errors", false},
+		{"metrics.*.latency", "metrics..latency", true},
+		{"metrics.**.latency", "metrics.api.v2.latency", true},
+		{"metrics.**", "metrics.api.v2.latency", true},
+		{"metrics.**", "other.api", false},
+		{"**", "", true},
+		{"f(?, ?)", "f(a, 0x01)", true},
+		{"f(?, ?)", "f(a)", false},
+		{"f(*)", "f(a, b)", true},
+		{"f(*)", "f(a.b)", false},
+		{"api/*/users", "api/v1/users", true},
+		{"map[string]*", "map[string] uint64", true},
+		{"map[string]*", "map[uint64]uint64", false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.matched, MatchPattern(Tokenize(test.pattern, Wildcards()), test.input), "%v ~ %v", test.pattern, test.input)
+	}
+}
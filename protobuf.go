@@ -0,0 +1,130 @@
+package symbolizer
+
+import "strings"
+
+// FullyQualifiedName is a fully qualified protobuf name such as `pkg.sub.Message.Field`, split into its
+// package and the names within the package (such as nested messages and a field, enum value or method)
+type FullyQualifiedName struct {
+	// Package is the dotted package of the name (such as 'pkg.sub'), if any
+	Package string
+	// Names are the components of the name after the package (such as 'Message' and 'Field')
+	Names []string
+}
+
+// String returns the FullyQualifiedName formatted as a dotted name
+func (name FullyQualifiedName) String() string {
+	if name.Package == "" {
+		return strings.Join(name.Names, ".")
+	}
+
+	return name.Package + "." + strings.Join(name.Names, ".")
+}
+
+// MethodPath is the path of a gRPC method such as `/pkg.Service/Method`,
+// which is used as the :path of the requests that invoke the method
+type MethodPath struct {
+	// Package is the dotted package of the service (such as 'pkg.v1'), if any
+	Package string
+	// Service and Method are the names of the service and the method
+	Service, Method string
+}
+
+// String returns the MethodPath formatted as a gRPC method path
+func (path MethodPath) String() string {
+	if path.Package == "" {
+		return "/" + path.Service + "/" + path.Method
+	}
+
+	return "/" + path.Package + "." + path.Service + "/" + path.Method
+}
+
+// ParseFullyQualifiedName parses a fully qualified protobuf name such as `pkg.sub.Message.Field` (which may have a
+// leading '.' like the type names within descriptors) into its package and names. Since protobuf names do not
+// delimit their package, the package is the leading components that begin with a lowercase letter, as per the
+// protobuf style guide. Returns an error if the input is not a dotted sequence of identifiers or has no names
+// after the package.
+func ParseFullyQualifiedName(input string) (FullyQualifiedName, error) {
+	parser := NewParser(input, NoDefaultKeywords())
+
+	// Skip the leading '.' of an absolute name
+	if parser.IsCursor('.') {
+		parser.Advance()
+	}
+
+	components, err := parser.parseQualified()
+	if err != nil {
+		return FullyQualifiedName{}, err
+	}
+
+	if !parser.Exhausted() {
+		return FullyQualifiedName{}, parser.errorf(parser.curr.Position, "unexpected token after name: %v", describeToken(parser.curr))
+	}
+
+	// The package ends at the first component that does not begin with a lowercase letter
+	pkg := 0
+	for pkg < len(components) && isPackageComponent(components[pkg]) {
+		pkg++
+	}
+
+	if pkg == len(components) {
+		return FullyQualifiedName{}, parser.errorf(0, "missing name after package: '%v'", input)
+	}
+
+	return FullyQualifiedName{strings.Join(components[:pkg], "."), components[pkg:]}, nil
+}
+
+// ParseMethodPath parses a gRPC method path such as `/pkg.Service/Method` into the package and
+// name of its service and the name of its method. The package is every component of the fully
+// qualified service name except its last. Returns an error if the input is not a method path.
+func ParseMethodPath(input string) (MethodPath, error) {
+	parser := NewParser(input, NoDefaultKeywords())
+
+	if _, err := parser.Require('/'); err != nil {
+		return MethodPath{}, err
+	}
+
+	service, err := parser.parseQualified()
+	if err != nil {
+		return MethodPath{}, err
+	}
+
+	if _, err = parser.Require('/'); err != nil {
+		return MethodPath{}, err
+	}
+
+	method, err := parser.Require(TokenIdent)
+	if err != nil {
+		return MethodPath{}, err
+	}
+
+	if !parser.Exhausted() {
+		return MethodPath{}, parser.errorf(parser.curr.Position, "unexpected token after method: %v", describeToken(parser.curr))
+	}
+
+	last := len(service) - 1
+	return MethodPath{strings.Join(service[:last], "."), service[last], method.Literal}, nil
+}
+
+// parseQualified parses the dotted sequence of identifiers at the cursor and returns its components
+func (parser *Parser) parseQualified() ([]string, error) {
+	var components []string
+
+	for {
+		component, err := parser.Require(TokenIdent)
+		if err != nil {
+			return nil, err
+		}
+
+		components = append(components, component.Literal)
+		if !parser.IsCursor('.') {
+			return components, nil
+		}
+
+		parser.Advance()
+	}
+}
+
+// isPackageComponent returns whether the component of a protobuf name begins with a lowercase letter
+func isPackageComponent(component string) bool {
+	return component[0] >= 'a' && component[0] <= 'z'
+}
@@ -0,0 +1,80 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFullyQualifiedName(t *testing.T) {
+	tests := []struct {
+		input string
+		name  FullyQualifiedName
+	}{
+		{"pkg.sub.Message.Field", FullyQualifiedName{"pkg.sub", []string{"Message", "Field"}}},
+		{".google.protobuf.Timestamp", FullyQualifiedName{"google.protobuf", []string{"Timestamp"}}},
+		{"pkg.v1.Outer.Inner.value", FullyQualifiedName{"pkg.v1", []string{"Outer", "Inner", "value"}}},
+		{"Message", FullyQualifiedName{"", []string{"Message"}}},
+		{"true.False", FullyQualifiedName{"true", []string{"False"}}},
+	}
+
+	for _, test := range tests {
+		name, err := ParseFullyQualifiedName(test.input)
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.name, name, test.input)
+		assert.Equal(t, test.input[len(test.input)-len(name.String()):], name.String(), test.input)
+	}
+}
+
+func TestParseFullyQualifiedName_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"", "expected <ident>, found <eof>"},
+		{"pkg.sub", "missing name after package: 'pkg.sub'"},
+		{"pkg..Message", "expected <ident>, found '.'"},
+		{"pkg.Message.", "expected <ident>, found <eof>"},
+		{"pkg.1Message", "expected <ident>, found <num> '1'"},
+		{"pkg.Message Field", "unexpected token after name: ' '"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseFullyQualifiedName(test.input)
+		assert.EqualError(t, err, test.err, test.input)
+	}
+}
+
+func TestParseMethodPath(t *testing.T) {
+	tests := []struct {
+		input string
+		path  MethodPath
+	}{
+		{"/pkg.Service/Method", MethodPath{"pkg", "Service", "Method"}},
+		{"/grpc.health.v1.Health/Check", MethodPath{"grpc.health.v1", "Health", "Check"}},
+		{"/Service/Method", MethodPath{"", "Service", "Method"}},
+	}
+
+	for _, test := range tests {
+		path, err := ParseMethodPath(test.input)
+		require.NoError(t, err, test.input)
+		assert.Equal(t, test.path, path, test.input)
+		assert.Equal(t, test.input, path.String(), test.input)
+	}
+
+	errors := []struct {
+		input string
+		err   string
+	}{
+		{"pkg.Service/Method", "expected '/', found <ident> 'pkg'"},
+		{"/pkg.Service", "expected '/', found <eof>"},
+		{"/pkg.Service/", "expected <ident>, found <eof>"},
+		{"/pkg.Service/Method/", "unexpected token after method: '/'"},
+	}
+
+	for _, test := range errors {
+		_, err := ParseMethodPath(test.input)
+		assert.EqualError(t, err, test.err, test.input)
+	}
+}
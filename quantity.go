@@ -0,0 +1,186 @@
+package symbolizer
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Unit is a unit of measurement of a Quantity, which is a multiple of a base unit (such as 'GiB' of 'B')
+type Unit struct {
+	// Symbol is the symbol of the unit within the input (such as 'GiB' or 'ms')
+	Symbol string
+	// Base is the symbol of the base unit that the unit is a multiple of (such as 'B' or 's')
+	Base string
+	// Factor is the number of base units in the unit (such as 1<<30 for 'GiB')
+	Factor float64
+}
+
+// Quantity is a numeric value with a Unit of measurement, such as 10GiB, 250ms or 3.5kg
+type Quantity struct {
+	Value float64
+	Unit  Unit
+}
+
+// String returns the Quantity formatted as its value followed by the symbol of its unit
+func (quantity Quantity) String() string {
+	return strconv.FormatFloat(quantity.Value, 'g', -1, 64) + quantity.Unit.Symbol
+}
+
+// Normalize returns the Quantity converted into the base unit of its Unit, such that 2KiB is normalized to 2048B
+func (quantity Quantity) Normalize() Quantity {
+	base := Unit{Symbol: quantity.Unit.Base, Base: quantity.Unit.Base, Factor: 1}
+	return Quantity{quantity.Value * quantity.Unit.Factor, base}
+}
+
+// Convert returns the Quantity converted into the given Unit, such as 1500ms into 1.5s.
+// Returns an error if the units do not have the same base unit.
+func (quantity Quantity) Convert(unit Unit) (Quantity, error) {
+	if unit.Base != quantity.Unit.Base {
+		return Quantity{}, fmt.Errorf("cannot convert quantity of '%v' into '%v'", quantity.Unit.Symbol, unit.Symbol)
+	}
+
+	return Quantity{quantity.Value * quantity.Unit.Factor / unit.Factor, unit}, nil
+}
+
+// UnitRegistry is a registry of the Units that are recognized when parsing a Quantity, by their symbol.
+// Units can be registered with Register, which must not be called concurrently with parsing.
+type UnitRegistry struct {
+	units map[string]Unit
+}
+
+// NewUnitRegistry generates a new UnitRegistry with the given Units
+func NewUnitRegistry(units ...Unit) *UnitRegistry {
+	registry := &UnitRegistry{units: make(map[string]Unit, len(units))}
+	registry.Register(units...)
+
+	return registry
+}
+
+// Register adds the given Units to the UnitRegistry, replacing any Units with the same symbol
+func (registry *UnitRegistry) Register(units ...Unit) {
+	for _, unit := range units {
+		registry.units[unit.Symbol] = unit
+	}
+}
+
+// Lookup returns the Unit with the given symbol, if it is registered
+func (registry *UnitRegistry) Lookup(symbol string) (Unit, bool) {
+	unit, ok := registry.units[symbol]
+	return unit, ok
+}
+
+// DefaultUnits returns a new UnitRegistry of the common units of data (such as 'kB' and 'GiB' of 'B'), time ('ns',
+// 'us', 'µs', 'ms', 's', 'min', 'h' and 'd' of 's'), mass ('mg', 'g', 'kg' and 't' of 'kg') and length ('mm', 'cm',
+// 'm' and 'km' of 'm'). Minutes are 'min' since 'm' is the metre. The returned UnitRegistry can be extended.
+func DefaultUnits() *UnitRegistry {
+	registry := NewUnitRegistry(
+		Unit{"B", "B", 1},
+		Unit{"ns", "s", 1e-9}, Unit{"us", "s", 1e-6}, Unit{"µs", "s", 1e-6}, Unit{"ms", "s", 1e-3},
+		Unit{"s", "s", 1}, Unit{"min", "s", 60}, Unit{"h", "s", 3600}, Unit{"d", "s", 86400},
+		Unit{"mg", "kg", 1e-6}, Unit{"g", "kg", 1e-3}, Unit{"kg", "kg", 1}, Unit{"t", "kg", 1e3},
+		Unit{"mm", "m", 1e-3}, Unit{"cm", "m", 1e-2}, Unit{"m", "m", 1}, Unit{"km", "m", 1e3},
+	)
+
+	// Decimal and binary multiples of bytes
+	for idx, prefix := range []string{"k", "M", "G", "T", "P"} {
+		decimal, binary := prefix+"B", prefix+"iB"
+		if prefix == "k" {
+			binary = "KiB"
+		}
+
+		exponent := float64(idx + 1)
+		registry.Register(Unit{decimal, "B", math.Pow(1e3, exponent)}, Unit{binary, "B", math.Pow(1024, exponent)})
+	}
+
+	return registry
+}
+
+// defaultUnits is the UnitRegistry used when parsing a Quantity without a UnitRegistry
+var defaultUnits = DefaultUnits()
+
+// ParseQuantity parses a quantity such as `10GiB`, `250ms` or `3.5 kg` into its value and Unit, with the Units of
+// the given UnitRegistry (or of DefaultUnits, if it is nil). Returns an error if the input is not a quantity, if
+// its unit is not registered or if it has trailing data after the quantity. See Parser.Quantity for details.
+func ParseQuantity(input string, registry *UnitRegistry) (Quantity, error) {
+	parser := NewParser(input, IgnoreWhitespaces())
+
+	quantity, err := parser.Quantity(registry)
+	if err != nil {
+		return Quantity{}, err
+	}
+
+	if !parser.Exhausted() {
+		return Quantity{}, parser.errorf(parser.curr.Position, "unexpected token after quantity: %v", describeToken(parser.curr))
+	}
+
+	return quantity, nil
+}
+
+// Quantity parses the quantity at the cursor into its value and Unit, with the Units of the given UnitRegistry
+// (or of DefaultUnits, if it is nil), and advances the parser past it. A quantity is a decimal numeric (which may be
+// signed and fractional) followed by the symbol of its unit, which may be separated from it by whitespace. It is
+// scanned from the input regardless of how its Tokens are scanned by the parser (such as TokenDuration with the
+// DurationLiterals option), but it must end at the end of a Token. Returns an error if there is no quantity at the
+// cursor or if its unit is not registered (classified as ErrInvalidValue), in which case the parser does not advance.
+func (parser *Parser) Quantity(registry *UnitRegistry) (Quantity, error) {
+	if registry == nil {
+		registry = defaultUnits
+	}
+
+	start := parser.curr.Position
+	input := parser.scanner.input[start:]
+
+	numeric, _ := matchDecimal(input, true)
+	if parser.Exhausted() || numeric == 0 {
+		return Quantity{}, parser.errorf(start, "expected quantity, found %v", describeToken(parser.curr))
+	}
+
+	// Collect the symbol of the unit after any whitespace
+	symbol := numeric
+	for symbol < len(input) && unicode.IsSpace(rune(input[symbol])) {
+		symbol++
+	}
+
+	end := symbol
+	for end < len(input) {
+		char, size := utf8.DecodeRune(input[end:])
+		if !parser.scanner.identChar(char) {
+			break
+		}
+
+		end += size
+	}
+
+	if end == symbol {
+		return Quantity{}, parser.errorf(start+numeric, "missing unit of quantity: '%s'", input[:numeric])
+	}
+
+	unit, ok := registry.Lookup(string(input[symbol:end]))
+	if !ok {
+		return Quantity{}, parser.sentinelf(start+symbol, ErrInvalidValue, "unknown unit of quantity: '%s'", input[symbol:end])
+	}
+
+	// The quantity must span whole Tokens
+	last := parser.Clone()
+	for !last.Exhausted() && last.curr.End < start+end {
+		last.Advance()
+	}
+
+	if last.curr.End != start+end {
+		return Quantity{}, parser.errorf(start, "quantity does not end at the end of a token: '%s'", input[:end])
+	}
+
+	value, err := strconv.ParseFloat(string(input[:numeric]), 64)
+	if err != nil {
+		return Quantity{}, parser.sentinelf(start, ErrInvalidValue, "invalid value of quantity: '%s'", input[:numeric])
+	}
+
+	for parser.curr.Position < start+end {
+		parser.Advance()
+	}
+
+	return Quantity{value, unit}, nil
+}
@@ -0,0 +1,97 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		input      string
+		value      float64
+		symbol     string
+		normalized float64
+		base       string
+	}{
+		{"10GiB", 10, "GiB", 10 * (1 << 30), "B"},
+		{"2kB", 2, "kB", 2000, "B"},
+		{"250ms", 250, "ms", 0.25, "s"},
+		{"250µs", 250, "µs", 0.00025, "s"},
+		{"3.5kg", 3.5, "kg", 3.5, "kg"},
+		{"  1.5 min ", 1.5, "min", 90, "s"},
+		{"-2km", -2, "km", -2000, "m"},
+	}
+
+	for _, test := range tests {
+		quantity, err := ParseQuantity(test.input, nil)
+		require.NoError(t, err, test.input)
+
+		assert.Equal(t, test.value, quantity.Value, test.input)
+		assert.Equal(t, test.symbol, quantity.Unit.Symbol, test.input)
+
+		normalized := quantity.Normalize()
+		assert.InDelta(t, test.normalized, normalized.Value, 1e-12, test.input)
+		assert.Equal(t, Unit{test.base, test.base, 1}, normalized.Unit, test.input)
+	}
+}
+
+func TestParseQuantity_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"", "expected quantity, found <eof>"},
+		{"GiB", "expected quantity, found <ident> 'GiB'"},
+		{"10", "missing unit of quantity: '10'"},
+		{"10 ", "missing unit of quantity: '10'"},
+		{"10parsecs", "unknown unit of quantity: 'parsecs'"},
+		{"10kg 5", "unexpected token after quantity: <num> '5'"},
+	}
+
+	for _, test := range tests {
+		_, err := ParseQuantity(test.input, nil)
+		assert.EqualError(t, err, test.err, test.input)
+	}
+
+	_, err := ParseQuantity("10parsecs", nil)
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestParser_Quantity(t *testing.T) {
+	registry := NewUnitRegistry(Unit{"pc", "m", 3.0857e16}, Unit{"ly", "m", 9.4607e15})
+	registry.Register(Unit{"m", "m", 1})
+
+	// Quantities are scanned regardless of the Tokens of the parser
+	parser := NewParser("[1.5ly, 250ms, 3pc]", IgnoreWhitespaces(), DurationLiterals(), ScientificNumbers())
+	parser.Advance()
+
+	quantity, err := parser.Quantity(registry)
+	require.NoError(t, err)
+	assert.Equal(t, Quantity{1.5, Unit{"ly", "m", 9.4607e15}}, quantity)
+	assert.Equal(t, "1.5ly", quantity.String())
+	assert.True(t, parser.IsCursor(','))
+
+	parser.Advance()
+	_, err = parser.Quantity(registry)
+	assert.EqualError(t, err, "unknown unit of quantity: 'ms'")
+	assert.Equal(t, TokenDuration, parser.Cursor().Kind)
+
+	quantity, err = parser.Quantity(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "250ms", quantity.String())
+
+	parser.Advance()
+	quantity, err = parser.Quantity(registry)
+	require.NoError(t, err)
+
+	converted, err := quantity.Convert(Unit{"ly", "m", 9.4607e15})
+	require.NoError(t, err)
+	assert.InDelta(t, 9.785, converted.Value, 1e-3)
+
+	_, err = quantity.Convert(Unit{"s", "s", 1})
+	assert.EqualError(t, err, "cannot convert quantity of 'pc' into 's'")
+
+	assert.True(t, parser.IsCursor(']'))
+}
@@ -0,0 +1,100 @@
+package symbolizer
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Query is a set of values parsed from a query-string such as `key=value&key=value`, keyed by their
+// (unescaped) keys. Like url.Values, a key may have multiple values in the order that they appear.
+type Query map[string][]any
+
+// Get returns the first value for the given key or nil if there are no values for the key
+func (query Query) Get(key string) any {
+	if values := query[key]; len(values) != 0 {
+		return values[0]
+	}
+
+	return nil
+}
+
+// ParseQueryLike parses a query-string such as `id=42&name=alice&verified=true` into a Query. Pairs are
+// separated by '&' and each key is separated from its value by the first '=' in the pair. Pairs without a
+// '=' have an empty string value and empty pairs are skipped.
+//
+// Values that are a single token are converted with Token.Value (such as numbers, booleans and hex), while all
+// other values are returned as their source text with any percent-encoding and '+' for spaces unescaped (like
+// url.QueryUnescape). Single token values that contain a '+' or '%' (such as +5) are also unescaped source text,
+// since unescaping alters them. Keys are always unescaped source text. Returns an error if any escape is invalid.
+func ParseQueryLike(input string, opts ...ParserOption) (Query, error) {
+	parser := NewParser(input, opts...)
+	query := make(Query)
+
+	for {
+		tokens := parser.TakeUntil('&')
+		if len(tokens) != 0 {
+			if err := parser.queryPair(query, tokens); err != nil {
+				return nil, err
+			}
+		}
+
+		if !parser.IsCursor('&') {
+			return query, nil
+		}
+
+		parser.Advance()
+	}
+}
+
+// queryPair parses the Tokens of a single query-string pair and adds its value into the Query
+func (parser *Parser) queryPair(query Query, tokens []Token) error {
+	// Split the pair on its first '=' into the key and value tokens
+	split := len(tokens)
+	for idx, token := range tokens {
+		if token.Kind == '=' {
+			split = idx
+			break
+		}
+	}
+
+	key, err := parser.queryText(tokens[:split])
+	if err != nil {
+		return err
+	}
+
+	var value any = ""
+
+	switch {
+	// Missing or Empty Value
+	case split >= len(tokens)-1:
+
+	// Single Token Value, that is not altered by unescaping
+	case split == len(tokens)-2 && tokens[split+1].Kind.CanValue() && !strings.ContainsAny(tokens[split+1].Literal, "+%"):
+		if value, err = parser.tokenValue(tokens[split+1]); err != nil {
+			return err
+		}
+
+	// Source Text
+	default:
+		if value, err = parser.queryText(tokens[split+1:]); err != nil {
+			return err
+		}
+	}
+
+	query[key] = append(query[key], value)
+	return nil
+}
+
+// queryText returns the unescaped source text of the given Tokens
+func (parser *Parser) queryText(tokens []Token) (string, error) {
+	if len(tokens) == 0 {
+		return "", nil
+	}
+
+	text, err := url.QueryUnescape(parser.scanner.collectBetween(tokens[0].Position, tokens[len(tokens)-1].End))
+	if err != nil {
+		return "", parser.errorf(tokens[0].Position, "invalid query escape: %v", err)
+	}
+
+	return text, nil
+}
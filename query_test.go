@@ -0,0 +1,58 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueryLike(t *testing.T) {
+	tests := []struct {
+		input string
+		query Query
+		err   string
+	}{
+		{
+			"id=42&name=alice&verified=true&key=0x0abc&delta=-5",
+			Query{"id": {uint64(42)}, "name": {"alice"}, "verified": {true}, "key": {[]byte{0x0a, 0xbc}}, "delta": {int64(-5)}},
+			"",
+		},
+		{
+			"tag=a&tag=b&&flag&empty=",
+			Query{"tag": {"a", "b"}, "flag": {""}, "empty": {""}},
+			"",
+		},
+		{
+			"q=hello+big%20world&first%20name=bob&eq=a=b&str=\"quoted value\"",
+			Query{"q": {"hello big world"}, "first name": {"bob"}, "eq": {"a=b"}, "str": {"quoted value"}},
+			"",
+		},
+		{
+			"x=+5&y=a+b&z=\"a+b\"&w=-0x0f",
+			Query{"x": {" 5"}, "y": {"a b"}, "z": {"\"a b\""}, "w": {int64(-15)}},
+			"",
+		},
+		{
+			"", Query{}, "",
+		},
+		{
+			"bad=%zz", nil, "invalid query escape: invalid URL escape \"%zz\"",
+		},
+	}
+
+	for _, test := range tests {
+		query, err := ParseQueryLike(test.input)
+
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.input)
+			continue
+		}
+
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.query, query, test.input)
+	}
+
+	query, _ := ParseQueryLike("a=1&a=2")
+	assert.Equal(t, uint64(1), query.Get("a"))
+	assert.Nil(t, query.Get("b"))
+}
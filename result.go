@@ -0,0 +1,204 @@
+package symbolizer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Result wraps a value parsed from a symbol (such as the map of a KeyedGroup, a Call or a Query) and
+// provides path based lookups into it, so that nested fields can be retrieved without walking it manually.
+type Result struct {
+	Value any
+}
+
+// Get returns the value at the given path within the Result. A path is a sequence of keys such as 'a.b[2].c',
+// where each key is either an identifier preceded by a '.' (except for the first) or a number or quoted string
+// within square brackets. Identifier and string keys select the value of that key within a group, while number
+// keys select the element at that index within a list (or the value of that numeric key within a group).
+// Groups of Located values are unwrapped and the keyed and positional arguments of a Call are both addressable.
+// An empty path returns the value of the Result itself.
+func (result Result) Get(path string) (any, error) {
+	keys, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	value := result.Value
+	for idx, key := range keys {
+		var found bool
+		if value, found = lookupKey(value, key); !found {
+			return nil, fmt.Errorf("path not found: '%v'", formatPath(keys[:idx+1]))
+		}
+	}
+
+	return value, nil
+}
+
+// GetString returns the string value at the given path within the Result (see Get)
+func (result Result) GetString(path string) (string, error) {
+	value, err := result.Get(path)
+	if err != nil {
+		return "", err
+	}
+
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("value at path '%v' is not a string: %T", path, value)
+	}
+
+	return str, nil
+}
+
+// GetUint returns the unsigned integer value at the given path within the Result (see Get).
+// Signed integers are accepted if they are not negative.
+func (result Result) GetUint(path string) (uint64, error) {
+	value, err := result.Get(path)
+	if err != nil {
+		return 0, err
+	}
+
+	switch number := value.(type) {
+	case uint64:
+		return number, nil
+	case int64:
+		if number >= 0 {
+			return uint64(number), nil
+		}
+	}
+
+	return 0, fmt.Errorf("value at path '%v' is not an unsigned integer: %v", path, value)
+}
+
+// GetInt returns the signed integer value at the given path within the Result (see Get).
+// Unsigned integers are accepted if they do not overflow an int64.
+func (result Result) GetInt(path string) (int64, error) {
+	value, err := result.Get(path)
+	if err != nil {
+		return 0, err
+	}
+
+	switch number := value.(type) {
+	case int64:
+		return number, nil
+	case uint64:
+		if number <= 1<<63-1 {
+			return int64(number), nil
+		}
+	}
+
+	return 0, fmt.Errorf("value at path '%v' is not a signed integer: %v", path, value)
+}
+
+// GetBool returns the boolean value at the given path within the Result (see Get)
+func (result Result) GetBool(path string) (bool, error) {
+	value, err := result.Get(path)
+	if err != nil {
+		return false, err
+	}
+
+	boolean, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("value at path '%v' is not a boolean: %v", path, value)
+	}
+
+	return boolean, nil
+}
+
+// parsePath parses a path such as 'a.b[2].c' into its keys.
+// Identifier and string keys are a string, while number keys are a uint64.
+func parsePath(path string) ([]any, error) {
+	parser := NewParser(path, IgnoreWhitespaces())
+
+	var keys []any
+	for !parser.Exhausted() {
+		switch {
+		// Identifier Key (preceded by a '.' if not the first)
+		case len(keys) == 0 && parser.IsCursor(TokenIdent), len(keys) != 0 && parser.IsCursor('.') && parser.ExpectPeek(TokenIdent):
+			keys = append(keys, parser.curr.Literal)
+			parser.Advance()
+
+		// Index or Quoted Key
+		case parser.IsCursor('[') && parser.IsPeekAny(TokenNumber, TokenString):
+			parser.Advance()
+
+			key, err := parser.tokenValue(parser.curr)
+			if err != nil {
+				return nil, err
+			}
+
+			if !parser.ExpectPeek(']') {
+				return nil, parser.errorf(parser.next.Position, "missing end of path index: ']'")
+			}
+
+			keys = append(keys, key)
+			parser.Advance()
+
+		default:
+			return nil, parser.errorf(parser.curr.Position, "invalid path element: '%v'", parser.curr.Literal)
+		}
+	}
+
+	return keys, nil
+}
+
+// formatPath formats the keys of a path back into a path
+func formatPath(keys []any) string {
+	var path strings.Builder
+	for idx, key := range keys {
+		switch key := key.(type) {
+		case uint64:
+			fmt.Fprintf(&path, "[%d]", key)
+		default:
+			if idx > 0 {
+				path.WriteByte('.')
+			}
+
+			path.WriteString(key.(string))
+		}
+	}
+
+	return path.String()
+}
+
+// lookupKey returns the value of the key within the given value
+func lookupKey(value any, key any) (any, bool) {
+	switch value := value.(type) {
+	case Located:
+		return lookupKey(value.Value, key)
+
+	case map[any]any:
+		found, ok := value[key]
+		return found, ok
+
+	case map[any]Located:
+		found, ok := value[key]
+		return found.Value, ok
+
+	case Query:
+		if name, ok := key.(string); ok {
+			found, ok := value[name]
+			return found, ok
+		}
+
+	case map[string]any:
+		if name, ok := key.(string); ok {
+			found, ok := value[name]
+			return found, ok
+		}
+
+	case Call:
+		if name, ok := key.(string); ok {
+			found, ok := value.Keyed[name]
+			return found, ok
+		}
+
+		return lookupKey(value.Positional, key)
+
+	case []any:
+		if index, ok := key.(uint64); ok && index < uint64(len(value)) {
+			return value[index], true
+		}
+	}
+
+	return nil, false
+}
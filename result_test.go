@@ -0,0 +1,95 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult_Get(t *testing.T) {
+	group, err := NewParser(`{a: {b: 5, "c d": true}, 1: "one", n: -2}`, IgnoreWhitespaces()).KeyedGroup(EnclosureCurly(), ':', ',')
+	assert.NoError(t, err)
+
+	call, err := NewParser(`f(1, g(x, y=0x01), key="v")`, IgnoreWhitespaces()).ParseCall()
+	assert.NoError(t, err)
+
+	query, err := ParseQueryLike("tag=a&tag=b")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		result Result
+		path   string
+		value  any
+		err    string
+	}{
+		{Result{group}, "a.b", uint64(5), ""},
+		{Result{group}, `a["c d"]`, true, ""},
+		{Result{group}, "[1]", "one", ""},
+		{Result{group}, "", group, ""},
+		{Result{group}, "a.x", nil, "path not found: 'a.x'"},
+		{Result{group}, "a.b.c", nil, "path not found: 'a.b.c'"},
+		{Result{call}, "[1].y", []byte{0x01}, ""},
+		{Result{call}, "[1][0]", "x", ""},
+		{Result{call}, "key", "v", ""},
+		{Result{call}, "[3]", nil, "path not found: '[3]'"},
+		{Result{query}, "tag[1]", "b", ""},
+		{Result{group}, "a..b", nil, "invalid path element: '.'"},
+		{Result{group}, "a[1", nil, "missing end of path index: ']'"},
+		{Result{group}, ".a", nil, "invalid path element: '.'"},
+	}
+
+	for _, test := range tests {
+		value, err := test.result.Get(test.path)
+
+		if test.err != "" {
+			assert.EqualError(t, err, test.err, test.path)
+			continue
+		}
+
+		assert.NoError(t, err, test.path)
+		assert.Equal(t, test.value, value, test.path)
+	}
+
+	located, err := NewParser(`{a: {b: "x"}}`, IgnoreWhitespaces()).KeyedGroupLocated(EnclosureCurly(), ':', ',')
+	assert.NoError(t, err)
+
+	value, err := Result{located}.GetString("a.b")
+	assert.NoError(t, err)
+	assert.Equal(t, "x", value)
+}
+
+func TestResult_TypedGetters(t *testing.T) {
+	group, err := NewParser(`{s: "x", u: 5, i: -2, b: false}`, IgnoreWhitespaces()).KeyedGroup(EnclosureCurly(), ':', ',')
+	assert.NoError(t, err)
+
+	result := Result{group}
+
+	str, err := result.GetString("s")
+	assert.NoError(t, err)
+	assert.Equal(t, "x", str)
+
+	_, err = result.GetString("u")
+	assert.EqualError(t, err, "value at path 'u' is not a string: uint64")
+
+	unsigned, err := result.GetUint("u")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), unsigned)
+
+	_, err = result.GetUint("i")
+	assert.EqualError(t, err, "value at path 'i' is not an unsigned integer: -2")
+
+	signed, err := result.GetInt("u")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), signed)
+
+	signed, err = result.GetInt("i")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-2), signed)
+
+	boolean, err := result.GetBool("b")
+	assert.NoError(t, err)
+	assert.False(t, boolean)
+
+	_, err = result.GetBool("missing")
+	assert.EqualError(t, err, "path not found: 'missing'")
+}
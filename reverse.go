@@ -0,0 +1,106 @@
+package symbolizer
+
+// ReverseParser is a parser that iterates over the Tokens of a pre-tokenized input from its end towards its
+// start, for suffix-oriented grammars (such as type symbols with trailing qualifiers like '[]' or '?') which
+// are simpler to parse from the end. It is generated from a Parser with Reverse, and the unconsumed prefix
+// of the input can be parsed forwards again with Forward once the suffixes have been consumed.
+type ReverseParser struct {
+	// parser is the Parser from which the ReverseParser was generated
+	parser *Parser
+	// tokens are the buffered Tokens, ordered by their position
+	tokens []Token
+	// cursor is the index of the current Token in the tokens
+	cursor int
+	// start is the byte offset at which the buffered Tokens begin
+	start int
+}
+
+// Reverse returns a ReverseParser over the Tokens from the cursor until the end of the input, with its cursor
+// at the last Token of the input. The Tokens are scanned ahead into a buffer without advancing the parser.
+func (parser *Parser) Reverse() *ReverseParser {
+	tokens := parser.RemainingTokens()
+	return &ReverseParser{parser: parser, tokens: tokens, cursor: len(tokens) - 1, start: parser.curr.Position}
+}
+
+// Cursor returns the current Token. If all the Tokens have been consumed,
+// an EoF Token at the start of the buffered Tokens is returned.
+func (reverse *ReverseParser) Cursor() Token { return reverse.token(reverse.cursor) }
+
+// Peek looks behind and returns the Token before the cursor without moving the cursor.
+// If there is no such Token, an EoF Token at the start of the buffered Tokens is returned.
+func (reverse *ReverseParser) Peek() Token { return reverse.token(reverse.cursor - 1) }
+
+// token returns the buffered Token at the given index, or an EoF Token if the index precedes the buffer
+func (reverse *ReverseParser) token(idx int) Token {
+	if idx < 0 {
+		return Token{Kind: TokenEoF, Position: reverse.start, End: reverse.start}
+	}
+
+	return reverse.tokens[idx]
+}
+
+// AdvanceBack moves the cursor to the Token before it. It does nothing if the ReverseParser is exhausted.
+func (reverse *ReverseParser) AdvanceBack() {
+	if reverse.cursor >= 0 {
+		reverse.cursor--
+	}
+}
+
+// Exhausted returns whether all the Tokens have been consumed i.e, the cursor is at the start of the input
+func (reverse *ReverseParser) Exhausted() bool {
+	return reverse.cursor < 0
+}
+
+// IsCursor checks if the current token is of the specified TokenKind.
+func (reverse *ReverseParser) IsCursor(t TokenKind) bool {
+	return reverse.Cursor().Kind == t
+}
+
+// IsPeek checks if the token before the cursor is of the specified TokenKind.
+// This look behind is performed without moving the cursor.
+func (reverse *ReverseParser) IsPeek(t TokenKind) bool {
+	return reverse.Peek().Kind == t
+}
+
+// ExpectPeek moves the cursor back if the token before it is of the specified TokenKind.
+// If it is not the same type, the cursor does not move.
+// The returned boolean indicates if the cursor was moved.
+func (reverse *ReverseParser) ExpectPeek(t TokenKind) bool {
+	if !reverse.IsPeek(t) {
+		return false
+	}
+
+	reverse.AdvanceBack()
+	return true
+}
+
+// ConsumeSuffix moves the cursor back past the given TokenKinds if the Tokens ending at the cursor are of those
+// kinds, in the order they appear in the input, such that ConsumeSuffix('[', ']') consumes a trailing '[]'.
+// If the Tokens do not match, the cursor does not move. The returned boolean indicates if the cursor was moved.
+func (reverse *ReverseParser) ConsumeSuffix(kinds ...TokenKind) bool {
+	if len(kinds) == 0 || len(kinds) > reverse.cursor+1 {
+		return false
+	}
+
+	first := reverse.cursor + 1 - len(kinds)
+	for idx, kind := range kinds {
+		if reverse.tokens[first+idx].Kind != kind {
+			return false
+		}
+	}
+
+	reverse.cursor -= len(kinds)
+	return true
+}
+
+// Unparsed returns the data in the input from the start of the buffered Tokens until the end of the cursor
+func (reverse *ReverseParser) Unparsed() string {
+	return reverse.parser.scanner.collectBetween(reverse.start, reverse.Cursor().End)
+}
+
+// Forward returns a Parser that parses the input from the start of the buffered Tokens until the end of the cursor,
+// which is the prefix of the input that has not been consumed. Token positions produced by the Parser remain relative
+// to the complete input and it accumulates errors into the same list as the Parser from which Reverse was called.
+func (reverse *ReverseParser) Forward() *Parser {
+	return reverse.parser.subParser(reverse.start, reverse.Cursor().End)
+}
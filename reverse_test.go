@@ -0,0 +1,61 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_Reverse(t *testing.T) {
+	// 0         1
+	// 0123456789012345
+	// x map[a]b[][]?
+	parser := NewParser("x map[a]b[][]?", IgnoreWhitespaces())
+	parser.Advance()
+
+	reverse := parser.Reverse()
+	assert.Equal(t, Token{TokenKind('?'), "?", 13, 14, nil}, reverse.Cursor())
+	assert.Equal(t, Token{TokenKind(']'), "]", 12, 13, nil}, reverse.Peek())
+
+	// The parser is not advanced
+	assert.Equal(t, "map", parser.Cursor().Literal)
+
+	// Strip the trailing qualifiers
+	assert.True(t, reverse.IsCursor('?'))
+	reverse.AdvanceBack()
+
+	dimensions := 0
+	for reverse.ConsumeSuffix('[', ']') {
+		dimensions++
+	}
+
+	assert.Equal(t, 2, dimensions)
+	assert.False(t, reverse.ConsumeSuffix('[', ']'))
+	assert.Equal(t, "map[a]b", reverse.Unparsed())
+
+	// Parse the unconsumed prefix forwards
+	forward := reverse.Forward()
+	assert.Equal(t, "map", forward.Cursor().Literal)
+	assert.Equal(t, []string{"map", "[", "a", "]", "b"}, literals(forward.RemainingTokens()))
+
+	require.True(t, reverse.IsCursor(TokenIdent))
+	assert.True(t, reverse.ExpectPeek(']'))
+	assert.False(t, reverse.ExpectPeek(']'))
+	assert.Equal(t, "map[a]", reverse.Unparsed())
+
+	// Consume the remaining Tokens
+	for !reverse.Exhausted() {
+		reverse.AdvanceBack()
+	}
+
+	reverse.AdvanceBack()
+	assert.Equal(t, Token{TokenEoF, "", 2, 2, nil}, reverse.Cursor())
+	assert.True(t, reverse.IsPeek(TokenEoF))
+	assert.Equal(t, "", reverse.Unparsed())
+	assert.True(t, reverse.Forward().Exhausted())
+	assert.False(t, reverse.ConsumeSuffix(TokenIdent))
+
+	// Reversing an exhausted parser
+	assert.True(t, NewParser("").Reverse().Exhausted())
+}
@@ -0,0 +1,71 @@
+package symbolizer
+
+// Lexeme is a lightweight lexical token that only records its TokenKind and the byte offsets
+// of its literal within the scanned input. Unlike Token, the literal is not materialized
+// until requested, which allows tokenizing large inputs without allocating for every token.
+type Lexeme struct {
+	Kind  TokenKind
+	Start int
+	End   int
+}
+
+// Literal materializes the literal value of the Lexeme from the input it was scanned from
+func (lexeme Lexeme) Literal(input []byte) string {
+	return string(input[lexeme.Start:lexeme.End])
+}
+
+// Bytes returns the literal of the Lexeme as a sub-slice of the input it was scanned from.
+// The returned slice shares memory with the input and must not be modified.
+func (lexeme Lexeme) Bytes(input []byte) []byte {
+	return input[lexeme.Start:lexeme.End]
+}
+
+// Token materializes a Token for the Lexeme from the input it was scanned from
+func (lexeme Lexeme) Token(input []byte) Token {
+	return Token{lexeme.Kind, lexeme.Literal(input), lexeme.Start, lexeme.End, nil}
+}
+
+// Scanner is an allocation-free tokenizer that operates directly on a byte slice.
+// It produces Lexemes instead of Tokens, leaving it to the caller to materialize
+// the literal for only those lexemes that it is interested in.
+type Scanner struct {
+	lexer *lexer
+}
+
+// NewScanner generates a new Scanner for the given input bytes and some options that
+// modify the tokenization behaviour such as ignoring whitespaces or using custom keywords.
+// The input is not copied and must not be modified while the Scanner is in use.
+//
+// With the NFCNormalize option, an input that is not already in NFC is normalized into a copy, and the
+// offsets of the Lexemes are within that copy instead of the given input. Lexemes must then be materialized
+// from the input returned by Input, which is the given input itself when no normalization was necessary.
+func NewScanner(input []byte, opts ...ParserOption) *Scanner {
+	return &Scanner{lexer: newLexer(input, newParseConfig(opts...))}
+}
+
+// Next advances the Scanner and returns the encountered Lexeme.
+// Once the input is exhausted, an EoF Lexeme is returned for every call.
+func (scanner *Scanner) Next() Lexeme {
+	return scanner.lexer.next()
+}
+
+// Input returns the input bytes that the Scanner tokenizes and that the offsets of its Lexemes are within.
+// This is the input the Scanner was created with, unless it was normalized with the NFCNormalize option.
+// The returned slice must not be modified.
+func (scanner *Scanner) Input() []byte {
+	return scanner.lexer.input
+}
+
+// Done returns whether the Scanner has exhausted its input
+func (scanner *Scanner) Done() bool {
+	return scanner.lexer.done()
+}
+
+// Err returns the error that terminated the Scanner (such as an exceeded limit), if any
+func (scanner *Scanner) Err() error {
+	if scanner.lexer.err == nil {
+		return nil
+	}
+
+	return scanner.lexer.err
+}
@@ -0,0 +1,67 @@
+package symbolizer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanner(t *testing.T) {
+	tests := []struct {
+		input    string
+		options  []ParserOption
+		lexemes  []Lexeme
+		literals []string
+	}{
+		{
+			`map[string]uint64`, nil,
+			[]Lexeme{{TokenIdent, 0, 3}, {'[', 3, 4}, {TokenIdent, 4, 10}, {']', 10, 11}, {TokenIdent, 11, 17}, {TokenEoF, 17, 17}},
+			[]string{"map", "[", "string", "]", "uint64", ""},
+		},
+		{
+			`π = "ünïcode"`, []ParserOption{IgnoreWhitespaces()},
+			[]Lexeme{{TokenIdent, 0, 2}, {'=', 3, 4}, {TokenString, 5, 16}, {TokenEoF, 16, 16}},
+			[]string{"π", "=", `"ünïcode"`, ""},
+		},
+		{
+			`€*0x1F`, nil,
+			[]Lexeme{{'€', 0, 3}, {'*', 3, 4}, {TokenHexNumber, 4, 8}, {TokenEoF, 8, 8}},
+			[]string{"€", "*", "0x1F", ""},
+		},
+		{
+			"cafe\u0301 = \"e\u0301\"", []ParserOption{IgnoreWhitespaces(), NFCNormalize()},
+			[]Lexeme{{TokenIdent, 0, 5}, {'=', 6, 7}, {TokenString, 8, 12}, {TokenEoF, 12, 12}},
+			[]string{"café", "=", `"é"`, ""},
+		},
+	}
+
+	for _, test := range tests {
+		scanner := NewScanner([]byte(test.input), test.options...)
+		input := scanner.Input()
+
+		for idx, expected := range test.lexemes {
+			lexeme := scanner.Next()
+			assert.Equal(t, expected, lexeme)
+			assert.Equal(t, test.literals[idx], lexeme.Literal(input))
+			assert.Equal(t, Token{expected.Kind, test.literals[idx], expected.Start, expected.End, nil}, lexeme.Token(input))
+		}
+
+		assert.True(t, scanner.Done())
+		assert.Equal(t, TokenEoF, scanner.Next().Kind)
+	}
+}
+
+func TestScanner_Allocations(t *testing.T) {
+	input := []byte(strings.Repeat(`map[string]uint64{"key": 0xFF, value: -42, flag: true} `, 1000))
+	config := []ParserOption{IgnoreWhitespaces()}
+
+	allocs := testing.AllocsPerRun(10, func() {
+		scanner := NewScanner(input, config...)
+		for scanner.Next().Kind != TokenEoF {
+		}
+	})
+
+	// Only the Scanner, lexer and the parse config may be allocated
+	assert.LessOrEqual(t, allocs, float64(5))
+}
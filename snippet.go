@@ -0,0 +1,96 @@
+package symbolizer
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Snippet returns the source text surrounding the Token within the given input, with up to radius symbols on
+// either side of it, followed by a line with a caret marker under the Token (such as for 'a = 0xZ' below).
+// The snippet does not extend beyond the line of the Token. It is intended for readable error messages.
+//
+//	a = 0xZ
+//	    ^^^
+func (token Token) Snippet(input string, radius int) string {
+	return snippet(input, token.Position, token.End, radius)
+}
+
+// Source returns the line of the parser's input that contains the span between the given byte offsets (such as
+// the Position of an Error or the Span of a Token), followed by a line with a caret marker under the span.
+// Spans that extend beyond the line are marked until its end, and empty spans are marked with a single caret.
+func (parser *Parser) Source(start, end int) string {
+	return snippet(string(parser.scanner.input), start, end, -1)
+}
+
+// snippet returns the source text around the span between the given byte offsets with up to radius symbols
+// on either side of it (or the entire line if radius is negative) and a caret marker line under the span.
+func snippet(input string, start, end, radius int) string {
+	start, end = clampOffset(input, start), clampOffset(input, end)
+	if end < start {
+		end = start
+	}
+
+	// Determine the bounds of the line that contains the start of the span
+	lineStart := strings.LastIndexByte(input[:start], '\n') + 1
+	lineEnd := len(input)
+	if newline := strings.IndexByte(input[start:], '\n'); newline >= 0 {
+		lineEnd = start + newline
+	}
+
+	if end > lineEnd {
+		end = lineEnd
+	}
+
+	// Restrict the context to the radius around the span
+	from, to := lineStart, lineEnd
+	if radius >= 0 {
+		from, to = start, end
+		for count := 0; count < radius && from > lineStart; count++ {
+			_, width := utf8.DecodeLastRuneInString(input[lineStart:from])
+			from -= width
+		}
+
+		for count := 0; count < radius && to < lineEnd; count++ {
+			_, width := utf8.DecodeRuneInString(input[to:lineEnd])
+			to += width
+		}
+	}
+
+	context := strings.TrimSuffix(input[from:to], "\r")
+
+	// Pad the marker to the start of the span, retaining tabs for alignment
+	var marker strings.Builder
+	for _, symbol := range input[from:start] {
+		if symbol == '\t' {
+			marker.WriteByte('\t')
+		} else {
+			marker.WriteByte(' ')
+		}
+	}
+
+	carets := utf8.RuneCountInString(input[start:end])
+	if carets == 0 {
+		carets = 1
+	}
+
+	marker.WriteString(strings.Repeat("^", carets))
+	return context + "\n" + marker.String()
+}
+
+// clampOffset clamps a byte offset into the bounds of the input and
+// moves it back to the start of the symbol that it falls within
+func clampOffset(input string, offset int) int {
+	if offset < 0 {
+		return 0
+	}
+
+	if offset > len(input) {
+		return len(input)
+	}
+
+	for offset > 0 && offset < len(input) && !utf8.RuneStart(input[offset]) {
+		offset--
+	}
+
+	return offset
+}
@@ -0,0 +1,40 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken_Snippet(t *testing.T) {
+	input := "first line\nkey = 0xZZ, other = 42\nlast"
+
+	tests := []struct {
+		token  Token
+		radius int
+		output string
+	}{
+		{Token{TokenMalformed, "0x", 17, 19, nil}, 3, " = 0xZZ,\n   ^^"},
+		{Token{TokenNumber, "42", 31, 33, nil}, 8, "other = 42\n        ^^"},
+		{Token{TokenIdent, "first", 0, 5, nil}, 0, "first\n^^^^^"},
+		{EOFToken(len(input)), 2, "st\n  ^"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, test.token.Snippet(input, test.radius), test.token.Literal)
+	}
+
+	// The context is measured in symbols and tabs are retained for alignment
+	assert.Equal(t, "\tcafé x\n\t     ^", Token{TokenIdent, "x", 7, 8, nil}.Snippet("\tcafé x", 8))
+}
+
+func TestParser_Source(t *testing.T) {
+	parser := NewParser("{a: 1,\n b 2}", IgnoreWhitespaces())
+
+	_, err := parser.KeyedGroup(EnclosureCurly(), ':', ',')
+	assert.EqualError(t, err, "missing pair separator <unicode:':'> after key: 'b'")
+
+	position := parser.Errors()[0].Position
+	assert.Equal(t, " b 2}\n   ^", parser.Source(position, position+1))
+	assert.Equal(t, "{a: 1,\n^^^^^^", parser.Source(0, 100))
+}
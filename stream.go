@@ -0,0 +1,109 @@
+package symbolizer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+)
+
+// Tokens calls yield for each Token from the cursor until the end of the input, excluding the
+// EoF Token, advancing the parser past each yielded Token. Iteration stops early if yield returns
+// false, in which case the parser's cursor remains at the Token for which it returned false.
+// Since the module targets Go 1.18 (which has no iter package), Tokens is not declared as an
+// iter.Seq[Token], but the method value has the same shape and can be ranged over directly on
+// toolchains with range-over-func (such as `for token := range parser.Tokens`).
+func (parser *Parser) Tokens(yield func(Token) bool) {
+	for !parser.Exhausted() {
+		if !yield(parser.curr) {
+			return
+		}
+
+		parser.Advance()
+	}
+}
+
+// Stream returns a channel that receives each Token from the cursor until the end of the input,
+// excluding the EoF Token. The Tokens are scanned on a separate goroutine which advances the parser,
+// so the parser must not be used until the channel is closed. The channel is closed once the input
+// is exhausted or the given context is cancelled, after which ctx.Err() reports the cancellation.
+func (parser *Parser) Stream(ctx context.Context) <-chan Token {
+	stream := make(chan Token)
+
+	go func() {
+		defer close(stream)
+
+		parser.Tokens(func(token Token) bool {
+			// Prefer cancellation over a ready receiver
+			if ctx.Err() != nil {
+				return false
+			}
+
+			select {
+			case <-ctx.Done():
+				return false
+			case stream <- token:
+				return true
+			}
+		})
+	}()
+
+	return stream
+}
+
+// ForEachRecord reads records separated by the delimiter from the reader and calls fn with a Parser (generated
+// with the given options) positioned at the start of each record, such as for parsing log or export files with a
+// symbol on each line. The delimiter is not included in the records and a trailing '\r' is removed from records
+// when the delimiter is '\n'. Empty records are skipped. A single Parser and its buffers are reused for all the
+// records, so fn must not retain the Parser (or any Parsers cloned from it) after it returns.
+//
+// Iteration stops at the first error returned by fn, which is returned with the number of the record (counted
+// from 1, including empty records, such that it is the line number for the '\n' delimiter).
+// Returns any error from the reader other than io.EOF.
+func ForEachRecord(r io.Reader, delimiter rune, fn func(*Parser) error, opts ...ParserOption) error {
+	reader := bufio.NewReader(r)
+	parser := NewParser("", opts...)
+
+	encoded := []byte(string(delimiter))
+	last := encoded[len(encoded)-1]
+
+	var record []byte
+	count := 0
+
+	for {
+		// Read until the last byte of the delimiter, which may be followed by more of the record
+		chunk, err := reader.ReadSlice(last)
+		record = append(record, chunk...)
+
+		if err == bufio.ErrBufferFull || (err == nil && !bytes.HasSuffix(record, encoded)) {
+			continue
+		}
+
+		if err != nil && err != io.EOF {
+			return err
+		}
+
+		data := bytes.TrimSuffix(record, encoded)
+		if delimiter == '\n' {
+			data = bytes.TrimSuffix(data, []byte("\r"))
+		}
+
+		count++
+
+		if len(data) > 0 {
+			buffer := parser.scanner.input[:0:cap(parser.scanner.input)]
+			parser.reset(append(buffer, data...))
+
+			if err := fn(parser); err != nil {
+				return fmt.Errorf("record %d: %w", count, err)
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		record = record[:0]
+	}
+}
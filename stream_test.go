@@ -0,0 +1,127 @@
+package symbolizer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_Tokens(t *testing.T) {
+	tests := []struct {
+		input  string
+		limit  int
+		tokens []Token
+		cursor Token
+	}{
+		{
+			"a + 10", -1,
+			[]Token{{TokenIdent, "a", 0, 1, nil}, {TokenKind('+'), "+", 2, 3, nil}, {TokenNumber, "10", 4, 6, nil}},
+			EOFToken(6),
+		},
+		{
+			"a + 10", 2,
+			[]Token{{TokenIdent, "a", 0, 1, nil}, {TokenKind('+'), "+", 2, 3, nil}},
+			Token{TokenNumber, "10", 4, 6, nil},
+		},
+		{
+			"", -1,
+			nil,
+			EOFToken(0),
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, IgnoreWhitespaces())
+
+		var tokens []Token
+		parser.Tokens(func(token Token) bool {
+			if len(tokens) == test.limit {
+				return false
+			}
+
+			tokens = append(tokens, token)
+			return true
+		})
+
+		assert.Equal(t, test.tokens, tokens, test.input)
+		assert.Equal(t, test.cursor, parser.Cursor(), test.input)
+	}
+}
+
+func TestParser_Stream(t *testing.T) {
+	parser := NewParser("[a, b, c]")
+
+	var literals []string
+	for token := range parser.Stream(context.Background()) {
+		literals = append(literals, token.Literal)
+	}
+
+	assert.Equal(t, []string{"[", "a", ",", " ", "b", ",", " ", "c", "]"}, literals)
+	assert.True(t, parser.Exhausted())
+
+	// Cancelled streams must close without exhausting the parser
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	parser = NewParser("[a, b, c]")
+	for range parser.Stream(ctx) {
+	}
+
+	assert.False(t, parser.Exhausted())
+}
+
+func TestForEachRecord(t *testing.T) {
+	tests := []struct {
+		input     string
+		delimiter rune
+		records   []string
+	}{
+		{"a(1)\nb(2)\r\n\nc(3)", '\n', []string{"a(1)", "b(2)", "c(3)"}},
+		{"a(1)\nb(2)\n", '\n', []string{"a(1)", "b(2)"}},
+		{"a;b;;c;", ';', []string{"a", "b", "c"}},
+		{"a→b€c→d", '→', []string{"a", "b€c", "d"}},
+		{"", '\n', nil},
+		{strings.Repeat("x", bufio.MaxScanTokenSize) + "\ny", '\n', []string{strings.Repeat("x", bufio.MaxScanTokenSize), "y"}},
+	}
+
+	for _, test := range tests {
+		var records []string
+
+		err := ForEachRecord(iotest.HalfReader(strings.NewReader(test.input)), test.delimiter, func(parser *Parser) error {
+			records = append(records, parser.Unparsed())
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.records, records)
+	}
+
+	// Errors from the callback stop the iteration and report the record
+	var idents []string
+
+	err := ForEachRecord(strings.NewReader("a 1\nb 2\n\nc\nd 4"), '\n', func(parser *Parser) error {
+		ident, err := parser.ExpectIdent()
+		if err != nil {
+			return err
+		}
+
+		idents = append(idents, ident.Literal)
+		if !parser.IsCursor(TokenNumber) {
+			return errors.New("missing number")
+		}
+
+		return nil
+	}, IgnoreWhitespaces())
+
+	assert.EqualError(t, err, "record 4: missing number")
+	assert.Equal(t, []string{"a", "b", "c"}, idents)
+
+	// Errors from the reader are returned
+	err = ForEachRecord(iotest.ErrReader(errors.New("broken")), '\n', func(*Parser) error { return nil })
+	assert.EqualError(t, err, "broken")
+}
@@ -0,0 +1,170 @@
+package symbolizertest
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/manishmeganathan/symbolizer"
+)
+
+// GeneratorConfig is the configuration of the symbols generated by a Generator.
+// The zero value generates symbols with parenthesis enclosures and comma delimiters.
+type GeneratorConfig struct {
+	// Keywords are the literals of keywords that may be generated in place of identifiers.
+	// They are only tokenized as keywords if the symbols are tokenized with the same keywords.
+	Keywords []string
+	// Enclosures are the Enclosures that may wrap nested groups (defaults to EnclosureParens)
+	Enclosures []symbolizer.Enclosure
+	// Delimiters are the unicode characters that separate the elements of a group (defaults to ',')
+	Delimiters []rune
+	// MaxDepth is the maximum nesting depth of the Enclosures (defaults to 3)
+	MaxDepth int
+	// MaxElements is the maximum number of elements in a group (defaults to 4)
+	MaxElements int
+	// Spaces indicates if whitespaces may be generated after delimiters
+	Spaces bool
+}
+
+// Generator is a source of random symbols that are valid for a GeneratorConfig, for fuzzing and benchmarking
+// parsers built on symbolizer. A symbol is a delimited group of elements, where each element is a literal (an
+// identifier, keyword, number, hex number, string or boolean), an enclosed group or a literal followed by an
+// enclosed group (such as a call). Symbols never contain malformed Tokens and their enclosures are balanced.
+// The symbols are deterministic for a seed. A Generator is not safe for concurrent use.
+type Generator struct {
+	config GeneratorConfig
+	random *rand.Rand
+}
+
+// NewGenerator generates a new Generator of symbols for the GeneratorConfig, with the given random seed
+func NewGenerator(seed int64, config GeneratorConfig) *Generator {
+	if len(config.Enclosures) == 0 {
+		config.Enclosures = []symbolizer.Enclosure{symbolizer.EnclosureParens()}
+	}
+
+	if len(config.Delimiters) == 0 {
+		config.Delimiters = []rune{','}
+	}
+
+	if config.MaxDepth <= 0 {
+		config.MaxDepth = 3
+	}
+
+	if config.MaxElements <= 0 {
+		config.MaxElements = 4
+	}
+
+	return &Generator{config: config, random: rand.New(rand.NewSource(seed))}
+}
+
+// Symbol returns a random symbol
+func (generator *Generator) Symbol() string {
+	var symbol strings.Builder
+	generator.group(&symbol, 0)
+
+	return symbol.String()
+}
+
+// Symbols returns n random symbols
+func (generator *Generator) Symbols(n int) []string {
+	symbols := make([]string, n)
+	for idx := range symbols {
+		symbols[idx] = generator.Symbol()
+	}
+
+	return symbols
+}
+
+// Tokens returns a random symbol and its Tokens, tokenized with the given options
+func (generator *Generator) Tokens(opts ...symbolizer.ParserOption) (string, []symbolizer.Token) {
+	symbol := generator.Symbol()
+	return symbol, symbolizer.Tokenize(symbol, opts...)
+}
+
+// group writes a delimited group of elements at the given nesting depth
+func (generator *Generator) group(symbol *strings.Builder, depth int) {
+	delimiter := generator.config.Delimiters[generator.random.Intn(len(generator.config.Delimiters))]
+
+	for idx, count := 0, 1+generator.random.Intn(generator.config.MaxElements); idx < count; idx++ {
+		if idx > 0 {
+			symbol.WriteRune(delimiter)
+
+			if generator.config.Spaces && generator.random.Intn(2) == 0 {
+				symbol.WriteByte(' ')
+			}
+		}
+
+		generator.element(symbol, depth)
+	}
+}
+
+// element writes a literal, an enclosed group or a literal followed by an enclosed group
+func (generator *Generator) element(symbol *strings.Builder, depth int) {
+	// Elements at the maximum depth are always literals
+	variant := 0
+	if depth < generator.config.MaxDepth {
+		variant = generator.random.Intn(4)
+	}
+
+	if variant != 2 {
+		generator.literal(symbol)
+	}
+
+	if variant >= 2 {
+		enc := generator.config.Enclosures[generator.random.Intn(len(generator.config.Enclosures))]
+
+		symbol.WriteString(enc.Open())
+		generator.group(symbol, depth+1)
+		symbol.WriteString(enc.Close())
+	}
+}
+
+// literal writes a random literal
+func (generator *Generator) literal(symbol *strings.Builder) {
+	switch generator.random.Intn(6) {
+	case 0:
+		if len(generator.config.Keywords) != 0 {
+			symbol.WriteString(generator.config.Keywords[generator.random.Intn(len(generator.config.Keywords))])
+			return
+		}
+
+		symbol.WriteString(generator.word())
+
+	case 1:
+		symbol.WriteString(strconv.Itoa(generator.random.Intn(2001) - 1000))
+
+	case 2:
+		symbol.WriteString("0x")
+		for idx := 2 * (1 + generator.random.Intn(4)); idx > 0; idx-- {
+			symbol.WriteByte("0123456789abcdef"[generator.random.Intn(16)])
+		}
+
+	case 3:
+		symbol.WriteByte('"')
+		symbol.WriteString(generator.word())
+		symbol.WriteByte('"')
+
+	case 4:
+		symbol.WriteString(strconv.FormatBool(generator.random.Intn(2) == 0))
+
+	default:
+		symbol.WriteString(generator.word())
+	}
+}
+
+// word returns a random identifier of lowercase letters and digits that starts with a letter
+func (generator *Generator) word() string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+
+	word := make([]byte, 1+generator.random.Intn(8))
+	for idx := range word {
+		if idx > 0 && generator.random.Intn(4) == 0 {
+			word[idx] = byte('0' + generator.random.Intn(10))
+			continue
+		}
+
+		word[idx] = letters[generator.random.Intn(len(letters))]
+	}
+
+	return string(word)
+}
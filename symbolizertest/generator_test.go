@@ -0,0 +1,65 @@
+package symbolizertest
+
+import (
+	"testing"
+
+	"github.com/manishmeganathan/symbolizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator(t *testing.T) {
+	config := GeneratorConfig{
+		Keywords:   []string{"select", "from"},
+		Enclosures: []symbolizer.Enclosure{symbolizer.EnclosureParens(), symbolizer.EnclosureSquare()},
+		Delimiters: []rune{',', ';'},
+		MaxDepth:   2,
+		Spaces:     true,
+	}
+
+	// Generators with the same seed generate the same symbols
+	assert.Equal(t, NewGenerator(7, config).Symbols(10), NewGenerator(7, config).Symbols(10))
+	assert.NotEqual(t, NewGenerator(7, config).Symbols(10), NewGenerator(8, config).Symbols(10))
+
+	generator := NewGenerator(1, config)
+	keywords := symbolizer.Keywords(map[string]symbolizer.TokenKind{"select": -20, "from": -21})
+
+	for idx := 0; idx < 500; idx++ {
+		symbol, tokens := generator.Tokens(keywords)
+
+		depth, maxDepth := 0, 0
+		for _, token := range tokens {
+			require.NotEqual(t, symbolizer.TokenMalformed, token.Kind, symbol)
+
+			switch token.Kind {
+			case '(', '[':
+				depth++
+				if depth > maxDepth {
+					maxDepth = depth
+				}
+			case ')', ']':
+				depth--
+				require.GreaterOrEqual(t, depth, 0, symbol)
+			}
+		}
+
+		assert.Equal(t, 0, depth, symbol)
+		assert.LessOrEqual(t, maxDepth, 2, symbol)
+	}
+}
+
+func TestGenerator_Defaults(t *testing.T) {
+	for _, symbol := range NewGenerator(3, GeneratorConfig{}).Symbols(100) {
+		assert.NotContains(t, symbol, " ")
+		assert.NotContains(t, symbol, "[")
+		assert.NotContains(t, symbol, ";")
+	}
+}
+
+func BenchmarkGenerator(b *testing.B) {
+	generator := NewGenerator(1, GeneratorConfig{Spaces: true})
+
+	for i := 0; i < b.N; i++ {
+		generator.Symbol()
+	}
+}
@@ -0,0 +1,243 @@
+// Package symbolizertest provides helpers for testing grammars built on symbolizer against golden files.
+//
+// A golden file is a Fixture encoded as JSON, which records an input and the Tokens that it is expected to
+// generate. Fixtures are generated from inputs with GenerateFixtures (or by setting Update while running the
+// tests) and asserted with AssertGolden, AssertTokens or RunFixtures. The encoding of a Fixture is deterministic,
+// so that golden files can be checked into version control and reviewed as diffs.
+//
+// It also provides a Generator of random valid symbols for fuzzing and benchmarking parsers.
+package symbolizertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/manishmeganathan/symbolizer"
+)
+
+// Update indicates if the golden files asserted by AssertGolden, AssertTokens and RunFixtures are (re)written
+// with the actual Tokens instead of being compared to them. It is set if the SYMBOLIZER_UPDATE_GOLDEN
+// environment variable is non-empty, and can also be bound to a flag by the tests that use the package.
+var Update = os.Getenv("SYMBOLIZER_UPDATE_GOLDEN") != ""
+
+// Token is the JSON representation of a symbolizer.Token in a Fixture.
+// The Kind is the string representation of the TokenKind (such as "<ident>").
+type Token struct {
+	Kind    string `json:"kind"`
+	Literal string `json:"literal"`
+	Start   int    `json:"start"`
+	End     int    `json:"end"`
+}
+
+// String implements the Stringer interface for Token
+func (token Token) String() string {
+	return fmt.Sprintf("%v %q [%d:%d]", token.Kind, token.Literal, token.Start, token.End)
+}
+
+// Fixture is the contents of a golden file, with an input and the Tokens that it generates.
+// The Input is omitted for golden files of token streams that are asserted with AssertTokens.
+type Fixture struct {
+	Input  string  `json:"input,omitempty"`
+	Tokens []Token `json:"tokens"`
+}
+
+// NewFixture tokenizes the input with the given options and returns a Fixture of its Tokens
+func NewFixture(input string, opts ...symbolizer.ParserOption) Fixture {
+	return Fixture{Input: input, Tokens: Tokens(symbolizer.Tokenize(input, opts...))}
+}
+
+// Tokens returns the JSON representation of the given Tokens
+func Tokens(tokens []symbolizer.Token) []Token {
+	converted := make([]Token, 0, len(tokens))
+	for _, token := range tokens {
+		converted = append(converted, Token{token.Kind.String(), token.Literal, token.Position, token.End})
+	}
+
+	return converted
+}
+
+// ReadFixture reads the Fixture from the golden file at the given path
+func ReadFixture(path string) (Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixture{}, err
+	}
+
+	var fixture Fixture
+	if err = json.Unmarshal(data, &fixture); err != nil {
+		return Fixture{}, fmt.Errorf("invalid golden file %v: %w", path, err)
+	}
+
+	return fixture, nil
+}
+
+// WriteFixture writes the Fixture to the golden file at the given path, creating its directory if necessary.
+// The Fixture is encoded as indented JSON (without escaping HTML characters) and ends with a newline.
+func WriteFixture(path string, fixture Fixture) error {
+	var buffer bytes.Buffer
+
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(fixture); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buffer.Bytes(), 0o644)
+}
+
+// GenerateFixtures tokenizes each of the inputs with the given options and writes
+// its Fixture to a golden file named after its key (with a .json extension) in dir
+func GenerateFixtures(dir string, inputs map[string]string, opts ...symbolizer.ParserOption) error {
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := WriteFixture(filepath.Join(dir, name+".json"), NewFixture(inputs[name], opts...)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AssertGolden tokenizes the input with the given options and asserts that it matches the golden file at the
+// given path, which must have the same input. If Update is set, the golden file is written instead.
+// Returns whether the assertion succeeded.
+func AssertGolden(t testing.TB, path, input string, opts ...symbolizer.ParserOption) bool {
+	t.Helper()
+
+	actual := NewFixture(input, opts...)
+	if Update {
+		return write(t, path, actual)
+	}
+
+	expected, ok := read(t, path)
+	if !ok {
+		return false
+	}
+
+	if expected.Input != input {
+		t.Errorf("input does not match golden file %v:\nexpected: %q\nactual:   %q", path, expected.Input, input)
+		return false
+	}
+
+	return compare(t, path, expected.Tokens, actual.Tokens)
+}
+
+// AssertTokens asserts that the given Tokens match the Tokens of the golden file at the given path, for token
+// streams that are not generated by Tokenize (such as those of a Parser). If Update is set, the Tokens of the
+// golden file are written instead (retaining its input, if any). Returns whether the assertion succeeded.
+func AssertTokens(t testing.TB, path string, tokens []symbolizer.Token) bool {
+	t.Helper()
+
+	if Update {
+		// The input of an existing golden file is retained
+		fixture, err := ReadFixture(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("%v", err)
+			return false
+		}
+
+		fixture.Tokens = Tokens(tokens)
+		return write(t, path, fixture)
+	}
+
+	expected, ok := read(t, path)
+	if !ok {
+		return false
+	}
+
+	return compare(t, path, expected.Tokens, Tokens(tokens))
+}
+
+// RunFixtures runs a sub-test for each golden file with a .json extension in dir (named after the file without
+// its extension), which asserts that the input of the golden file generates its Tokens with the given options.
+func RunFixtures(t *testing.T, dir string, opts ...symbolizer.ParserOption) {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(paths) == 0 {
+		t.Fatalf("no golden files in %v", dir)
+	}
+
+	for _, path := range paths {
+		path := path
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+
+		t.Run(name, func(t *testing.T) {
+			fixture, ok := read(t, path)
+			if ok {
+				AssertGolden(t, path, fixture.Input, opts...)
+			}
+		})
+	}
+}
+
+// read reads the golden file at the given path and reports an error to t if it cannot be read
+func read(t testing.TB, path string) (Fixture, bool) {
+	t.Helper()
+
+	fixture, err := ReadFixture(path)
+	if err != nil {
+		t.Errorf("%v (set SYMBOLIZER_UPDATE_GOLDEN to generate it)", err)
+		return Fixture{}, false
+	}
+
+	return fixture, true
+}
+
+// write writes the golden file at the given path and reports an error to t if it cannot be written
+func write(t testing.TB, path string, fixture Fixture) bool {
+	t.Helper()
+
+	if err := WriteFixture(path, fixture); err != nil {
+		t.Errorf("cannot write golden file %v: %v", path, err)
+		return false
+	}
+
+	return true
+}
+
+// compare reports the first Token that differs between the expected and actual Tokens to t, if any
+func compare(t testing.TB, path string, expected, actual []Token) bool {
+	t.Helper()
+
+	for idx := 0; idx < len(expected) || idx < len(actual); idx++ {
+		switch {
+		case idx >= len(expected):
+			t.Errorf("tokens do not match golden file %v: unexpected token %d: %v", path, idx, actual[idx])
+		case idx >= len(actual):
+			t.Errorf("tokens do not match golden file %v: missing token %d: %v", path, idx, expected[idx])
+		case expected[idx] != actual[idx]:
+			t.Errorf("tokens do not match golden file %v: token %d:\nexpected: %v\nactual:   %v", path, idx, expected[idx], actual[idx])
+		default:
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,93 @@
+package symbolizertest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/manishmeganathan/symbolizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a testing.TB that records the failures reported to it
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (recorder *recorder) Helper() {}
+
+func (recorder *recorder) Errorf(format string, args ...any) {
+	recorder.failures = append(recorder.failures, fmt.Sprintf(format, args...))
+}
+
+func TestGenerateFixtures(t *testing.T) {
+	dir := t.TempDir()
+
+	err := GenerateFixtures(dir, map[string]string{"call": "f(a, 1)", "empty": ""}, symbolizer.IgnoreWhitespaces())
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(dir, "empty.json"))
+	require.NoError(t, err)
+	assert.Equal(t, `{
+  "tokens": [
+    {
+      "kind": "<eof>",
+      "literal": "",
+      "start": 0,
+      "end": 0
+    }
+  ]
+}
+`, string(data))
+
+	fixture, err := ReadFixture(filepath.Join(dir, "call.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "f(a, 1)", fixture.Input)
+	assert.Equal(t, []Token{
+		{"<ident>", "f", 0, 1},
+		{"<unicode:'('>", "(", 1, 2},
+		{"<ident>", "a", 2, 3},
+		{"<unicode:','>", ",", 3, 4},
+		{"<num>", "1", 5, 6},
+		{"<unicode:')'>", ")", 6, 7},
+		{"<eof>", "", 7, 7},
+	}, fixture.Tokens)
+
+	RunFixtures(t, dir, symbolizer.IgnoreWhitespaces())
+}
+
+func TestAssertGolden(t *testing.T) {
+	RunFixtures(t, "testdata")
+
+	path := filepath.Join("testdata", "pairs.json")
+
+	failed := new(recorder)
+	assert.False(t, AssertGolden(failed, path, "a=1; b=3"))
+	assert.Equal(t, []string{"input does not match golden file testdata/pairs.json:\nexpected: \"a=1; b=2\"\nactual:   \"a=1; b=3\""}, failed.failures)
+
+	failed = new(recorder)
+	assert.False(t, AssertGolden(failed, path, "a=1; b=2", symbolizer.IgnoreWhitespaces()))
+	assert.Equal(t, []string{"tokens do not match golden file testdata/pairs.json: token 4:\nexpected: <unicode:' '> \" \" [4:5]\nactual:   <ident> \"b\" [5:6]"}, failed.failures)
+
+	failed = new(recorder)
+	assert.False(t, AssertGolden(failed, filepath.Join("testdata", "missing.json"), "a"))
+	assert.Len(t, failed.failures, 1)
+}
+
+func TestAssertTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "golden", "tokens.json")
+	tokens := symbolizer.NewParser("a b").RemainingTokens()
+
+	Update = true
+	assert.True(t, AssertTokens(t, path, tokens))
+	Update = false
+
+	assert.True(t, AssertTokens(t, path, tokens))
+
+	failed := new(recorder)
+	assert.False(t, AssertTokens(failed, path, tokens[:2]))
+	assert.Equal(t, []string{"tokens do not match golden file " + path + ": missing token 2: <ident> \"b\" [2:3]"}, failed.failures)
+}
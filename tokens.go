@@ -1,18 +1,31 @@
 package symbolizer
 
 import (
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 )
 
 // TokenKind is an enum for representing token grouping/values.
 // For unicode tokens, the TokenKind is equal to its code point value.
 // For literal such identifiers and numerics, the TokenKind values descend from 0.
 // Note: Custom TokenKind values can be used by external packages for keyword detection
-// for special literals, but these values should be below -10 to prevent collisions
+// for special literals, but these values must be -10 or below to prevent collisions
+//
+// TokenMalformed Tokens are generated for symbols that cannot be scanned. Each spans the
+// malformed symbols and scanning resumes immediately after it, as described below:
+//   - An unterminated string spans from its opening quote to the end of the input.
+//   - A hex prefix '0x' (optionally signed) that is not followed by any hex characters spans the prefix.
+//   - An invalid UTF-8 byte spans that single byte.
+//
+// A '-' that is not followed by a decimal digit is not malformed and generates a unicode Token.
 type TokenKind int32
 
 const (
@@ -24,6 +37,23 @@ const (
 	TokenString
 	TokenBoolean
 	TokenHexNumber
+	TokenDuration
+	TokenTimestamp
+)
+
+// Optional token classes that are only generated when enabled with a ParserOption.
+// They are allocated from the bottom of the TokenKind range so that they
+// cannot collide with custom TokenKind values that descend from -10.
+const (
+	TokenBase64 TokenKind = math.MinInt32 + iota
+	TokenFloat
+	TokenSuffixed
+	TokenSemver
+	TokenAmount
+	TokenPlaceholder
+	TokenWildcard
+	TokenRegex
+	TokenNull
 )
 
 // String implements the Stringer interface for TokenKind
@@ -47,94 +77,526 @@ func (kind TokenKind) String() string {
 		return "<str>"
 	case TokenHexNumber:
 		return "<hex>"
+	case TokenDuration:
+		return "<duration>"
+	case TokenTimestamp:
+		return "<timestamp>"
+	case TokenBase64:
+		return "<base64>"
+	case TokenFloat:
+		return "<float>"
+	case TokenSuffixed:
+		return "<suffixed>"
+	case TokenSemver:
+		return "<semver>"
+	case TokenAmount:
+		return "<amount>"
+	case TokenPlaceholder:
+		return "<placeholder>"
+	case TokenWildcard:
+		return "<wildcard>"
+	case TokenRegex:
+		return "<regex>"
+	case TokenNull:
+		return "<null>"
 	default:
 		return fmt.Sprintf("<custom:%d>", kind)
 	}
 }
 
+// customKinds is the greatest custom TokenKind value, from which custom TokenKinds descend
+const customKinds TokenKind = -10
+
+// custom returns whether the TokenKind is a custom TokenKind (such as the TokenKind of a keyword),
+// which descend from -10 and are above the optional token classes
+func (kind TokenKind) custom() bool {
+	return kind <= customKinds && kind > TokenNull
+}
+
 // CanValue returns whether the TokenKind can be converted into a value
 func (kind TokenKind) CanValue() bool {
-	return kind == TokenNumber || kind == TokenString || kind == TokenBoolean || kind == TokenHexNumber
+	switch kind {
+	case TokenNumber, TokenString, TokenBoolean, TokenHexNumber, TokenDuration, TokenTimestamp, TokenBase64, TokenFloat, TokenSuffixed, TokenSemver, TokenAmount, TokenRegex, TokenNull:
+		return true
+	default:
+		return false
+	}
 }
 
 // UnicodeToken returns a Token for a given rune character.
 // The TokenKind of the returned Token has the same value as it's unicode code point.
 func UnicodeToken(char rune, pos int) Token {
-	return Token{TokenKind(char), string(char), pos}
+	literal := string(char)
+	return Token{TokenKind(char), literal, pos, pos + len(literal), nil}
 }
 
 // EOFToken returns an End of File Token
 func EOFToken(pos int) Token {
-	return Token{TokenEoF, "", pos}
+	return Token{TokenEoF, "", pos, pos, nil}
 }
 
+// Null is the value of TokenNull Tokens, which marks an explicitly null value (such as 'null' or 'nil')
+// to distinguish it from an absent value (nil) and from the zero value of any other type.
+type Null struct{}
+
+// String implements the Stringer interface for Null
+func (Null) String() string { return "null" }
+
 // Token represents a lexical Token.
-// It may be either a lone unicode character or some literal value
+// It may be either a lone unicode character or some literal value.
+// The Position and End of a Token are the byte offsets of the start and end
+// of its literal within the input, such that input[Position:End] is the literal.
+// The Data of a Token is a handle to the payload of the keyword that generated it, if any (see KeywordsWithData).
 type Token struct {
 	Kind     TokenKind
 	Literal  string
 	Position int
+	End      int
+	Data     *KeywordData
+}
+
+// KeywordData is a handle to the payload of a keyword provided with KeywordsWithData or the boolean value of a
+// spelling provided with BooleanKeywords. The Tokens of a keyword share the handle of its payload, such that
+// Tokens remain comparable with == regardless of the payload (which may be a func or a map).
+type KeywordData struct {
+	value any
+}
+
+// Value returns the payload of the keyword, or nil if the KeywordData is nil
+func (data *KeywordData) Value() any {
+	if data == nil {
+		return nil
+	}
+
+	return data.value
+}
+
+// Span returns the start and end byte offsets of the Token within the input
+func (token Token) Span() (int, int) {
+	return token.Position, token.End
 }
 
 // Value returns an object value for the Token.
-// If the Token is kind TokenString -> string (literal is returned as is)
-// If the Token is kind TokenBoolean -> bool (parsed with strconv.ParseBool)
+// If the Token is kind TokenString -> string (literal is returned without quotes, backticks or heredoc markers)
+// If the Token is kind TokenBoolean -> bool (its Data if it is a bool, see BooleanKeywords, or parsed with strconv.ParseBool)
 // If the Token is kind TokenNumber -> uint64/int64 (parsed with strconv depending on if a negative sign is present)
 // If the Token is kind TokenHexNumber -> []byte (decoded with hex.DecodeString after trimming the 0x)
-// All other Token kinds will return an error if attempted to convert to values
+// or int64 (parsed with strconv as base 16) if a negative sign is present
+// If the Token is kind TokenDuration -> time.Duration (parsed with time.ParseDuration)
+// If the Token is kind TokenTimestamp -> time.Time (parsed with time.Parse as RFC3339)
+// If the Token is kind TokenBase64 -> []byte (decoded with base64 after trimming the prefix and quotes)
+// If the Token is kind TokenFloat -> float64 (parsed with strconv.ParseFloat, regardless of the ExactDecimals
+// option since a Token carries no parser configuration, see Token.Rat or Parser.Value for the exact value)
+// If the Token is kind TokenSuffixed -> uint64/int64/float64 (with the multiplier of the suffix applied)
+// If the Token is kind TokenSemver -> Semver (with the major, minor and patch versions and any labels)
+// If the Token is kind TokenAmount -> Amount (with the currency and the amount as a *big.Rat, regardless of the
+// Decimals option since a Token carries no parser configuration, see Parser.Value for the configured decimals)
+// If the Token is kind TokenRegex -> *regexp.Regexp (compiled with its flags applied)
+// If the Token is kind TokenNull -> Null (the marker for a null value, distinct from the zero value of any type)
+// All other Token kinds will return an error if attempted to convert to values.
+// Errors are an *Error at the position of the Token that is classified as ErrInvalidValue,
+// ErrValueOverflow or ErrInvalidConversion, which can be checked for with errors.Is.
 func (token Token) Value() (any, error) {
 	switch token.Kind {
 
 	// String Value
 	case TokenString:
-		return strings.Trim(token.Literal, `"`), nil
+		switch {
+		case strings.HasPrefix(token.Literal, "`"):
+			return strings.Trim(token.Literal, "`"), nil
+		case strings.HasPrefix(token.Literal, "<<"):
+			return heredocContent(token.Literal), nil
+		default:
+			return strings.Trim(token.Literal, `"`), nil
+		}
 
 	// Boolean Value
 	case TokenBoolean:
+		if boolean, ok := token.Data.Value().(bool); ok {
+			return boolean, nil
+		}
+
 		boolean, err := strconv.ParseBool(token.Literal)
 		if err != nil {
-			return nil, errors.New("invalid boolean token: could not parse as boolean")
+			return nil, token.errorf(ErrInvalidValue, "invalid boolean token: could not parse as boolean")
 		}
 
 		return boolean, nil
 
 	// Hex Value
 	case TokenHexNumber:
-		data, err := hex.DecodeString(strings.TrimPrefix(token.Literal, "0x"))
+		// Negative Hex
+		if strings.HasPrefix(token.Literal, "-") {
+			number, err := strconv.ParseInt("-"+strings.TrimPrefix(token.Literal, "-0x"), 16, 64)
+			if err != nil {
+				return nil, token.errorf(numericSentinel(err), "invalid signed hex token: %v", err)
+			}
+
+			return number, nil
+		}
+
+		data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(token.Literal, "+"), "0x"))
+		if err != nil {
+			return nil, token.errorf(ErrInvalidValue, "invalid hex token: %v", err)
+		}
+
+		return data, nil
+
+	// Duration Value
+	case TokenDuration:
+		duration, err := time.ParseDuration(token.Literal)
+		if err != nil {
+			return nil, token.errorf(ErrInvalidValue, "invalid duration token: %v", err)
+		}
+
+		return duration, nil
+
+	// Timestamp Value
+	case TokenTimestamp:
+		timestamp, err := time.Parse(time.RFC3339Nano, token.Literal)
+		if err != nil {
+			return nil, token.errorf(ErrInvalidValue, "invalid timestamp token: %v", err)
+		}
+
+		return timestamp, nil
+
+	// Base64 Value
+	case TokenBase64:
+		quote := strings.IndexByte(token.Literal, '"')
+		if quote < 0 {
+			return nil, token.errorf(ErrInvalidValue, "invalid base64 token: missing quoted data: '%v'", token.Literal)
+		}
+
+		data, err := decodeBase64(token.Literal[quote:])
 		if err != nil {
-			return nil, fmt.Errorf("invalid hex token: %w", err)
+			return nil, token.errorf(ErrInvalidValue, "invalid base64 token: %v", err)
 		}
 
 		return data, nil
 
+	// Float Value
+	case TokenFloat:
+		number, err := strconv.ParseFloat(token.Literal, 64)
+		if err != nil {
+			return nil, token.errorf(numericSentinel(err), "invalid float token: %v", err)
+		}
+
+		return number, nil
+
+	// Suffixed Numeric Value
+	case TokenSuffixed:
+		value, err := suffixedValue(token.Literal)
+		return value, token.locate(err)
+
+	// Semantic Version Value
+	case TokenSemver:
+		value, err := semverValue(token.Literal)
+		return value, token.locate(err)
+
+	// Currency Amount Value
+	case TokenAmount:
+		value, err := amountValue(token.Literal, nil)
+		return value, token.locate(err)
+
+	// Regular Expression Value
+	case TokenRegex:
+		value, err := regexValue(token.Literal)
+		return value, token.locate(err)
+
+	// Null Value
+	case TokenNull:
+		return Null{}, nil
+
 	// Numeric Value
 	case TokenNumber:
 		// Negative Number
 		if strings.HasPrefix(token.Literal, "-") {
 			number, err := strconv.ParseInt(token.Literal, 10, 64)
 			if err != nil {
-				return nil, fmt.Errorf("invalid signed numeric token: %w", err)
+				return nil, token.errorf(numericSentinel(err), "invalid signed numeric token: %v", err)
 			}
 
 			return number, nil
 		}
 
-		number, err := strconv.ParseUint(token.Literal, 10, 64)
+		number, err := strconv.ParseUint(strings.TrimPrefix(token.Literal, "+"), 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("invalid numeric token: %w", err)
+			return nil, token.errorf(numericSentinel(err), "invalid numeric token: %v", err)
 		}
 
 		return number, nil
 
 	default:
-		return nil, fmt.Errorf("cannot generate from value from token of kind '%v'", token.Kind)
+		return nil, token.errorf(ErrInvalidConversion, "cannot generate from value from token of kind '%v'", token.Kind)
+	}
+}
+
+// BigValue returns the value of a numeric Token as a big.Int, for literals that overflow 64-bit integers.
+// Decimal literals of kind TokenNumber are parsed in base 10 and hex literals of kind TokenHexNumber are
+// parsed in base 16 (with the hex digits as the big-endian representation), both may have a sign.
+// All other Token kinds will return an error if attempted to convert to a big.Int
+func (token Token) BigValue() (*big.Int, error) {
+	var digits string
+	var base int
+
+	switch token.Kind {
+	case TokenNumber:
+		digits, base = token.Literal, 10
+	case TokenHexNumber:
+		digits, base = strings.Replace(token.Literal, "0x", "", 1), 16
+	default:
+		return nil, token.errorf(ErrInvalidConversion, "cannot generate big number from token of kind '%v'", token.Kind)
+	}
+
+	number, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, token.errorf(ErrInvalidValue, "invalid big number token: '%v'", token.Literal)
+	}
+
+	return number, nil
+}
+
+// Int64 returns the value of a numeric Token as an int64. Returns an error if the Token is not numeric, if
+// its value is not a whole number or if its value overflows an int64. Unsigned hex literals are interpreted
+// as the big-endian representation of an unsigned integer.
+func (token Token) Int64() (int64, error) {
+	number, err := token.integer()
+	if err != nil {
+		return 0, err
+	}
+
+	if !number.IsInt64() {
+		return 0, token.errorf(ErrValueOverflow, "value out of range for int64: '%v'", token.Literal)
+	}
+
+	return number.Int64(), nil
+}
+
+// Uint64 returns the value of a numeric Token as a uint64. Returns an error if the Token is not numeric,
+// if its value is not a whole number or if its value is negative or overflows a uint64. Unsigned hex
+// literals are interpreted as the big-endian representation of an unsigned integer.
+func (token Token) Uint64() (uint64, error) {
+	number, err := token.integer()
+	if err != nil {
+		return 0, err
+	}
+
+	if !number.IsUint64() {
+		return 0, token.errorf(ErrValueOverflow, "value out of range for uint64: '%v'", token.Literal)
+	}
+
+	return number.Uint64(), nil
+}
+
+// Float64 returns the value of a numeric Token as a float64. Integer values are converted to
+// the nearest float64. Returns an error if the Token is not numeric or its value is invalid.
+func (token Token) Float64() (float64, error) {
+	value, err := token.Value()
+	if err != nil {
+		return 0, err
 	}
+
+	switch value := value.(type) {
+	case float64:
+		return value, nil
+	case int64:
+		return float64(value), nil
+	case uint64:
+		return float64(value), nil
+	}
+
+	number, err := token.integer()
+	if err != nil {
+		return 0, err
+	}
+
+	float, _ := new(big.Float).SetInt(number).Float64()
+	return float, nil
+}
+
+// Rat returns the exact value of a numeric Token as a big.Rat, without the rounding of float64 values. Decimal,
+// hex, floating point and suffixed numeric literals are supported, as are the decimals of currency amounts.
+// Unsigned hex literals are interpreted as the big-endian representation of an unsigned integer.
+func (token Token) Rat() (*big.Rat, error) {
+	switch token.Kind {
+	case TokenNumber, TokenHexNumber:
+		number, err := token.BigValue()
+		if err != nil {
+			return nil, err
+		}
+
+		return new(big.Rat).SetInt(number), nil
+
+	case TokenFloat:
+		number, ok := new(big.Rat).SetString(token.Literal)
+		if !ok {
+			return nil, token.errorf(ErrInvalidValue, "invalid float token: '%v'", token.Literal)
+		}
+
+		return number, nil
+
+	case TokenSuffixed:
+		decimal, multiplier := splitSuffixed(token.Literal)
+
+		number, ok := new(big.Rat).SetString(decimal)
+		if !ok {
+			return nil, token.errorf(ErrInvalidValue, "invalid suffixed numeric token: '%v'", token.Literal)
+		}
+
+		return number.Mul(number, new(big.Rat).SetInt(new(big.Int).SetUint64(multiplier))), nil
+
+	case TokenAmount:
+		value, err := token.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		return value.(Amount).Value.(*big.Rat), nil
+	}
+
+	return nil, token.errorf(ErrInvalidConversion, "cannot convert token of kind '%v' to rational", token.Kind)
+}
+
+// Bytes returns the value of a Token that represents binary data (such as TokenHexNumber or TokenBase64) as a
+// []byte. Returns an error if the Token does not represent binary data (including signed hex literals).
+func (token Token) Bytes() ([]byte, error) {
+	value, err := token.Value()
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := value.([]byte)
+	if !ok {
+		return nil, token.errorf(ErrInvalidConversion, "cannot convert token of kind '%v' to bytes", token.Kind)
+	}
+
+	return data, nil
+}
+
+// Bool returns the value of a TokenBoolean Token as a bool.
+// Returns an error if the Token is not a boolean or its value is invalid.
+func (token Token) Bool() (bool, error) {
+	if token.Kind != TokenBoolean {
+		return false, token.errorf(ErrInvalidConversion, "cannot convert token of kind '%v' to bool", token.Kind)
+	}
+
+	value, err := token.Value()
+	if err != nil {
+		return false, err
+	}
+
+	return value.(bool), nil
+}
+
+// EnumValue returns the value of an identifier, keyword or string Token if it is one of the allowed values, such as
+// for option-like symbols. Strings are unquoted before they are matched. The value is matched case-sensitively,
+// use EnumValueFold to match it regardless of case. Returns an error classified as ErrInvalidConversion if the
+// Token is not an identifier, keyword or string, or as ErrInvalidValue if the value is not allowed.
+func (token Token) EnumValue(allowed ...string) (string, error) {
+	return token.enumValue(allowed, false)
+}
+
+// EnumValueFold returns the value of an identifier, keyword or string Token like EnumValue, but matches the value
+// against the allowed values regardless of case (with strings.EqualFold). The allowed value that was matched is
+// returned as the canonical form of the value, such that EnumValueFold("ASC", "asc", "desc") returns 'asc'.
+func (token Token) EnumValueFold(allowed ...string) (string, error) {
+	return token.enumValue(allowed, true)
+}
+
+// enumValue returns the allowed value that matches the value of the Token, regardless of case if fold is true
+func (token Token) enumValue(allowed []string, fold bool) (string, error) {
+	var value string
+
+	switch {
+	case token.Kind == TokenString:
+		unquoted, _ := token.Value()
+		value = unquoted.(string)
+	case token.Kind == TokenIdent, token.Kind.custom():
+		value = token.Literal
+	default:
+		return "", token.errorf(ErrInvalidConversion, "cannot convert token of kind '%v' to enum value", token.Kind)
+	}
+
+	for _, candidate := range allowed {
+		if value == candidate || (fold && strings.EqualFold(value, candidate)) {
+			return candidate, nil
+		}
+	}
+
+	return "", token.errorf(ErrInvalidValue, "invalid enum value '%v': expected '%v'", value, strings.Join(allowed, "' or '"))
+}
+
+// integer returns the value of a numeric Token as a big.Int.
+// Returns an error if the Token is not numeric or its value is not a whole number.
+func (token Token) integer() (*big.Int, error) {
+	switch token.Kind {
+	case TokenNumber, TokenHexNumber:
+		return token.BigValue()
+
+	case TokenFloat, TokenSuffixed:
+		value, err := token.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		switch value := value.(type) {
+		case int64:
+			return big.NewInt(value), nil
+		case uint64:
+			return new(big.Int).SetUint64(value), nil
+		case float64:
+			if math.IsInf(value, 0) || value != math.Trunc(value) {
+				return nil, token.errorf(ErrInvalidConversion, "value is not a whole number: '%v'", token.Literal)
+			}
+
+			number, _ := big.NewFloat(value).Int(nil)
+			return number, nil
+		}
+	}
+
+	return nil, token.errorf(ErrInvalidConversion, "cannot convert token of kind '%v' to integer", token.Kind)
+}
+
+// errorf generates a new Error at the position of the Token classified by the
+// sentinel error with a formatted message, for errors from converting the Token
+func (token Token) errorf(sentinel error, format string, args ...any) error {
+	return &Error{Position: token.Position, Message: fmt.Sprintf(format, args...), Err: sentinel}
+}
+
+// locate returns the Error from converting the literal of the Token at the position of the Token
+func (token Token) locate(err error) error {
+	if located, ok := err.(*Error); ok {
+		return &Error{Position: token.Position, Message: located.Message, Err: located.Err}
+	}
+
+	return err
+}
+
+// decodeBase64 decodes a quoted base64 string. The standard or URL-safe alphabet
+// is selected by the characters in the data and padding is optional.
+func decodeBase64(quoted string) ([]byte, error) {
+	data := strings.Trim(quoted, `"`)
+
+	encoding := base64.StdEncoding
+	if strings.ContainsAny(data, "-_") {
+		encoding = base64.URLEncoding
+	}
+
+	if !strings.HasSuffix(data, "=") {
+		encoding = encoding.WithPadding(base64.NoPadding)
+	}
+
+	return encoding.DecodeString(data)
 }
 
 // Enclosure is a tuple of unicode code points that indicate
 // start and stop pairs. They cannot be the same.
+// Enclosures can also have multi-character start and stop sequences (see NewSequenceEnclosure).
 type Enclosure struct {
 	start, stop rune
+	// open and close are the start and stop sequences of an Enclosure
+	// with multi-character sequences, which are empty otherwise
+	open, close string
 }
 
 // NewEnclosure generates a new Enclosure set and returns it.
@@ -144,25 +606,67 @@ func NewEnclosure(start, stop rune) (Enclosure, error) {
 		return Enclosure{}, errors.New("enclosure start and stop cannot be the same")
 	}
 
-	return Enclosure{start, stop}, nil
+	return Enclosure{start: start, stop: stop}, nil
+}
+
+// NewSequenceEnclosure generates a new Enclosure with multi-character start and stop sequences
+// such as `<%` and `%>`, `/*` and `*/` or `[[` and `]]`. The sequences are matched against the
+// input at the boundaries of Tokens, such that each sequence must span one or more whole Tokens.
+// Throws an error if either sequence is empty or if the sequences are identical.
+func NewSequenceEnclosure(start, stop string) (Enclosure, error) {
+	if start == "" || stop == "" {
+		return Enclosure{}, errors.New("enclosure start and stop cannot be empty")
+	}
+
+	if start == stop {
+		return Enclosure{}, errors.New("enclosure start and stop cannot be the same")
+	}
+
+	// Single character sequences are equivalent to an Enclosure of code points
+	if utf8.RuneCountInString(start) == 1 && utf8.RuneCountInString(stop) == 1 {
+		startRune, _ := utf8.DecodeRuneInString(start)
+		stopRune, _ := utf8.DecodeRuneInString(stop)
+
+		return Enclosure{start: startRune, stop: stopRune}, nil
+	}
+
+	return Enclosure{open: start, close: stop}, nil
+}
+
+// Open returns the start sequence of the Enclosure
+func (enc Enclosure) Open() string {
+	if enc.open != "" {
+		return enc.open
+	}
+
+	return string(enc.start)
+}
+
+// Close returns the stop sequence of the Enclosure
+func (enc Enclosure) Close() string {
+	if enc.close != "" {
+		return enc.close
+	}
+
+	return string(enc.stop)
 }
 
 // EnclosureParens returns an Enclosure set for Parenthesis '()'
 func EnclosureParens() Enclosure {
-	return Enclosure{'(', ')'}
+	return Enclosure{start: '(', stop: ')'}
 }
 
 // EnclosureSquare returns an Enclosure set for Square Brackets '[]'
 func EnclosureSquare() Enclosure {
-	return Enclosure{'[', ']'}
+	return Enclosure{start: '[', stop: ']'}
 }
 
 // EnclosureCurly returns an Enclosure set for Curly Brackets '{}'
 func EnclosureCurly() Enclosure {
-	return Enclosure{'{', '}'}
+	return Enclosure{start: '{', stop: '}'}
 }
 
 // EnclosureAngle returns an Enclosure set for Angle Brackets '<>'
 func EnclosureAngle() Enclosure {
-	return Enclosure{'<', '>'}
+	return Enclosure{start: '<', stop: '>'}
 }
@@ -1,6 +1,9 @@
 package symbolizer
 
 import (
+	"errors"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -22,6 +25,15 @@ func TestTokenKind_String(t *testing.T) {
 		{TokenHexNumber, "<hex>"},
 		{TokenBoolean, "<bool>"},
 		{TokenMalformed, "<malformed>"},
+		{TokenDuration, "<duration>"},
+		{TokenTimestamp, "<timestamp>"},
+		{TokenBase64, "<base64>"},
+		{TokenSemver, "<semver>"},
+		{TokenAmount, "<amount>"},
+		{TokenPlaceholder, "<placeholder>"},
+		{TokenWildcard, "<wildcard>"},
+		{TokenRegex, "<regex>"},
+		{TokenNull, "<null>"},
 	}
 
 	for _, test := range tests {
@@ -45,6 +57,10 @@ func TestTokenKind_CanValue(t *testing.T) {
 		{TokenHexNumber, true},
 		{TokenBoolean, true},
 		{TokenMalformed, false},
+		{TokenDuration, true},
+		{TokenTimestamp, true},
+		{TokenBase64, true},
+		{TokenNull, true},
 	}
 
 	for _, test := range tests {
@@ -65,12 +81,20 @@ func TestToken_Value(t *testing.T) {
 		{Token{Kind: TokenBoolean, Literal: "TRUE"}, true, ""},
 		{Token{Kind: TokenBoolean, Literal: "False"}, false, ""},
 		{Token{Kind: TokenBoolean, Literal: "Quantum"}, nil, "invalid boolean token: could not parse as boolean"},
+		{Token{Kind: TokenBoolean, Literal: "yes"}, nil, "invalid boolean token: could not parse as boolean"},
+
+		{Token{Kind: TokenNull, Literal: "null"}, Null{}, ""},
 
 		{Token{Kind: TokenHexNumber, Literal: "0x23ab8492"}, []byte{0x23, 0xab, 0x84, 0x92}, ""},
 		{Token{Kind: TokenHexNumber, Literal: "23ab8492"}, []byte{0x23, 0xab, 0x84, 0x92}, ""},
 		{Token{Kind: TokenHexNumber, Literal: "23ab842"}, nil, "invalid hex token: encoding/hex: odd length hex string"},
+		{Token{Kind: TokenHexNumber, Literal: "+0x23ab"}, []byte{0x23, 0xab}, ""},
+		{Token{Kind: TokenHexNumber, Literal: "-0xFF"}, int64(-255), ""},
+		{Token{Kind: TokenHexNumber, Literal: "-0x8000000000000000"}, int64(-9223372036854775808), ""},
+		{Token{Kind: TokenHexNumber, Literal: "-0x8000000000000001"}, nil, "invalid signed hex token: strconv.ParseInt: parsing \"-8000000000000001\": value out of range"},
 
 		{Token{Kind: TokenNumber, Literal: "9328572352"}, uint64(9328572352), ""},
+		{Token{Kind: TokenNumber, Literal: "+42"}, uint64(42), ""},
 		{Token{Kind: TokenNumber, Literal: "-9223372036854775807"}, int64(-9223372036854775807), ""},
 		{Token{Kind: TokenNumber, Literal: "18446744073709551615"}, uint64(18446744073709551615), ""},
 		{Token{Kind: TokenNumber, Literal: "1844674407370955161523123"}, nil, "invalid numeric token: strconv.ParseUint: parsing \"1844674407370955161523123\": value out of range"},
@@ -89,3 +113,192 @@ func TestToken_Value(t *testing.T) {
 		}
 	}
 }
+
+func TestToken_BigValue(t *testing.T) {
+	mustBig := func(value string, base int) *big.Int {
+		number, _ := new(big.Int).SetString(value, base)
+		return number
+	}
+
+	tests := []struct {
+		token Token
+		value *big.Int
+		err   string
+	}{
+		{Token{Kind: TokenNumber, Literal: "42"}, big.NewInt(42), ""},
+		{Token{Kind: TokenNumber, Literal: "+1844674407370955161523123"}, mustBig("1844674407370955161523123", 10), ""},
+		{Token{Kind: TokenNumber, Literal: "-18446744073709551616"}, mustBig("-18446744073709551616", 10), ""},
+		{Token{Kind: TokenHexNumber, Literal: "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"}, mustBig("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 16), ""},
+		{Token{Kind: TokenHexNumber, Literal: "-0x10000000000000000"}, mustBig("-10000000000000000", 16), ""},
+		{Token{Kind: TokenNumber, Literal: "12ab"}, nil, "invalid big number token: '12ab'"},
+		{Token{Kind: TokenString, Literal: `"12"`}, nil, "cannot generate big number from token of kind '<str>'"},
+	}
+
+	for _, test := range tests {
+		value, err := test.token.BigValue()
+
+		if test.err == "" {
+			require.NoError(t, err)
+			require.Equal(t, 0, test.value.Cmp(value), value.String())
+		} else {
+			require.Nil(t, value)
+			require.EqualError(t, err, test.err)
+		}
+	}
+}
+
+func TestToken_EnumValue(t *testing.T) {
+	allowed := []string{"asc", "desc"}
+
+	tests := []struct {
+		token     Token
+		value     string
+		folded    string
+		err, fold string
+	}{
+		{Token{Kind: TokenIdent, Literal: "asc"}, "asc", "asc", "", ""},
+		{Token{Kind: TokenString, Literal: `"desc"`}, "desc", "desc", "", ""},
+		{Token{Kind: -10, Literal: "DESC"}, "", "desc", "invalid enum value 'DESC': expected 'asc' or 'desc'", ""},
+		{Token{Kind: TokenIdent, Literal: "up"}, "", "", "invalid enum value 'up': expected 'asc' or 'desc'", "invalid enum value 'up': expected 'asc' or 'desc'"},
+		{Token{Kind: TokenNumber, Literal: "1"}, "", "", "cannot convert token of kind '<num>' to enum value", "cannot convert token of kind '<num>' to enum value"},
+		{Token{Kind: TokenRegex, Literal: "/asc/"}, "", "", "cannot convert token of kind '<regex>' to enum value", "cannot convert token of kind '<regex>' to enum value"},
+	}
+
+	for _, test := range tests {
+		value, err := test.token.EnumValue(allowed...)
+		if test.err != "" {
+			assert.EqualError(t, err, test.err)
+			assert.True(t, errors.Is(err, ErrInvalidValue) || errors.Is(err, ErrInvalidConversion))
+		} else {
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, test.value, value)
+
+		folded, err := test.token.EnumValueFold(allowed...)
+		if test.fold != "" {
+			assert.EqualError(t, err, test.fold)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, test.folded, folded)
+	}
+}
+
+func TestToken_TypedAccessors(t *testing.T) {
+	type result struct {
+		value any
+		err   string
+	}
+
+	tests := []struct {
+		token                                  Token
+		int64, uint64, float64, bytes, boolean result
+	}{
+		{
+			Token{Kind: TokenNumber, Literal: "42"},
+			result{int64(42), ""}, result{uint64(42), ""}, result{42.0, ""},
+			result{nil, "cannot convert token of kind '<num>' to bytes"}, result{false, "cannot convert token of kind '<num>' to bool"},
+		},
+		{
+			Token{Kind: TokenNumber, Literal: "-7"},
+			result{int64(-7), ""}, result{uint64(0), "value out of range for uint64: '-7'"}, result{-7.0, ""},
+			result{nil, "cannot convert token of kind '<num>' to bytes"}, result{false, "cannot convert token of kind '<num>' to bool"},
+		},
+		{
+			Token{Kind: TokenNumber, Literal: "18446744073709551615"},
+			result{int64(0), "value out of range for int64: '18446744073709551615'"}, result{uint64(math.MaxUint64), ""}, result{float64(math.MaxUint64), ""},
+			result{nil, "cannot convert token of kind '<num>' to bytes"}, result{false, "cannot convert token of kind '<num>' to bool"},
+		},
+		{
+			Token{Kind: TokenHexNumber, Literal: "0x01ff"},
+			result{int64(511), ""}, result{uint64(511), ""}, result{511.0, ""},
+			result{[]byte{0x01, 0xff}, ""}, result{false, "cannot convert token of kind '<hex>' to bool"},
+		},
+		{
+			Token{Kind: TokenFloat, Literal: "2.5e1"},
+			result{int64(25), ""}, result{uint64(25), ""}, result{25.0, ""},
+			result{nil, "cannot convert token of kind '<float>' to bytes"}, result{false, "cannot convert token of kind '<float>' to bool"},
+		},
+		{
+			Token{Kind: TokenFloat, Literal: "0.5"},
+			result{int64(0), "value is not a whole number: '0.5'"}, result{uint64(0), "value is not a whole number: '0.5'"}, result{0.5, ""},
+			result{nil, "cannot convert token of kind '<float>' to bytes"}, result{false, "cannot convert token of kind '<float>' to bool"},
+		},
+		{
+			Token{Kind: TokenSuffixed, Literal: "-2k"},
+			result{int64(-2000), ""}, result{uint64(0), "value out of range for uint64: '-2k'"}, result{-2000.0, ""},
+			result{nil, "cannot convert token of kind '<suffixed>' to bytes"}, result{false, "cannot convert token of kind '<suffixed>' to bool"},
+		},
+		{
+			Token{Kind: TokenBoolean, Literal: "true"},
+			result{int64(0), "cannot convert token of kind '<bool>' to integer"}, result{uint64(0), "cannot convert token of kind '<bool>' to integer"}, result{0.0, "cannot convert token of kind '<bool>' to integer"},
+			result{nil, "cannot convert token of kind '<bool>' to bytes"}, result{true, ""},
+		},
+		{
+			Token{Kind: TokenString, Literal: `"x"`},
+			result{int64(0), "cannot convert token of kind '<str>' to integer"}, result{uint64(0), "cannot convert token of kind '<str>' to integer"}, result{0.0, "cannot convert token of kind '<str>' to integer"},
+			result{nil, "cannot convert token of kind '<str>' to bytes"}, result{false, "cannot convert token of kind '<str>' to bool"},
+		},
+	}
+
+	check := func(expected result, value any, err error) {
+		if expected.err != "" {
+			assert.EqualError(t, err, expected.err)
+		} else {
+			assert.NoError(t, err)
+		}
+
+		if expected.value == nil {
+			assert.Nil(t, value)
+		} else {
+			assert.Equal(t, expected.value, value)
+		}
+	}
+
+	for _, test := range tests {
+		int64Value, err := test.token.Int64()
+		check(test.int64, int64Value, err)
+
+		uint64Value, err := test.token.Uint64()
+		check(test.uint64, uint64Value, err)
+
+		float64Value, err := test.token.Float64()
+		check(test.float64, float64Value, err)
+
+		bytesValue, err := test.token.Bytes()
+		check(test.bytes, bytesValue, err)
+
+		boolValue, err := test.token.Bool()
+		check(test.boolean, boolValue, err)
+	}
+}
+
+func TestToken_Rat(t *testing.T) {
+	tests := []struct {
+		token Token
+		value *big.Rat
+		err   string
+	}{
+		{Token{Kind: TokenNumber, Literal: "-42"}, big.NewRat(-42, 1), ""},
+		{Token{Kind: TokenHexNumber, Literal: "0x01ff"}, big.NewRat(511, 1), ""},
+		{Token{Kind: TokenFloat, Literal: "0.1"}, big.NewRat(1, 10), ""},
+		{Token{Kind: TokenFloat, Literal: "-1.5E-3"}, big.NewRat(-3, 2000), ""},
+		{Token{Kind: TokenSuffixed, Literal: "1.5Ki"}, big.NewRat(1536, 1), ""},
+		{Token{Kind: TokenAmount, Literal: "$19.99"}, big.NewRat(1999, 100), ""},
+		{Token{Kind: TokenString, Literal: `"x"`}, nil, "cannot convert token of kind '<str>' to rational"},
+	}
+
+	for _, test := range tests {
+		value, err := test.token.Rat()
+
+		if test.err == "" {
+			assert.NoError(t, err)
+			assert.Equal(t, test.value, value, test.token.Literal)
+		} else {
+			assert.Nil(t, value)
+			assert.EqualError(t, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package symbolizer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Logger is the interface of the logger used to trace a Parser with WithTrace.
+// It is satisfied by *log.Logger.
+type Logger interface {
+	Printf(format string, args ...any)
+}
+
+// WithTrace returns a ParserOption that traces the Parser with the given Logger, for debugging
+// grammars built on the Parser. Each Token consumed by the Parser is logged when it advances
+// past it, as is each parser primitive (such as Split, Unwrap or ExpectPeek) when it is invoked,
+// along with the Token at the cursor. Sub-parsers for nested content and clones of the Parser
+// trace to the same Logger, and their Token positions remain relative to the complete input.
+func WithTrace(logger Logger) ParserOption {
+	return func(config *parseConfig) {
+		config.trace = logger
+	}
+}
+
+// traceConsume logs the Token that the parser advances past, if tracing is enabled.
+// The zero Token that precedes the first Token of the input is not logged.
+func (parser *Parser) traceConsume(token Token) {
+	if parser.scanner.config.trace == nil || token.Kind == 0 {
+		return
+	}
+
+	parser.scanner.config.trace.Printf("consume %v '%v' at %d:%d", token.Kind, token.Literal, token.Position, token.End)
+}
+
+// trace logs the invocation of a parser primitive with its arguments and the Token at the cursor, if tracing is enabled
+func (parser *Parser) trace(primitive string, args ...any) {
+	if parser.scanner.config.trace == nil {
+		return
+	}
+
+	formatted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if enc, ok := arg.(Enclosure); ok {
+			arg = enc.Open() + enc.Close()
+		}
+
+		formatted = append(formatted, fmt.Sprint(arg))
+	}
+
+	parser.scanner.config.trace.Printf("%v(%v) at %d: cursor %v '%v'",
+		primitive, strings.Join(formatted, ", "), parser.curr.Position, parser.curr.Kind, parser.curr.Literal)
+}
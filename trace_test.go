@@ -0,0 +1,30 @@
+package symbolizer
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTrace(t *testing.T) {
+	var buffer bytes.Buffer
+
+	parser := NewParser("f(a) b", IgnoreWhitespaces(), WithTrace(log.New(&buffer, "", 0)))
+	assert.True(t, parser.ExpectPeek(TokenKind('(')))
+
+	_, err := parser.Unwrap(EnclosureParens())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, parser.Split(','))
+
+	assert.Equal(t, `ExpectPeek(<unicode:'('>) at 0: cursor <ident> 'f'
+consume <ident> 'f' at 0:1
+Unwrap(()) at 1: cursor <unicode:'('> '('
+consume <unicode:'('> '(' at 1:2
+consume <ident> 'a' at 2:3
+consume <unicode:')'> ')' at 3:4
+Split(<unicode:','>) at 5: cursor <ident> 'b'
+consume <ident> 'b' at 5:6
+`, buffer.String())
+}
@@ -0,0 +1,478 @@
+package symbolizer
+
+import "strings"
+
+// Node is a node of the syntax tree of a symbol parsed with ParseTree.
+// Every Node records the byte offsets of its source within the input.
+type Node interface {
+	// Span returns the start and end byte offsets of the source of the Node within the input
+	Span() (int, int)
+	// String returns the Node formatted as a symbol. Nodes parsed with ParseTree retain the trivia between their
+	// parts (such as whitespaces), so that unmodified Nodes reproduce their source exactly (see ParseTree).
+	String() string
+
+	node()
+}
+
+// IdentNode is an identifier (or a custom keyword) within a syntax tree
+type IdentNode struct {
+	Token Token
+}
+
+// LiteralNode is a literal that can be converted into a value (such as a number or string) within a syntax tree
+type LiteralNode struct {
+	Token Token
+	// Value is the value of the literal Token (see Token.Value)
+	Value any
+}
+
+// SymbolNode is any other Token (such as an operator like '*' or '->') within a syntax tree
+type SymbolNode struct {
+	Token Token
+}
+
+// GroupNode is a group of elements wrapped in curly brackets, square brackets or parenthesis and separated by ','
+type GroupNode struct {
+	Open, Close Token
+	Elements    []Node
+
+	// trivia is the source text before each element and before the closing Token (including the delimiters)
+	trivia []string
+}
+
+// KeyValueNode is a keyed element of a group such as `key: value` or `key = value`
+type KeyValueNode struct {
+	Key Node
+	// Separator is the ':' or '=' Token between the key and the value
+	Separator Token
+	Value     Node
+
+	// trivia is the source text before and after the separator
+	trivia []string
+}
+
+// CallNode is an expression that is called with a group of arguments in parenthesis such as `f(a, b)`
+type CallNode struct {
+	Callee Node
+	Args   *GroupNode
+}
+
+// IndexNode is an expression that is indexed with a group in square brackets such as `m[key]`
+type IndexNode struct {
+	Target Node
+	Index  *GroupNode
+}
+
+// SequenceNode is a sequence of juxtaposed expressions such as `map[string]string` or `a + b`
+type SequenceNode struct {
+	Nodes []Node
+
+	// trivia is the source text between each of the Nodes
+	trivia []string
+}
+
+// Span implements the Node interface for IdentNode
+func (node *IdentNode) Span() (int, int) { return node.Token.Span() }
+
+// Span implements the Node interface for LiteralNode
+func (node *LiteralNode) Span() (int, int) { return node.Token.Span() }
+
+// Span implements the Node interface for SymbolNode
+func (node *SymbolNode) Span() (int, int) { return node.Token.Span() }
+
+// Span implements the Node interface for GroupNode
+func (node *GroupNode) Span() (int, int) { return node.Open.Position, node.Close.End }
+
+// Span implements the Node interface for KeyValueNode
+func (node *KeyValueNode) Span() (int, int) { return spanOf(node.Key, node.Value) }
+
+// Span implements the Node interface for CallNode
+func (node *CallNode) Span() (int, int) { return spanOf(node.Callee, node.Args) }
+
+// Span implements the Node interface for IndexNode
+func (node *IndexNode) Span() (int, int) { return spanOf(node.Target, node.Index) }
+
+// Span implements the Node interface for SequenceNode
+func (node *SequenceNode) Span() (int, int) {
+	return spanOf(node.Nodes[0], node.Nodes[len(node.Nodes)-1])
+}
+
+// spanOf returns the span from the start of the first Node to the end of the last Node
+func spanOf(first, last Node) (int, int) {
+	start, _ := first.Span()
+	_, end := last.Span()
+
+	return start, end
+}
+
+// String implements the Node interface for IdentNode
+func (node *IdentNode) String() string { return node.Token.Literal }
+
+// String implements the Node interface for LiteralNode
+func (node *LiteralNode) String() string { return node.Token.Literal }
+
+// String implements the Node interface for SymbolNode
+func (node *SymbolNode) String() string { return node.Token.Literal }
+
+// String implements the Node interface for GroupNode. If the elements were replaced with a different number of
+// elements, the elements are formatted with ', ' between them within the opening and closing Tokens.
+func (node *GroupNode) String() string {
+	if len(node.trivia) == len(node.Elements)+1 {
+		var formatted strings.Builder
+		formatted.WriteString(node.Open.Literal)
+
+		for idx, element := range node.Elements {
+			formatted.WriteString(node.trivia[idx])
+			formatted.WriteString(element.String())
+		}
+
+		formatted.WriteString(node.trivia[len(node.Elements)])
+		formatted.WriteString(node.Close.Literal)
+
+		return formatted.String()
+	}
+
+	elements := make([]string, 0, len(node.Elements))
+	for _, element := range node.Elements {
+		elements = append(elements, element.String())
+	}
+
+	return node.Open.Literal + strings.Join(elements, ", ") + node.Close.Literal
+}
+
+// String implements the Node interface for KeyValueNode. If the KeyValueNode was not parsed with ParseTree,
+// the ':' separator is followed by a space, while the '=' separator is not surrounded by spaces.
+func (node *KeyValueNode) String() string {
+	if len(node.trivia) == 2 {
+		return node.Key.String() + node.trivia[0] + node.Separator.Literal + node.trivia[1] + node.Value.String()
+	}
+
+	if node.Separator.Kind == ':' {
+		return node.Key.String() + ": " + node.Value.String()
+	}
+
+	return node.Key.String() + node.Separator.Literal + node.Value.String()
+}
+
+// String implements the Node interface for CallNode
+func (node *CallNode) String() string { return node.Callee.String() + node.Args.String() }
+
+// String implements the Node interface for IndexNode
+func (node *IndexNode) String() string { return node.Target.String() + node.Index.String() }
+
+// String implements the Node interface for SequenceNode. If the Nodes were replaced with a different number
+// of Nodes, Nodes that were adjacent in the input remain adjacent, while others are separated by a space.
+func (node *SequenceNode) String() string {
+	exact := len(node.trivia) == len(node.Nodes)-1
+
+	var formatted strings.Builder
+	for idx, element := range node.Nodes {
+		switch {
+		case idx == 0:
+		case exact:
+			formatted.WriteString(node.trivia[idx-1])
+		default:
+			if _, end := node.Nodes[idx-1].Span(); end != startOf(element) {
+				formatted.WriteByte(' ')
+			}
+		}
+
+		formatted.WriteString(element.String())
+	}
+
+	return formatted.String()
+}
+
+// startOf returns the start byte offset of a Node
+func startOf(node Node) int {
+	start, _ := node.Span()
+	return start
+}
+
+func (*IdentNode) node()    {}
+func (*LiteralNode) node()  {}
+func (*SymbolNode) node()   {}
+func (*GroupNode) node()    {}
+func (*KeyValueNode) node() {}
+func (*CallNode) node()     {}
+func (*IndexNode) node()    {}
+func (*SequenceNode) node() {}
+
+// ParseTree parses an arbitrary symbol such as `map[string]uint64`, `transfer(to: 0xab, amount: 1.5k)` or
+// `{a: [1, 2], b: f(x)[0]}` into a syntax tree. Identifiers (and custom keywords), literals and other Tokens
+// generate IdentNode, LiteralNode and SymbolNode leaves. Curly brackets, square brackets and parenthesis generate
+// a GroupNode of elements separated by ',', where each element may be keyed with ':' or '='. An expression that
+// is immediately followed by parenthesis or square brackets generates a CallNode or an IndexNode, while
+// expressions that follow each other generate a SequenceNode. Whitespaces are always ignored.
+//
+// The Nodes retain the trivia between their parts (the whitespaces, delimiters and any Tokens dropped by a
+// TokenFilter such as comments), so that the String of the returned Node reproduces the input byte-for-byte
+// (excluding any leading or trailing whitespace), unless the tree is modified. Modified leaves are formatted
+// with their new literal within the original trivia, while groups and sequences whose number of elements is
+// modified are formatted without their original trivia.
+//
+// Returns an error if the symbol is empty or malformed (such as an unclosed group or a ',' outside of a group).
+func ParseTree(input string, opts ...ParserOption) (Node, error) {
+	parser := NewParser(input, opts...)
+	parser.skipSpaces()
+
+	node, err := parser.parseSequence()
+	if err != nil {
+		return nil, err
+	}
+
+	if !parser.Exhausted() {
+		return nil, parser.errorf(parser.curr.Position, "unexpected token: '%v'", parser.curr.Literal)
+	}
+
+	if err := parser.Errors().Err(); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// parseSequence parses the expressions from the cursor until a ',', a group closer or the end of the input.
+// A single expression is returned as is, while multiple expressions are returned as a SequenceNode.
+func (parser *Parser) parseSequence() (Node, error) {
+	var nodes []Node
+
+	for !parser.Exhausted() && !parser.IsCursor(',') && !isGroupCloser(parser.curr.Kind) {
+		node, err := parser.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, node)
+		parser.skipSpaces()
+	}
+
+	switch len(nodes) {
+	case 0:
+		return nil, parser.errorf(parser.curr.Position, "missing expression")
+	case 1:
+		return nodes[0], nil
+	}
+
+	sequence := &SequenceNode{Nodes: nodes}
+	for idx := 1; idx < len(nodes); idx++ {
+		_, end := nodes[idx-1].Span()
+		sequence.trivia = append(sequence.trivia, parser.scanner.collectBetween(end, startOf(nodes[idx])))
+	}
+
+	return sequence, nil
+}
+
+// parseExpression parses an expression at the cursor along with any calls
+// or indexes that immediately follow it, without any whitespace in between.
+func (parser *Parser) parseExpression() (Node, error) {
+	node, err := parser.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, end := node.Span(); parser.curr.Position == end; _, end = node.Span() {
+		switch {
+		case parser.IsCursor('('):
+			args, err := parser.parseGroupNode(')')
+			if err != nil {
+				return nil, err
+			}
+
+			node = &CallNode{node, args}
+
+		case parser.IsCursor('['):
+			index, err := parser.parseGroupNode(']')
+			if err != nil {
+				return nil, err
+			}
+
+			node = &IndexNode{node, index}
+
+		default:
+			return node, nil
+		}
+	}
+
+	return node, nil
+}
+
+// parsePrimary parses a leaf Node or a group at the cursor
+func (parser *Parser) parsePrimary() (Node, error) {
+	token := parser.curr
+
+	if closer, ok := groupCloser(token.Kind); ok {
+		return parser.parseGroupNode(closer)
+	}
+
+	switch kind := token.Kind; {
+	case kind == TokenMalformed:
+		return nil, parser.errorf(token.Position, "malformed token: '%v'", token.Literal)
+
+	case kind.CanValue():
+		value, err := parser.tokenValue(token)
+		if err != nil {
+			return nil, err
+		}
+
+		parser.Advance()
+		return &LiteralNode{token, value}, nil
+
+	case kind == TokenIdent || (kind.custom() && len(parser.scanner.config.keywordsOf(kind)) != 0):
+		parser.Advance()
+		return &IdentNode{token}, nil
+
+	default:
+		parser.Advance()
+		return &SymbolNode{token}, nil
+	}
+}
+
+// parseGroupNode parses the group that opens at the cursor and closes with the given closer
+func (parser *Parser) parseGroupNode(closer TokenKind) (*GroupNode, error) {
+	if err := parser.descend(); err != nil {
+		return nil, err
+	}
+
+	defer parser.ascend()
+
+	group := &GroupNode{Open: parser.curr}
+	parser.Advance()
+
+	// last is the end of the previous part of the group
+	last := group.Open.End
+
+	for parser.skipSpaces(); !parser.IsCursor(closer); parser.skipSpaces() {
+		if parser.Exhausted() {
+			return nil, parser.sentinelf(group.Open.Position, ErrUnterminatedEnclosure, "missing end of enclosure: '%v'", string(rune(closer)))
+		}
+
+		element, err := parser.parseElement()
+		if err != nil {
+			return nil, err
+		}
+
+		group.Elements = append(group.Elements, element)
+		group.trivia = append(group.trivia, parser.scanner.collectBetween(last, startOf(element)))
+		_, last = element.Span()
+
+		// Move past the element delimiter
+		switch {
+		case parser.IsCursor(','):
+			parser.Advance()
+		case !parser.IsCursor(closer) && !parser.Exhausted():
+			return nil, parser.errorf(parser.curr.Position, "expected ',' or '%v', found '%v'", string(rune(closer)), parser.curr.Literal)
+		}
+	}
+
+	group.Close = parser.curr
+	group.trivia = append(group.trivia, parser.scanner.collectBetween(last, group.Close.Position))
+	parser.Advance()
+
+	return group, nil
+}
+
+// parseElement parses an element of a group, which is either an expression or a key and an
+// expression separated by ':' or '='. Keys are a single identifier or literal Token.
+func (parser *Parser) parseElement() (Node, error) {
+	if parser.curr.Kind == TokenIdent || parser.curr.Kind.CanValue() {
+		if parser.peekPastSpaces(':') || parser.peekPastSpaces('=') {
+			key, err := parser.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+
+			parser.skipSpaces()
+			separator := parser.curr
+			parser.Advance()
+			parser.skipSpaces()
+
+			value, err := parser.parseSequence()
+			if err != nil {
+				return nil, err
+			}
+
+			_, end := key.Span()
+			trivia := []string{
+				parser.scanner.collectBetween(end, separator.Position),
+				parser.scanner.collectBetween(separator.End, startOf(value)),
+			}
+
+			return &KeyValueNode{Key: key, Separator: separator, Value: value, trivia: trivia}, nil
+		}
+	}
+
+	return parser.parseSequence()
+}
+
+// Walk traverses the syntax tree of the given Node in depth-first order, calling visit for each Node before its
+// children. The children of a Node are skipped if visit returns false for it. Children are visited in the order that
+// they appear in the input, such as the callee of a CallNode before its arguments and a key before its value.
+func Walk(node Node, visit func(Node) bool) {
+	if node == nil || !visit(node) {
+		return
+	}
+
+	for _, child := range children(node) {
+		Walk(child, visit)
+	}
+}
+
+// Rewrite transforms the syntax tree of the given Node by replacing each Node with the Node returned by fn for it,
+// such as for renaming identifiers or normalizing literals. The tree is rewritten bottom-up, so fn receives each
+// Node after its children have been rewritten. Nodes are updated in place and the rewritten root Node is returned,
+// which can be formatted back into a symbol with its String method. The arguments of a CallNode and the index of
+// an IndexNode can only be replaced with another *GroupNode, other replacements for them are ignored.
+func Rewrite(node Node, fn func(Node) Node) Node {
+	switch node := node.(type) {
+	case *GroupNode:
+		for idx, element := range node.Elements {
+			node.Elements[idx] = Rewrite(element, fn)
+		}
+
+	case *KeyValueNode:
+		node.Key, node.Value = Rewrite(node.Key, fn), Rewrite(node.Value, fn)
+
+	case *CallNode:
+		node.Callee = Rewrite(node.Callee, fn)
+		node.Args = rewriteGroup(node.Args, fn)
+
+	case *IndexNode:
+		node.Target = Rewrite(node.Target, fn)
+		node.Index = rewriteGroup(node.Index, fn)
+
+	case *SequenceNode:
+		for idx, element := range node.Nodes {
+			node.Nodes[idx] = Rewrite(element, fn)
+		}
+	}
+
+	return fn(node)
+}
+
+// rewriteGroup rewrites a GroupNode that must remain a GroupNode, ignoring any other replacement
+func rewriteGroup(group *GroupNode, fn func(Node) Node) *GroupNode {
+	if rewritten, ok := Rewrite(group, fn).(*GroupNode); ok {
+		return rewritten
+	}
+
+	return group
+}
+
+// children returns the child Nodes of a Node in the order that they appear in the input
+func children(node Node) []Node {
+	switch node := node.(type) {
+	case *GroupNode:
+		return node.Elements
+	case *KeyValueNode:
+		return []Node{node.Key, node.Value}
+	case *CallNode:
+		return []Node{node.Callee, node.Args}
+	case *IndexNode:
+		return []Node{node.Target, node.Index}
+	case *SequenceNode:
+		return node.Nodes
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,154 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTree(t *testing.T) {
+	node, err := ParseTree(`transfer(to: 0xab, amount = 42)[0]`)
+	require.NoError(t, err)
+
+	index, ok := node.(*IndexNode)
+	require.True(t, ok)
+
+	call := index.Target.(*CallNode)
+	assert.Equal(t, &IdentNode{Token{TokenIdent, "transfer", 0, 8, nil}}, call.Callee)
+	assert.Len(t, call.Args.Elements, 2)
+
+	to := call.Args.Elements[0].(*KeyValueNode)
+	assert.Equal(t, &IdentNode{Token{TokenIdent, "to", 9, 11, nil}}, to.Key)
+	assert.Equal(t, &LiteralNode{Token{TokenHexNumber, "0xab", 13, 17, nil}, []byte{0xab}}, to.Value)
+
+	amount := call.Args.Elements[1].(*KeyValueNode)
+	assert.Equal(t, TokenKind('='), amount.Separator.Kind)
+	assert.Equal(t, uint64(42), amount.Value.(*LiteralNode).Value)
+
+	assert.Equal(t, &LiteralNode{Token{TokenNumber, "0", 32, 33, nil}, uint64(0)}, index.Index.Elements[0])
+
+	start, end := node.Span()
+	assert.Equal(t, []int{0, 34}, []int{start, end})
+}
+
+func TestParseTree_String(t *testing.T) {
+	tests := []struct {
+		input  string
+		output string
+		err    string
+	}{
+		{"map[string]uint64", "map[string]uint64", ""},
+		{" { a:1 ,b : [ 1,2 ] , c=f( x )[0] } ", "{ a:1 ,b : [ 1,2 ] , c=f( x )[0] }", ""},
+		{"a  +  b*c", "a  +  b*c", ""},
+		{"([{}])", "([{}])", ""},
+		{"(a, )", "(a, )", ""},
+		{"", "", "missing expression"},
+		{"(a,, b)", "", "missing expression"},
+		{"a, b", "", "unexpected token: ','"},
+		{"[1, 2", "", "missing end of enclosure: ']'"},
+		{"{a: 1]", "", "expected ',' or '}', found ']'"},
+		{`f("x`, "", "malformed token: '\"x'"},
+	}
+
+	for _, test := range tests {
+		node, err := ParseTree(test.input)
+
+		if test.err == "" {
+			require.NoError(t, err, test.input)
+			assert.Equal(t, test.output, node.String(), test.input)
+		} else {
+			assert.Nil(t, node)
+			assert.EqualError(t, err, test.err, test.input)
+		}
+	}
+}
+
+func TestParseTree_Trivia(t *testing.T) {
+	// Comments that are dropped by a filter are retained as trivia
+	comments := []ParserOption{
+		CustomScanner(func(char rune) bool { return char == '#' }, func(cursor *LexerCursor) Token {
+			for !cursor.Done() && cursor.Char() != '\n' {
+				cursor.Advance()
+			}
+
+			return cursor.Token(-20)
+		}),
+		TokenFilter(func(token Token) (Token, bool) { return token, token.Kind != -20 }),
+	}
+
+	input := "{\n  a: 1, # first\n  b  =  x # second\n}"
+	node, err := ParseTree(input, comments...)
+	require.NoError(t, err)
+	assert.Equal(t, input, node.String())
+
+	// Modified leaves retain the original trivia
+	group := node.(*GroupNode)
+	group.Elements[1].(*KeyValueNode).Value.(*IdentNode).Token.Literal = "y"
+	assert.Equal(t, "{\n  a: 1, # first\n  b  =  y # second\n}", node.String())
+
+	// Groups with a different number of elements drop their own trivia, while children keep theirs
+	group.Elements = append(group.Elements, &IdentNode{Token{TokenIdent, "c", 0, 1, nil}})
+	assert.Equal(t, "{a: 1, b  =  y, c}", node.String())
+
+	// Nodes that are not parsed are formatted without trivia
+	sequence := &SequenceNode{Nodes: []Node{&IdentNode{Token{TokenIdent, "a", 0, 1, nil}}, &SymbolNode{Token{'*', "*", 2, 3, nil}}}}
+	assert.Equal(t, "a *", sequence.String())
+}
+
+func TestWalk(t *testing.T) {
+	node, err := ParseTree(`f(a, {b: g(c)}) d`)
+	require.NoError(t, err)
+
+	var idents []string
+	Walk(node, func(node Node) bool {
+		if ident, ok := node.(*IdentNode); ok {
+			idents = append(idents, ident.Token.Literal)
+		}
+
+		// Skip the arguments of nested calls
+		_, group := node.(*GroupNode)
+		return !group || startOf(node) < 5
+	})
+
+	assert.Equal(t, []string{"f", "a", "d"}, idents)
+}
+
+func TestRewrite(t *testing.T) {
+	node, err := ParseTree(`{owner: alice, amount: 1.5k, spender: alice}`, SuffixedNumbers())
+	require.NoError(t, err)
+
+	node = Rewrite(node, func(node Node) Node {
+		switch node := node.(type) {
+		// Rename an identifier
+		case *IdentNode:
+			if node.Token.Literal == "alice" {
+				node.Token.Literal = "bob"
+			}
+
+		// Normalize suffixed literals into their value
+		case *LiteralNode:
+			if node.Token.Kind == TokenSuffixed {
+				return &LiteralNode{Token{Kind: TokenFloat, Literal: "1500"}, 1500.0}
+			}
+		}
+
+		return node
+	})
+
+	assert.Equal(t, "{owner: bob, amount: 1500, spender: bob}", node.String())
+
+	// The arguments of a call cannot be replaced with a Node that is not a group
+	call, err := ParseTree(`f(x)`)
+	require.NoError(t, err)
+
+	call = Rewrite(call, func(node Node) Node {
+		if _, ok := node.(*GroupNode); ok {
+			return &IdentNode{Token{TokenIdent, "y", 0, 1, nil}}
+		}
+
+		return node
+	})
+
+	assert.Equal(t, "f(x)", call.String())
+}
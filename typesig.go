@@ -0,0 +1,230 @@
+package symbolizer
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TypeKind is an enum for representing the kind of type described by a TypeNode
+type TypeKind int
+
+const (
+	TypeNamed TypeKind = iota
+	TypePointer
+	TypeSlice
+	TypeArray
+	TypeMap
+)
+
+// String implements the Stringer interface for TypeKind
+func (kind TypeKind) String() string {
+	switch kind {
+	case TypeNamed:
+		return "named"
+	case TypePointer:
+		return "pointer"
+	case TypeSlice:
+		return "slice"
+	case TypeArray:
+		return "array"
+	case TypeMap:
+		return "map"
+	default:
+		return fmt.Sprintf("TypeKind(%d)", int(kind))
+	}
+}
+
+// TypeNode is a node in the tree of a parsed Go type signature.
+// Fields that are not relevant for the Kind of the node are left empty.
+type TypeNode struct {
+	Kind TypeKind
+	// Name is the (possibly package qualified) name of a TypeNamed
+	Name string
+	// Args are the type arguments of a generic TypeNamed
+	Args []*TypeNode
+	// Length is the length of a TypeArray
+	Length uint64
+	// Key is the key type of a TypeMap
+	Key *TypeNode
+	// Elem is the element type of a TypePointer, TypeSlice, TypeArray or TypeMap
+	Elem *TypeNode
+	// Position is the byte offset of the type within the input
+	Position int
+}
+
+// String returns the canonical Go representation of the type described by the TypeNode
+func (node TypeNode) String() string {
+	switch node.Kind {
+	case TypePointer:
+		return "*" + node.Elem.String()
+	case TypeSlice:
+		return "[]" + node.Elem.String()
+	case TypeArray:
+		return fmt.Sprintf("[%d]%v", node.Length, node.Elem)
+	case TypeMap:
+		return fmt.Sprintf("map[%v]%v", node.Key, node.Elem)
+	default:
+		if len(node.Args) == 0 {
+			return node.Name
+		}
+
+		args := make([]string, 0, len(node.Args))
+		for _, arg := range node.Args {
+			args = append(args, arg.String())
+		}
+
+		return fmt.Sprintf("%v[%v]", node.Name, strings.Join(args, ", "))
+	}
+}
+
+// ParseTypeSignature parses a Go type signature such as `map[string][]*pkg.List[int]` into a tree of
+// TypeNodes. Slices, arrays, maps, pointers, package qualified names and generic type arguments are
+// supported and may be nested arbitrarily. Whitespace between the components of the type is ignored.
+// Returns an error if the input is not a valid type signature or has trailing data after the type.
+// Options such as MaxDepth can be provided to limit the nesting depth of the type.
+func ParseTypeSignature(input string, opts ...ParserOption) (TypeNode, error) {
+	parser := NewParser(input, append([]ParserOption{IgnoreWhitespaces()}, opts...)...)
+
+	node, err := parser.parseType()
+	if err != nil {
+		return TypeNode{}, err
+	}
+
+	if !parser.Exhausted() {
+		return TypeNode{}, parser.errorf(parser.curr.Position, "unexpected token after type: '%v'", parser.curr.Literal)
+	}
+
+	return *node, nil
+}
+
+// parseType parses a type signature beginning at the cursor
+// and leaves the parser at the token following the type.
+func (parser *Parser) parseType() (*TypeNode, error) {
+	if err := parser.descend(); err != nil {
+		return nil, err
+	}
+
+	defer parser.ascend()
+
+	node := &TypeNode{Position: parser.curr.Position}
+
+	switch {
+	// Pointer Type
+	case parser.IsCursor('*'):
+		parser.Advance()
+		node.Kind = TypePointer
+
+		return parser.parseElemType(node)
+
+	// Slice Type
+	case parser.IsCursor('[') && parser.IsPeek(']'):
+		parser.Advance()
+		parser.Advance()
+		node.Kind = TypeSlice
+
+		return parser.parseElemType(node)
+
+	// Array Type
+	case parser.IsCursor('['):
+		if !parser.ExpectPeek(TokenNumber) {
+			return nil, parser.errorf(parser.next.Position, "invalid array length: '%v'", parser.next.Literal)
+		}
+
+		// Array lengths must be unsigned
+		length, err := parser.curr.Value()
+		if _, unsigned := length.(uint64); err != nil || !unsigned {
+			return nil, parser.errorf(parser.curr.Position, "invalid array length: '%v'", parser.curr.Literal)
+		}
+
+		if !parser.ExpectPeek(']') {
+			return nil, parser.errorf(parser.next.Position, "missing end of array length: ']'")
+		}
+
+		parser.Advance()
+		node.Kind, node.Length = TypeArray, length.(uint64)
+
+		return parser.parseElemType(node)
+
+	// Map Type
+	case parser.IsCursor(TokenIdent) && parser.curr.Literal == "map" && parser.IsPeek('['):
+		parser.Advance()
+		parser.Advance()
+		node.Kind = TypeMap
+
+		key, err := parser.parseType()
+		if err != nil {
+			return nil, err
+		}
+
+		if !parser.IsCursor(']') {
+			return nil, parser.errorf(parser.curr.Position, "missing end of map key: ']'")
+		}
+
+		parser.Advance()
+		node.Key = key
+
+		return parser.parseElemType(node)
+
+	// Named Type
+	case parser.IsCursor(TokenIdent):
+		return parser.parseNamedType(node)
+
+	default:
+		return nil, parser.errorf(parser.curr.Position, "expected type, found '%v'", parser.curr.Literal)
+	}
+}
+
+// parseElemType parses the element type for the given node beginning at the cursor
+func (parser *Parser) parseElemType(node *TypeNode) (*TypeNode, error) {
+	elem, err := parser.parseType()
+	if err != nil {
+		return nil, err
+	}
+
+	node.Elem = elem
+	return node, nil
+}
+
+// parseNamedType parses a package qualified type name and its
+// generic type arguments (if any) beginning at the cursor.
+func (parser *Parser) parseNamedType(node *TypeNode) (*TypeNode, error) {
+	node.Kind, node.Name = TypeNamed, parser.curr.Literal
+	parser.Advance()
+
+	// Collect the package qualified components of the name
+	for parser.IsCursor('.') {
+		if !parser.ExpectPeek(TokenIdent) {
+			return nil, parser.errorf(parser.next.Position, "expected qualified type name, found '%v'", parser.next.Literal)
+		}
+
+		node.Name += "." + parser.curr.Literal
+		parser.Advance()
+	}
+
+	// Collect the generic type arguments
+	if parser.IsCursor('[') {
+		parser.Advance()
+
+		for {
+			arg, err := parser.parseType()
+			if err != nil {
+				return nil, err
+			}
+
+			node.Args = append(node.Args, arg)
+
+			if parser.IsCursor(']') {
+				parser.Advance()
+				break
+			}
+
+			if !parser.IsCursor(',') {
+				return nil, parser.errorf(parser.curr.Position, "missing end of type arguments: ']'")
+			}
+
+			parser.Advance()
+		}
+	}
+
+	return node, nil
+}
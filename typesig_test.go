@@ -0,0 +1,78 @@
+package symbolizer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTypeSignature(t *testing.T) {
+	tests := []struct {
+		input  string
+		output TypeNode
+		str    string
+		error  string
+	}{
+		{
+			"string",
+			TypeNode{Kind: TypeNamed, Name: "string"},
+			"string", "",
+		},
+		{
+			"map[string]string",
+			TypeNode{Kind: TypeMap, Key: &TypeNode{Kind: TypeNamed, Name: "string", Position: 4}, Elem: &TypeNode{Kind: TypeNamed, Name: "string", Position: 11}},
+			"map[string]string", "",
+		},
+		{
+			"[32]uint64",
+			TypeNode{Kind: TypeArray, Length: 32, Elem: &TypeNode{Kind: TypeNamed, Name: "uint64", Position: 4}},
+			"[32]uint64", "",
+		},
+		{
+			"[]*big.Int",
+			TypeNode{Kind: TypeSlice, Elem: &TypeNode{Kind: TypePointer, Position: 2, Elem: &TypeNode{Kind: TypeNamed, Name: "big.Int", Position: 3}}},
+			"[]*big.Int", "",
+		},
+		{
+			"Pair[ string, []List[int] ]",
+			TypeNode{Kind: TypeNamed, Name: "Pair", Args: []*TypeNode{
+				{Kind: TypeNamed, Name: "string", Position: 6},
+				{Kind: TypeSlice, Position: 14, Elem: &TypeNode{Kind: TypeNamed, Name: "List", Position: 16, Args: []*TypeNode{
+					{Kind: TypeNamed, Name: "int", Position: 21},
+				}}},
+			}},
+			"Pair[string, []List[int]]", "",
+		},
+		{
+			"map[[2]byte]map[string]*T",
+			TypeNode{
+				Kind: TypeMap,
+				Key:  &TypeNode{Kind: TypeArray, Length: 2, Position: 4, Elem: &TypeNode{Kind: TypeNamed, Name: "byte", Position: 7}},
+				Elem: &TypeNode{Kind: TypeMap, Position: 12, Key: &TypeNode{Kind: TypeNamed, Name: "string", Position: 16}, Elem: &TypeNode{
+					Kind: TypePointer, Position: 23, Elem: &TypeNode{Kind: TypeNamed, Name: "T", Position: 24},
+				}},
+			},
+			"map[[2]byte]map[string]*T", "",
+		},
+		{"[-1]int", TypeNode{}, "", "invalid array length: '-1'"},
+		{"[32int", TypeNode{}, "", "missing end of array length: ']'"},
+		{"map[string", TypeNode{}, "", "missing end of map key: ']'"},
+		{"List[int, string", TypeNode{}, "", "missing end of type arguments: ']'"},
+		{"pkg.", TypeNode{}, "", "expected qualified type name, found ''"},
+		{"*", TypeNode{}, "", "expected type, found ''"},
+		{"string]", TypeNode{}, "", "unexpected token after type: ']'"},
+	}
+
+	for _, test := range tests {
+		node, err := ParseTypeSignature(test.input)
+
+		if test.error != "" {
+			assert.EqualError(t, err, test.error, test.input)
+			continue
+		}
+
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.output, node, test.input)
+		assert.Equal(t, test.str, node.String())
+	}
+}
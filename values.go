@@ -0,0 +1,303 @@
+package symbolizer
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// KeyedGroup parses a group of key-value pairs wrapped in the given Enclosure such as `{name: "alice", 0x01: true}`
+// into a map. The cursor must be the opening character of the Enclosure and the parser is advanced past its closing
+// character. Pairs are separated by delim and each key is separated from its value by sep.
+//
+// Keys may be of any token kind (identifiers, numbers, hex, strings or custom keyword kinds). They are converted
+// with Token.Value when possible and fall back to the literal of the key otherwise (or if the converted value cannot
+// be used as a map key, such as the []byte of hex keys). Values that are a single token are also converted with
+// Token.Value, values that are themselves wrapped in the Enclosure are parsed as nested groups and all other
+// values are returned as their source text. Returns an error if any key is repeated within the group.
+//
+// If the parser was created with the RecoverMalformed option, malformed pairs, repeated keys and values that
+// cannot be converted are omitted from the group and the errors are only accumulated into the parser's Errors.
+//
+// The parser is left at the remainder of the input after the group, which is rejected with the RejectTrailing option.
+func (parser *Parser) KeyedGroup(enc Enclosure, sep, delim TokenKind, opts ...ValueOption) (map[any]any, error) {
+	group := make(map[any]any)
+
+	err := parser.walkGroup(enc, sep, delim, false, func(key any, _ Token, value any, _ []Token) {
+		group[key] = value
+	})
+
+	if err == nil {
+		err = parser.checkTrailing(newValueConfig(opts...))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// Value parses the single value at the cursor with Token.Value (such as a number, string or boolean) and advances
+// the parser past it. Returns an Error classified as ErrUnexpectedToken if the Token at the cursor cannot be
+// converted into a value. The parser is left at the remainder of the input after the value, which is rejected
+// with the RejectTrailing option. Conversion errors are accumulated into the Errors of the parser.
+func (parser *Parser) Value(opts ...ValueOption) (any, error) {
+	token := parser.curr
+	if !token.Kind.CanValue() {
+		return nil, parser.sentinelf(token.Position, ErrUnexpectedToken, "expected value, found %v", describeToken(token))
+	}
+
+	value, err := parser.tokenValue(token)
+	if err != nil {
+		return nil, err
+	}
+
+	parser.Advance()
+
+	if err = parser.checkTrailing(newValueConfig(opts...)); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// checkTrailing returns an Error classified as ErrTrailingInput at the position of the first Token after the
+// cursor that is not a whitespace, if the RejectTrailing option is enabled. The parser is not advanced.
+func (parser *Parser) checkTrailing(config *valueConfig) error {
+	if !config.rejectTrailing {
+		return nil
+	}
+
+	for _, token := range parser.RemainingTokens() {
+		if !isSpaceToken(token) {
+			return parser.sentinelf(token.Position, ErrTrailingInput, "unexpected trailing input after value: %v", describeToken(token))
+		}
+	}
+
+	return nil
+}
+
+// Located is a value parsed from the input along with the location of its source within the input,
+// which allows validation layers to point at the exact part of the input that a bad value came from.
+type Located struct {
+	// Key is the key Token of the value within its group
+	Key Token
+	// Value is the parsed value. Nested groups are a map[any]Located.
+	Value any
+	// Position and End are the byte offsets of the start and end of the source of
+	// the value. Empty values have an empty span at the end of their key.
+	Position, End int
+}
+
+// KeyedGroupLocated parses a group of key-value pairs wrapped in the given Enclosure like KeyedGroup, but each
+// value in the returned map is a Located value which records the key Token and the span of the value's source.
+// Nested groups are also parsed into a map of Located values.
+func (parser *Parser) KeyedGroupLocated(enc Enclosure, sep, delim TokenKind, opts ...ValueOption) (map[any]Located, error) {
+	group := make(map[any]Located)
+
+	err := parser.walkGroup(enc, sep, delim, true, func(key any, keyToken Token, value any, tokens []Token) {
+		located := Located{Key: keyToken, Value: value, Position: keyToken.End, End: keyToken.End}
+		if len(tokens) != 0 {
+			located.Position, located.End = tokens[0].Position, tokens[len(tokens)-1].End
+		}
+
+		group[key] = located
+	})
+
+	if err == nil {
+		err = parser.checkTrailing(newValueConfig(opts...))
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// walkGroup parses a group of key-value pairs wrapped in the given Enclosure and calls set for each of its pairs
+// with the key, the key Token, the converted value and the value Tokens. If located is true, nested groups are
+// parsed with KeyedGroupLocated, otherwise they are parsed with KeyedGroup. See KeyedGroup for the semantics.
+func (parser *Parser) walkGroup(enc Enclosure, sep, delim TokenKind, located bool, set func(any, Token, any, []Token)) error {
+	inner, err := parser.enclosedParser(enc)
+	if err != nil {
+		return err
+	}
+
+	var (
+		keys       = make(map[any]struct{})
+		recovering = parser.scanner.config.recover
+		pairErr    error
+	)
+
+	// Walk the pairs in the enclosed data
+	err = inner.parsePairs(sep, delim, []Enclosure{enc}, func(keyToken Token, valueTokens []Token) bool {
+		// Skip malformed pairs that were recovered from
+		if keyToken.Kind == TokenMalformed {
+			return true
+		}
+
+		key := keyValue(keyToken)
+		if _, exists := keys[key]; exists {
+			pairErr = inner.errorf(keyToken.Position, "duplicate key in group: '%v'", keyToken.Literal)
+			return recovering
+		}
+
+		value, err := inner.groupValue(valueTokens, enc, sep, delim, located)
+		if err != nil {
+			pairErr = err
+			return recovering
+		}
+
+		keys[key] = struct{}{}
+		set(key, keyToken, value, valueTokens)
+
+		return true
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if pairErr != nil && !recovering {
+		return pairErr
+	}
+
+	return nil
+}
+
+// groupValue converts a run of value Tokens within a KeyedGroup into a value.
+// If located is true, nested groups are parsed with KeyedGroupLocated.
+func (parser *Parser) groupValue(tokens []Token, enc Enclosure, sep, delim TokenKind, located bool) (any, error) {
+	switch {
+	// Empty Value
+	case len(tokens) == 0:
+		return nil, nil
+
+	// Single Token Value
+	case len(tokens) == 1 && tokens[0].Kind.CanValue():
+		return parser.tokenValue(tokens[0])
+
+	// Nested Group
+	case parser.wraps(tokens, enc):
+		nested := parser.subParser(tokens[0].Position, tokens[len(tokens)-1].End)
+		if located {
+			return nested.KeyedGroupLocated(enc, sep, delim)
+		}
+
+		return nested.KeyedGroup(enc, sep, delim)
+
+	// Source Text
+	default:
+		return parser.scanner.collectBetween(tokens[0].Position, tokens[len(tokens)-1].End), nil
+	}
+}
+
+// keyValue converts a key Token into a value that can be used as a map key.
+// The Token value is used if it can be generated and is comparable,
+// otherwise the literal of the key Token is used.
+func keyValue(token Token) any {
+	if !token.Kind.CanValue() {
+		return token.Literal
+	}
+
+	value, err := token.Value()
+	if err != nil || !reflect.TypeOf(value).Comparable() {
+		return token.Literal
+	}
+
+	return value
+}
+
+// tokenValue converts a Token into a value with Token.Value, honouring the value options of the parser.
+// If AllowBigNumbers is enabled, numerics that overflow 64-bit integers are returned as a big.Int.
+// If PadOddHex is enabled, hex literals with an odd number of digits are left-padded with a zero.
+// If Decimals is specified, the decimals of amounts are constructed with its DecimalConstructor.
+// If ExactDecimals is enabled, fractional numerics are converted into exact decimals (see exactValue).
+// If RawRegex is enabled, regular expressions are converted into their raw pattern instead of being compiled.
+// Conversion errors are accumulated into the Errors of the parser at the position of the Token.
+func (parser *Parser) tokenValue(token Token) (any, error) {
+	if parser.scanner.config.padHex {
+		token = padHexToken(token)
+	}
+
+	// Construct the decimals of amounts with the configured constructor
+	if token.Kind == TokenAmount && parser.scanner.config.decimal != nil {
+		value, err := amountValue(token.Literal, parser.scanner.config.decimal)
+		if err != nil {
+			return nil, parser.valueError(token.Position, err)
+		}
+
+		return value, nil
+	}
+
+	// Convert regular expressions into their raw pattern
+	if token.Kind == TokenRegex && parser.scanner.config.rawRegex {
+		pattern, err := regexPattern(token.Literal)
+		if err != nil {
+			return nil, parser.valueError(token.Position, err)
+		}
+
+		return pattern, nil
+	}
+
+	// Convert fractional numerics into exact decimals
+	if parser.scanner.config.exact && isFractional(token) {
+		return parser.exactValue(token)
+	}
+
+	value, err := token.Value()
+
+	// Fallback to big numbers for numerics that are out of range
+	if err != nil && parser.scanner.config.bigNumbers && errors.Is(err, ErrValueOverflow) && (token.Kind == TokenNumber || token.Kind == TokenHexNumber) {
+		return token.BigValue()
+	}
+
+	if err != nil {
+		return nil, parser.valueError(token.Position, err)
+	}
+
+	return value, nil
+}
+
+// isFractional returns whether the Token is a numeric that may have a fractional part
+func isFractional(token Token) bool {
+	return token.Kind == TokenFloat || (token.Kind == TokenSuffixed && strings.Contains(token.Literal, "."))
+}
+
+// exactValue converts a fractional numeric Token into a *big.Rat or into the
+// value constructed from its exact decimal digits with the configured constructor.
+func (parser *Parser) exactValue(token Token) (any, error) {
+	number, err := token.Rat()
+	if err != nil {
+		return nil, parser.valueError(token.Position, err)
+	}
+
+	constructor := parser.scanner.config.decimal
+	if constructor == nil {
+		return number, nil
+	}
+
+	value, err := constructor(decimalDigits(number))
+	if err != nil {
+		return nil, parser.sentinelf(token.Position, ErrInvalidValue, "invalid decimal token: %v", err)
+	}
+
+	return value, nil
+}
+
+// padHexToken returns the given Token with a zero inserted after the '0x' prefix if it
+// is a hex Token with an odd number of digits (0x1A2 -> 0x01A2). Other Tokens are unchanged.
+func padHexToken(token Token) Token {
+	if token.Kind != TokenHexNumber {
+		return token
+	}
+
+	prefix := strings.Index(token.Literal, "0x") + 2
+	if (len(token.Literal)-prefix)%2 == 1 {
+		token.Literal = token.Literal[:prefix] + "0" + token.Literal[prefix:]
+	}
+
+	return token
+}
@@ -0,0 +1,197 @@
+package symbolizer
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParser_KeyedGroup(t *testing.T) {
+	mustEnclose := func(enclosure Enclosure, err error) Enclosure {
+		if err != nil {
+			panic(err)
+		}
+
+		return enclosure
+	}
+
+	tests := []struct {
+		input    string
+		options  []ParserOption
+		enclose  Enclosure
+		output   map[any]any
+		error    string
+		unparsed string
+	}{
+		{
+			`{name: "alice", age: 32, admin: false}`, []ParserOption{IgnoreWhitespaces()}, EnclosureCurly(),
+			map[any]any{"name": "alice", "age": uint64(32), "admin": false}, "", "",
+		},
+		{
+			`{1: a, -2: 0x0f, "three": map[string]string, 0xff: , true: x}rest`, []ParserOption{IgnoreWhitespaces()}, EnclosureCurly(),
+			map[any]any{uint64(1): "a", int64(-2): []byte{0x0f}, "three": "map[string]string", "0xff": nil, true: "x"}, "", "rest",
+		},
+		{
+			`(outer: (inner: 1, deep: (x: y)), kind: enum)`, []ParserOption{IgnoreWhitespaces(), Keywords(map[string]TokenKind{"enum": -10})}, EnclosureParens(),
+			map[any]any{"outer": map[any]any{"inner": uint64(1), "deep": map[any]any{"x": "y"}}, "kind": "enum"}, "", "",
+		},
+		{
+			`{a: 1, b 2, c: 3}`, []ParserOption{IgnoreWhitespaces(), RecoverMalformed()}, EnclosureCurly(),
+			map[any]any{"a": uint64(1), "c": uint64(3)}, "", "",
+		},
+		{
+			`{a: 18446744073709551616, b: -0x8000000000000001}`, []ParserOption{IgnoreWhitespaces(), AllowBigNumbers()}, EnclosureCurly(),
+			map[any]any{"a": new(big.Int).Lsh(big.NewInt(1), 64), "b": big.NewInt(0).Sub(big.NewInt(-0x7fffffffffffffff), big.NewInt(2))}, "", "",
+		},
+		{
+			`{a: 0x1A2, b: 0xabc123, c: -0xf}`, []ParserOption{IgnoreWhitespaces(), PadOddHex()}, EnclosureCurly(),
+			map[any]any{"a": []byte{0x01, 0xa2}, "b": []byte{0xab, 0xc1, 0x23}, "c": int64(-15)}, "", "",
+		},
+		{
+			`{a: 0.1, b: 1.5M, c: 2, d: 1e-2}`, []ParserOption{IgnoreWhitespaces(), ScientificNumbers(), SuffixedNumbers(), ExactDecimals()}, EnclosureCurly(),
+			map[any]any{"a": big.NewRat(1, 10), "b": big.NewRat(1500000, 1), "c": uint64(2), "d": big.NewRat(1, 100)}, "", "",
+		},
+		{
+			`{a: 0.10, b: 2.5e3}`, []ParserOption{IgnoreWhitespaces(), ScientificNumbers(), ExactDecimals(), Decimals(func(digits string) (any, error) { return digits, nil })}, EnclosureCurly(),
+			map[any]any{"a": "0.1", "b": "2500"}, "", "",
+		},
+		{
+			`<a: <b: 1, c: 2>, d: 3>`, []ParserOption{IgnoreWhitespaces()}, EnclosureAngle(),
+			map[any]any{"a": map[any]any{"b": uint64(1), "c": uint64(2)}, "d": uint64(3)}, "", "",
+		},
+		{
+			`«a: «b: 1, c: «d: x»», e: 3»`, []ParserOption{IgnoreWhitespaces()}, mustEnclose(NewEnclosure('«', '»')),
+			map[any]any{"a": map[any]any{"b": uint64(1), "c": map[any]any{"d": "x"}}, "e": uint64(3)}, "", "",
+		},
+		{
+			`<%a: <%b: 1, c: 2%>, d: 3%>`, []ParserOption{IgnoreWhitespaces()}, mustEnclose(NewSequenceEnclosure("<%", "%>")),
+			map[any]any{"a": map[any]any{"b": uint64(1), "c": uint64(2)}, "d": uint64(3)}, "", "",
+		},
+		{
+			`{a: 1, a: 2}`, []ParserOption{IgnoreWhitespaces()}, EnclosureCurly(),
+			nil, "duplicate key in group: 'a'", "",
+		},
+		{
+			`{a: 18446744073709551616}`, []ParserOption{IgnoreWhitespaces()}, EnclosureCurly(),
+			nil, "invalid numeric token: strconv.ParseUint: parsing \"18446744073709551616\": value out of range", "",
+		},
+		{
+			`{a 1}`, nil, EnclosureCurly(),
+			nil, "missing pair separator <unicode:':'> after key: 'a'", "",
+		},
+		{
+			`{a: 1`, nil, EnclosureCurly(),
+			nil, "missing end of enclosure: '}'", "",
+		},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input, test.options...)
+		group, err := parser.KeyedGroup(test.enclose, ':', ',')
+
+		if test.error != "" {
+			assert.EqualError(t, err, test.error)
+			assert.Nil(t, group)
+			continue
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.output, group)
+		assert.Equal(t, test.unparsed, parser.Unparsed())
+	}
+}
+
+func TestPadHexToken(t *testing.T) {
+	tests := []struct {
+		input  Token
+		output string
+	}{
+		{Token{Kind: TokenHexNumber, Literal: "0x1A2"}, "0x01A2"},
+		{Token{Kind: TokenHexNumber, Literal: "0x1A"}, "0x1A"},
+		{Token{Kind: TokenHexNumber, Literal: "+0xf"}, "+0x0f"},
+		{Token{Kind: TokenHexNumber, Literal: "-0x123"}, "-0x0123"},
+		{Token{Kind: TokenNumber, Literal: "123"}, "123"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.output, padHexToken(test.input).Literal)
+	}
+}
+
+func TestParser_KeyedGroupLocated(t *testing.T) {
+	parser := NewParser(`{name: "alice", tags: {x: 0x01}, bad: a b, empty: }`, IgnoreWhitespaces())
+
+	group, err := parser.KeyedGroupLocated(EnclosureCurly(), ':', ',')
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[any]Located{
+		"name": {Token{TokenIdent, "name", 1, 5, nil}, "alice", 7, 14},
+		"tags": {Token{TokenIdent, "tags", 16, 20, nil}, map[any]Located{
+			"x": {Token{TokenIdent, "x", 23, 24, nil}, []byte{0x01}, 26, 30},
+		}, 22, 31},
+		"bad":   {Token{TokenIdent, "bad", 33, 36, nil}, "a b", 38, 41},
+		"empty": {Token{TokenIdent, "empty", 43, 48, nil}, nil, 48, 48},
+	}, group)
+
+	// Errors must be reported at the position of the bad value
+	_, err = NewParser(`{a: 1, b: 0x1}`, IgnoreWhitespaces()).KeyedGroupLocated(EnclosureCurly(), ':', ',')
+	assert.EqualError(t, err, "invalid hex token: encoding/hex: odd length hex string")
+	assert.Equal(t, 10, err.(*Error).Position)
+}
+
+func TestParser_Value(t *testing.T) {
+	tests := []struct {
+		input  string
+		opts   []ValueOption
+		value  any
+		err    error
+		errPos int
+		rest   string
+	}{
+		{"42", nil, uint64(42), nil, 0, ""},
+		{"42 ", []ValueOption{RejectTrailing()}, uint64(42), nil, 0, " "},
+		{`"a" b`, nil, "a", nil, 0, " b"},
+		{`"a" b`, []ValueOption{RejectTrailing()}, nil, ErrTrailingInput, 4, ""},
+		{"true,", []ValueOption{RejectTrailing()}, nil, ErrTrailingInput, 4, ""},
+		{"a", nil, nil, ErrUnexpectedToken, 0, ""},
+	}
+
+	for _, test := range tests {
+		parser := NewParser(test.input)
+
+		value, err := parser.Value(test.opts...)
+		if test.err != nil {
+			assert.ErrorIs(t, err, test.err, test.input)
+			assert.Equal(t, test.errPos, err.(*Error).Position, test.input)
+			continue
+		}
+
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.value, value, test.input)
+		assert.Equal(t, test.rest, parser.Unparsed(), test.input)
+	}
+}
+
+func TestParser_KeyedGroup_Trailing(t *testing.T) {
+	// The remainder is left in the parser by default
+	parser := NewParser("{a: 1} tail", IgnoreWhitespaces())
+
+	group, err := parser.KeyedGroup(EnclosureCurly(), ':', ',')
+	assert.NoError(t, err)
+	assert.Equal(t, map[any]any{"a": uint64(1)}, group)
+	assert.Equal(t, "tail", parser.Unparsed())
+
+	// The remainder is rejected with RejectTrailing
+	parser = NewParser("{a: 1} tail", IgnoreWhitespaces())
+
+	_, err = parser.KeyedGroup(EnclosureCurly(), ':', ',', RejectTrailing())
+	assert.ErrorIs(t, err, ErrTrailingInput)
+	assert.EqualError(t, err, "unexpected trailing input after value: <ident> 'tail'")
+	assert.Equal(t, 7, err.(*Error).Position)
+	assert.Len(t, parser.Errors(), 1)
+
+	located, err := NewParser("{a: 1}  ").KeyedGroupLocated(EnclosureCurly(), ':', ',', RejectTrailing())
+	assert.NoError(t, err)
+	assert.Len(t, located, 1)
+}